@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "worker_skills";
+DROP TABLE IF EXISTS "shift_skills";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "category_id";
+DROP TABLE IF EXISTS "skills";
+DROP TABLE IF EXISTS "categories";
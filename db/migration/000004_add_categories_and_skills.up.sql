@@ -0,0 +1,48 @@
+CREATE TABLE "categories" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL UNIQUE,
+  "description" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "skills" (
+  "id" bigserial PRIMARY KEY,
+  "category_id" bigint, -- Optional grouping, a skill can span categories
+  "name" varchar NOT NULL UNIQUE,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "skills" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL;
+
+-- Every shift may belong to one category
+ALTER TABLE "shifts" ADD COLUMN "category_id" bigint;
+ALTER TABLE "shifts" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL;
+CREATE INDEX ON "shifts" ("category_id");
+
+-- Skills a business requires for a shift
+CREATE TABLE "shift_skills" (
+  "shift_id" bigint NOT NULL,
+  "skill_id" bigint NOT NULL,
+  PRIMARY KEY ("shift_id", "skill_id")
+);
+
+ALTER TABLE "shift_skills" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "shift_skills" ADD FOREIGN KEY ("skill_id") REFERENCES "skills" ("id") ON DELETE CASCADE;
+
+-- Skills a worker declares on their profile
+CREATE TABLE "worker_skills" (
+  "worker_id" bigint NOT NULL,
+  "skill_id" bigint NOT NULL,
+  "level" varchar NOT NULL DEFAULT 'BEGINNER', -- BEGINNER, INTERMEDIATE, EXPERT
+  "years_experience" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("worker_id", "skill_id"),
+  CHECK ("level" IN ('BEGINNER', 'INTERMEDIATE', 'EXPERT')),
+  CHECK ("years_experience" >= 0)
+);
+
+ALTER TABLE "worker_skills" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "worker_skills" ADD FOREIGN KEY ("skill_id") REFERENCES "skills" ("id") ON DELETE CASCADE;
+
+-- Reverse lookup: which workers have a given skill
+CREATE INDEX ON "worker_skills" ("skill_id");
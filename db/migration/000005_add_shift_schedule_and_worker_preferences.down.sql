@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "worker_preferences";
+ALTER TABLE "shifts" DROP CONSTRAINT IF EXISTS "shifts_schedule_order";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "ends_at";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "starts_at";
@@ -0,0 +1,20 @@
+-- When the shift takes place (optional for shifts posted before scheduling existed)
+ALTER TABLE "shifts" ADD COLUMN "starts_at" timestamptz;
+ALTER TABLE "shifts" ADD COLUMN "ends_at" timestamptz;
+ALTER TABLE "shifts" ADD CONSTRAINT "shifts_schedule_order" CHECK ("ends_at" IS NULL OR "starts_at" IS NULL OR "ends_at" > "starts_at");
+
+CREATE INDEX ON "shifts" ("starts_at");
+
+-- What a worker is looking for, used by the recommendation feed
+CREATE TABLE "worker_preferences" (
+  "worker_id" bigint PRIMARY KEY,
+  "min_pay_rate" decimal(10, 2) NOT NULL DEFAULT 0,
+  "home_lat" float8,
+  "home_lng" float8,
+  "max_distance_km" float8 NOT NULL DEFAULT 25,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("min_pay_rate" >= 0),
+  CHECK ("max_distance_km" > 0)
+);
+
+ALTER TABLE "worker_preferences" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
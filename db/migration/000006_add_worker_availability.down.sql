@@ -0,0 +1,3 @@
+ALTER TABLE "worker_preferences" DROP COLUMN IF EXISTS "timezone";
+DROP TABLE IF EXISTS "worker_blackout_dates";
+DROP TABLE IF EXISTS "worker_availability_windows";
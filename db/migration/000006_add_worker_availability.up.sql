@@ -0,0 +1,27 @@
+-- Recurring weekly windows in which a worker can take shifts
+CREATE TABLE "worker_availability_windows" (
+  "id" bigserial PRIMARY KEY,
+  "worker_id" bigint NOT NULL,
+  "weekday" smallint NOT NULL, -- 0 = Sunday ... 6 = Saturday
+  "start_minute" int NOT NULL, -- Minutes after local midnight
+  "end_minute" int NOT NULL,
+  CHECK ("weekday" BETWEEN 0 AND 6),
+  CHECK ("start_minute" >= 0 AND "end_minute" <= 1440 AND "end_minute" > "start_minute")
+);
+
+ALTER TABLE "worker_availability_windows" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "worker_availability_windows" ("worker_id");
+
+-- One-off dates on which a worker is unavailable
+CREATE TABLE "worker_blackout_dates" (
+  "id" bigserial PRIMARY KEY,
+  "worker_id" bigint NOT NULL,
+  "date" date NOT NULL,
+  "reason" varchar NOT NULL DEFAULT ''
+);
+
+ALTER TABLE "worker_blackout_dates" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE UNIQUE INDEX ON "worker_blackout_dates" ("worker_id", "date");
+
+-- Windows and blackout dates are interpreted in the worker's timezone
+ALTER TABLE "worker_preferences" ADD COLUMN "timezone" varchar NOT NULL DEFAULT 'Asia/Jakarta';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "shifts_series_occurrence";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "is_exception";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "occurrence_date";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "series_id";
+DROP TABLE IF EXISTS "shift_series";
+DROP TABLE IF EXISTS "shift_templates";
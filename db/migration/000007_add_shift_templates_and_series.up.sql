@@ -0,0 +1,51 @@
+-- Reusable shift definitions ("Weekend kitchen helper")
+CREATE TABLE "shift_templates" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "title" varchar NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "pay_rate" decimal(10, 2) NOT NULL,
+  "lat" float8 NOT NULL,
+  "lng" float8 NOT NULL,
+  "category_id" bigint,
+  "skill_ids" bigint[] NOT NULL DEFAULT '{}',
+  "start_minute" int NOT NULL, -- Local start time, minutes after midnight
+  "duration_minutes" int NOT NULL,
+  "timezone" varchar NOT NULL DEFAULT 'Asia/Jakarta',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("pay_rate" > 0),
+  CHECK ("start_minute" >= 0 AND "start_minute" < 1440),
+  CHECK ("duration_minutes" > 0 AND "duration_minutes" <= 1440)
+);
+
+ALTER TABLE "shift_templates" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "shift_templates" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL;
+CREATE INDEX ON "shift_templates" ("owner_id");
+
+-- A recurrence rule applied to a template
+CREATE TABLE "shift_series" (
+  "id" bigserial PRIMARY KEY,
+  "template_id" bigint NOT NULL,
+  "owner_id" bigint NOT NULL,
+  "rrule" varchar NOT NULL, -- e.g. FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20261231 or ...;COUNT=10
+  "starts_on" date NOT NULL,
+  "materialised_until" date, -- Last date the scheduler has generated shifts for
+  "status" varchar NOT NULL DEFAULT 'ACTIVE', -- ACTIVE, ENDED, CANCELLED
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("status" IN ('ACTIVE', 'ENDED', 'CANCELLED'))
+);
+
+ALTER TABLE "shift_series" ADD FOREIGN KEY ("template_id") REFERENCES "shift_templates" ("id");
+ALTER TABLE "shift_series" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+CREATE INDEX ON "shift_series" ("owner_id");
+CREATE INDEX ON "shift_series" ("status");
+
+-- Materialised occurrences point back to their series
+ALTER TABLE "shifts" ADD COLUMN "series_id" bigint;
+ALTER TABLE "shifts" ADD COLUMN "occurrence_date" date;
+ALTER TABLE "shifts" ADD COLUMN "is_exception" boolean NOT NULL DEFAULT false;
+ALTER TABLE "shifts" ADD FOREIGN KEY ("series_id") REFERENCES "shift_series" ("id");
+
+-- One shift per series per day, so the scheduler can run repeatedly
+CREATE UNIQUE INDEX "shifts_series_occurrence" ON "shifts" ("series_id", "occurrence_date");
@@ -0,0 +1,26 @@
+-- Clock-in / clock-out records of accepted workers
+CREATE TABLE "attendance" (
+  "id" bigserial PRIMARY KEY,
+  "application_id" bigint NOT NULL UNIQUE,
+  "shift_id" bigint NOT NULL,
+  "worker_id" bigint NOT NULL,
+  "clock_in_at" timestamptz NOT NULL,
+  "clock_in_lat" float8 NOT NULL,
+  "clock_in_lng" float8 NOT NULL,
+  "clock_in_distance_m" float8 NOT NULL, -- Distance from the shift location
+  "clock_in_within_fence" boolean NOT NULL,
+  "late_minutes" int NOT NULL DEFAULT 0, -- Minutes after starts_at (0 within the grace period)
+  "clock_out_at" timestamptz,
+  "clock_out_lat" float8,
+  "clock_out_lng" float8,
+  "clock_out_distance_m" float8,
+  "clock_out_within_fence" boolean,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("clock_out_at" IS NULL OR "clock_out_at" >= "clock_in_at")
+);
+
+ALTER TABLE "attendance" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE CASCADE;
+ALTER TABLE "attendance" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "attendance" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id");
+CREATE INDEX ON "attendance" ("shift_id");
+CREATE INDEX ON "attendance" ("worker_id");
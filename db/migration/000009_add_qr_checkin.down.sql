@@ -0,0 +1 @@
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "clock_in_qr_verified";
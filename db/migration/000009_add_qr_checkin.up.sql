@@ -0,0 +1,2 @@
+-- Whether the worker scanned the rotating on-site QR code when clocking in
+ALTER TABLE "attendance" ADD COLUMN "clock_in_qr_verified" boolean NOT NULL DEFAULT false;
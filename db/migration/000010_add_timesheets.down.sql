@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "timesheet_history";
+DROP TABLE IF EXISTS "timesheets";
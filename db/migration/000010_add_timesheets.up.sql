@@ -0,0 +1,50 @@
+-- Worked time per accepted application, computed from attendance
+CREATE TABLE "timesheets" (
+  "id" bigserial PRIMARY KEY,
+  "application_id" bigint NOT NULL UNIQUE,
+  "attendance_id" bigint NOT NULL,
+  "shift_id" bigint NOT NULL,
+  "worker_id" bigint NOT NULL,
+  "business_id" bigint NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "ended_at" timestamptz NOT NULL,
+  "break_minutes" int NOT NULL DEFAULT 0, -- Unpaid
+  "worked_minutes" int NOT NULL,
+  "hourly_rate" decimal(10, 2) NOT NULL, -- Snapshot of the shift's pay rate
+  "pay_amount" decimal(12, 2) NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'PENDING', -- PENDING, EDITED, CONTESTED, DISPUTED, APPROVED
+  "note" text NOT NULL DEFAULT '', -- Reason given with the last edit, dispute or contest
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("ended_at" >= "started_at"),
+  CHECK ("break_minutes" >= 0 AND "worked_minutes" >= 0),
+  CHECK ("status" IN ('PENDING', 'EDITED', 'CONTESTED', 'DISPUTED', 'APPROVED'))
+);
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE CASCADE;
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("attendance_id") REFERENCES "attendance" ("id") ON DELETE CASCADE;
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id");
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("business_id") REFERENCES "users" ("id");
+CREATE INDEX ON "timesheets" ("worker_id");
+CREATE INDEX ON "timesheets" ("business_id", "status");
+
+-- Append-only audit trail of every timesheet change
+CREATE TABLE "timesheet_history" (
+  "id" bigserial PRIMARY KEY,
+  "timesheet_id" bigint NOT NULL,
+  "actor_id" bigint NOT NULL,
+  "action" varchar NOT NULL, -- CREATE, APPROVE, EDIT, DISPUTE, ACCEPT, CONTEST
+  "from_status" varchar NOT NULL DEFAULT '',
+  "to_status" varchar NOT NULL,
+  "started_at" timestamptz NOT NULL, -- Values after the change
+  "ended_at" timestamptz NOT NULL,
+  "break_minutes" int NOT NULL,
+  "worked_minutes" int NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "timesheet_history" ADD FOREIGN KEY ("timesheet_id") REFERENCES "timesheets" ("id") ON DELETE CASCADE;
+ALTER TABLE "timesheet_history" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
+CREATE INDEX ON "timesheet_history" ("timesheet_id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "worker_bank_accounts";
+DROP TABLE IF EXISTS "ledger_entries";
+DROP TABLE IF EXISTS "payout_batches";
+DROP TABLE IF EXISTS "ledger_transactions";
+DROP TABLE IF EXISTS "ledger_accounts";
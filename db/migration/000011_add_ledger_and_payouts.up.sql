@@ -0,0 +1,64 @@
+-- Double-entry ledger. Platform accounts use owner_id 0.
+CREATE TABLE "ledger_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL, -- WORKER_PAYABLE, BUSINESS_RECEIVABLE, PLATFORM_REVENUE, PLATFORM_ADJUSTMENTS, PAYOUT_CLEARING
+  "owner_id" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "ledger_accounts" ("kind", "owner_id");
+
+CREATE TABLE "ledger_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL, -- EARNING, FEE, ADJUSTMENT, PAYOUT
+  "timesheet_id" bigint,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_by" bigint, -- Admin who posted an adjustment
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("kind" IN ('EARNING', 'FEE', 'ADJUSTMENT', 'PAYOUT'))
+);
+
+ALTER TABLE "ledger_transactions" ADD FOREIGN KEY ("timesheet_id") REFERENCES "timesheets" ("id");
+ALTER TABLE "ledger_transactions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+-- A timesheet is posted at most once per kind
+CREATE UNIQUE INDEX "ledger_transactions_timesheet_kind" ON "ledger_transactions" ("timesheet_id", "kind") WHERE "timesheet_id" IS NOT NULL;
+
+CREATE TABLE "payout_batches" (
+  "id" bigserial PRIMARY KEY,
+  "bank_format" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'EXPORTED',
+  "total_amount" decimal(14, 2) NOT NULL,
+  "line_count" int NOT NULL,
+  "file_name" varchar NOT NULL,
+  "file_content" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- Debits are positive, credits negative; every transaction sums to zero
+CREATE TABLE "ledger_entries" (
+  "id" bigserial PRIMARY KEY,
+  "transaction_id" bigint NOT NULL,
+  "account_id" bigint NOT NULL,
+  "amount" decimal(14, 2) NOT NULL,
+  "payout_batch_id" bigint, -- Set once the entry has been paid out
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("amount" <> 0)
+);
+
+ALTER TABLE "ledger_entries" ADD FOREIGN KEY ("transaction_id") REFERENCES "ledger_transactions" ("id");
+ALTER TABLE "ledger_entries" ADD FOREIGN KEY ("account_id") REFERENCES "ledger_accounts" ("id");
+ALTER TABLE "ledger_entries" ADD FOREIGN KEY ("payout_batch_id") REFERENCES "payout_batches" ("id");
+CREATE INDEX ON "ledger_entries" ("account_id", "payout_batch_id");
+CREATE INDEX ON "ledger_entries" ("transaction_id");
+
+-- Where a worker's payouts go
+CREATE TABLE "worker_bank_accounts" (
+  "worker_id" bigint PRIMARY KEY,
+  "bank_code" varchar(3) NOT NULL, -- Indonesian bank code, e.g. 014 = BCA
+  "account_number" varchar NOT NULL,
+  "account_holder" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "worker_bank_accounts" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
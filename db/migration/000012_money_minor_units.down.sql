@@ -0,0 +1,30 @@
+ALTER TABLE "payout_batches" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "payout_batches" RENAME COLUMN "total_minor" TO "total_amount";
+ALTER TABLE "payout_batches" ALTER COLUMN "total_amount" TYPE decimal(14, 2) USING "total_amount" / 100.0;
+
+ALTER TABLE "ledger_entries" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "ledger_entries" RENAME COLUMN "amount_minor" TO "amount";
+ALTER TABLE "ledger_entries" ALTER COLUMN "amount" TYPE decimal(14, 2) USING "amount" / 100.0;
+
+ALTER TABLE "timesheets" DROP COLUMN IF EXISTS "pay_unit";
+ALTER TABLE "timesheets" DROP COLUMN IF EXISTS "pay_currency";
+ALTER TABLE "timesheets" RENAME COLUMN "pay_amount_minor" TO "pay_amount";
+ALTER TABLE "timesheets" ALTER COLUMN "pay_amount" TYPE decimal(12, 2) USING "pay_amount" / 100.0;
+ALTER TABLE "timesheets" RENAME COLUMN "pay_rate_minor" TO "hourly_rate";
+ALTER TABLE "timesheets" ALTER COLUMN "hourly_rate" TYPE decimal(10, 2) USING "hourly_rate" / 100.0;
+
+ALTER TABLE "worker_preferences" DROP COLUMN IF EXISTS "min_pay_currency";
+ALTER TABLE "worker_preferences" RENAME COLUMN "min_pay_rate_minor" TO "min_pay_rate";
+ALTER TABLE "worker_preferences" ALTER COLUMN "min_pay_rate" TYPE decimal(10, 2) USING "min_pay_rate" / 100.0;
+
+ALTER TABLE "shift_templates" DROP CONSTRAINT IF EXISTS "shift_templates_pay_unit";
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "pay_unit";
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "pay_currency";
+ALTER TABLE "shift_templates" RENAME COLUMN "pay_rate_minor" TO "pay_rate";
+ALTER TABLE "shift_templates" ALTER COLUMN "pay_rate" TYPE decimal(10, 2) USING "pay_rate" / 100.0;
+
+ALTER TABLE "shifts" DROP CONSTRAINT IF EXISTS "shifts_pay_unit";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "pay_unit";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "pay_currency";
+ALTER TABLE "shifts" RENAME COLUMN "pay_rate_minor" TO "pay_rate";
+ALTER TABLE "shifts" ALTER COLUMN "pay_rate" TYPE decimal(10, 2) USING "pay_rate" / 100.0;
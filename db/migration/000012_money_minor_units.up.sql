@@ -0,0 +1,37 @@
+-- Money is stored as whole minor units (sen for IDR) plus an ISO 4217 currency code.
+-- Existing decimal amounts are IDR and converted exactly (x100).
+
+-- Shifts: pay is hourly unless stated otherwise
+ALTER TABLE "shifts" ALTER COLUMN "pay_rate" TYPE bigint USING round("pay_rate" * 100)::bigint;
+ALTER TABLE "shifts" RENAME COLUMN "pay_rate" TO "pay_rate_minor";
+ALTER TABLE "shifts" ADD COLUMN "pay_currency" char(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE "shifts" ADD COLUMN "pay_unit" varchar NOT NULL DEFAULT 'HOURLY';
+ALTER TABLE "shifts" ADD CONSTRAINT "shifts_pay_unit" CHECK ("pay_unit" IN ('HOURLY', 'PER_SHIFT'));
+
+ALTER TABLE "shift_templates" ALTER COLUMN "pay_rate" TYPE bigint USING round("pay_rate" * 100)::bigint;
+ALTER TABLE "shift_templates" RENAME COLUMN "pay_rate" TO "pay_rate_minor";
+ALTER TABLE "shift_templates" ADD COLUMN "pay_currency" char(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE "shift_templates" ADD COLUMN "pay_unit" varchar NOT NULL DEFAULT 'HOURLY';
+ALTER TABLE "shift_templates" ADD CONSTRAINT "shift_templates_pay_unit" CHECK ("pay_unit" IN ('HOURLY', 'PER_SHIFT'));
+
+-- Worker preferences (hourly)
+ALTER TABLE "worker_preferences" ALTER COLUMN "min_pay_rate" TYPE bigint USING round("min_pay_rate" * 100)::bigint;
+ALTER TABLE "worker_preferences" RENAME COLUMN "min_pay_rate" TO "min_pay_rate_minor";
+ALTER TABLE "worker_preferences" ADD COLUMN "min_pay_currency" char(3) NOT NULL DEFAULT 'IDR';
+
+-- Timesheets snapshot the shift's rate and unit
+ALTER TABLE "timesheets" ALTER COLUMN "hourly_rate" TYPE bigint USING round("hourly_rate" * 100)::bigint;
+ALTER TABLE "timesheets" RENAME COLUMN "hourly_rate" TO "pay_rate_minor";
+ALTER TABLE "timesheets" ALTER COLUMN "pay_amount" TYPE bigint USING round("pay_amount" * 100)::bigint;
+ALTER TABLE "timesheets" RENAME COLUMN "pay_amount" TO "pay_amount_minor";
+ALTER TABLE "timesheets" ADD COLUMN "pay_currency" char(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE "timesheets" ADD COLUMN "pay_unit" varchar NOT NULL DEFAULT 'HOURLY';
+
+-- Ledger
+ALTER TABLE "ledger_entries" ALTER COLUMN "amount" TYPE bigint USING round("amount" * 100)::bigint;
+ALTER TABLE "ledger_entries" RENAME COLUMN "amount" TO "amount_minor";
+ALTER TABLE "ledger_entries" ADD COLUMN "currency" char(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE "payout_batches" ALTER COLUMN "total_amount" TYPE bigint USING round("total_amount" * 100)::bigint;
+ALTER TABLE "payout_batches" RENAME COLUMN "total_amount" TO "total_minor";
+ALTER TABLE "payout_batches" ADD COLUMN "currency" char(3) NOT NULL DEFAULT 'IDR';
@@ -0,0 +1,3 @@
+ALTER TABLE "timesheets" DROP COLUMN IF EXISTS "minimum_wage_applied";
+ALTER TABLE "timesheets" DROP COLUMN IF EXISTS "holiday_minutes";
+ALTER TABLE "timesheets" DROP COLUMN IF EXISTS "region_code";
@@ -0,0 +1,5 @@
+-- Wage rules applied to a timesheet: the region decides the minimum wage and
+-- which holidays count, holiday minutes are paid at the holiday multiplier
+ALTER TABLE "timesheets" ADD COLUMN "region_code" varchar NOT NULL DEFAULT '';
+ALTER TABLE "timesheets" ADD COLUMN "holiday_minutes" int NOT NULL DEFAULT 0;
+ALTER TABLE "timesheets" ADD COLUMN "minimum_wage_applied" boolean NOT NULL DEFAULT false;
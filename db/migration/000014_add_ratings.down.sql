@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "rating_count";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "rating_avg";
+DROP TABLE IF EXISTS "ratings";
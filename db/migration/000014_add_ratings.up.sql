@@ -0,0 +1,31 @@
+-- Post-shift ratings, one per direction per accepted application. A rating
+-- stays hidden (published_at NULL) until the other side has rated too or the
+-- rating window has closed.
+CREATE TABLE "ratings" (
+  "id" bigserial PRIMARY KEY,
+  "application_id" bigint NOT NULL,
+  "shift_id" bigint NOT NULL,
+  "rater_id" bigint NOT NULL,
+  "ratee_id" bigint NOT NULL,
+  "direction" varchar NOT NULL, -- WORKER_TO_BUSINESS, BUSINESS_TO_WORKER
+  "stars" smallint NOT NULL,
+  "tags" text[] NOT NULL DEFAULT '{}',
+  "comment" text NOT NULL DEFAULT '',
+  "deadline_at" timestamptz NOT NULL, -- Published on its own after this
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("stars" BETWEEN 1 AND 5),
+  CHECK ("direction" IN ('WORKER_TO_BUSINESS', 'BUSINESS_TO_WORKER')),
+  UNIQUE ("application_id", "direction")
+);
+
+ALTER TABLE "ratings" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE CASCADE;
+ALTER TABLE "ratings" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "ratings" ADD FOREIGN KEY ("rater_id") REFERENCES "users" ("id");
+ALTER TABLE "ratings" ADD FOREIGN KEY ("ratee_id") REFERENCES "users" ("id");
+CREATE INDEX ON "ratings" ("ratee_id") WHERE "published_at" IS NOT NULL;
+CREATE INDEX ON "ratings" ("deadline_at") WHERE "published_at" IS NULL;
+
+-- Aggregate of a user's published ratings, kept up to date on every reveal
+ALTER TABLE "users" ADD COLUMN "rating_avg" decimal(3, 2);
+ALTER TABLE "users" ADD COLUMN "rating_count" int NOT NULL DEFAULT 0;
@@ -0,0 +1,30 @@
+-- No-shows and late withdrawals. Each event carries strikes; enough strikes
+-- inside the rolling window suspend the worker from applying. Events stay
+-- after their shift or application is deleted so the history can't be wiped.
+CREATE TABLE "reliability_events" (
+  "id" bigserial PRIMARY KEY,
+  "worker_id" bigint NOT NULL,
+  "application_id" bigint,
+  "shift_id" bigint,
+  "kind" varchar NOT NULL, -- NO_SHOW, LATE_WITHDRAWAL
+  "strikes" int NOT NULL,
+  "occurred_at" timestamptz NOT NULL, -- Shift start for a no-show, withdrawal time otherwise
+  "detail" text NOT NULL DEFAULT '',
+  "appeal_status" varchar NOT NULL DEFAULT 'NONE', -- NONE, PENDING, UPHELD, OVERTURNED
+  "appeal_reason" text NOT NULL DEFAULT '',
+  "appeal_note" text NOT NULL DEFAULT '', -- Admin's answer
+  "appeal_decided_by" bigint,
+  "appeal_decided_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("kind" IN ('NO_SHOW', 'LATE_WITHDRAWAL')),
+  CHECK ("strikes" >= 0),
+  CHECK ("appeal_status" IN ('NONE', 'PENDING', 'UPHELD', 'OVERTURNED')),
+  UNIQUE ("application_id", "kind")
+);
+
+ALTER TABLE "reliability_events" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id");
+ALTER TABLE "reliability_events" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE SET NULL;
+ALTER TABLE "reliability_events" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE SET NULL;
+ALTER TABLE "reliability_events" ADD FOREIGN KEY ("appeal_decided_by") REFERENCES "users" ("id");
+CREATE INDEX ON "reliability_events" ("worker_id", "created_at");
+CREATE INDEX ON "reliability_events" ("appeal_status") WHERE "appeal_status" = 'PENDING';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "ledger_transactions_cancellation_kind";
+ALTER TABLE "ledger_transactions" DROP COLUMN IF EXISTS "cancellation_id";
+DELETE FROM "ledger_entries" WHERE "transaction_id" IN (SELECT "id" FROM "ledger_transactions" WHERE "kind" = 'COMPENSATION');
+DELETE FROM "ledger_transactions" WHERE "kind" = 'COMPENSATION';
+ALTER TABLE "ledger_transactions" DROP CONSTRAINT IF EXISTS "ledger_transactions_kind_check";
+ALTER TABLE "ledger_transactions" ADD CONSTRAINT "ledger_transactions_kind_check"
+  CHECK ("kind" IN ('EARNING', 'FEE', 'ADJUSTMENT', 'PAYOUT'));
+DROP TABLE IF EXISTS "shift_cancellations";
@@ -0,0 +1,34 @@
+-- Cancellations of accepted work: a business cancelling a shift (one row per
+-- accepted worker, or a single row if nobody was accepted yet) or a worker
+-- cancelling their spot
+CREATE TABLE "shift_cancellations" (
+  "id" bigserial PRIMARY KEY,
+  "shift_id" bigint NOT NULL,
+  "application_id" bigint,
+  "worker_id" bigint, -- The worker whose spot was cancelled
+  "cancelled_by" bigint NOT NULL,
+  "side" varchar NOT NULL, -- BUSINESS, WORKER
+  "reason" text NOT NULL,
+  "notice_minutes" int, -- Time left before the start (NULL for unscheduled shifts)
+  "within_cutoff" boolean NOT NULL DEFAULT false,
+  "compensation_minor" bigint NOT NULL DEFAULT 0, -- Owed by the business to the worker
+  "compensation_currency" varchar(3) NOT NULL DEFAULT 'IDR',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("side" IN ('BUSINESS', 'WORKER')),
+  CHECK ("reason" <> ''),
+  CHECK ("compensation_minor" >= 0)
+);
+
+ALTER TABLE "shift_cancellations" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "shift_cancellations" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE SET NULL;
+ALTER TABLE "shift_cancellations" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id");
+ALTER TABLE "shift_cancellations" ADD FOREIGN KEY ("cancelled_by") REFERENCES "users" ("id");
+CREATE INDEX ON "shift_cancellations" ("shift_id");
+
+-- Late cancellation compensation is posted to the ledger once per cancellation
+ALTER TABLE "ledger_transactions" DROP CONSTRAINT IF EXISTS "ledger_transactions_kind_check";
+ALTER TABLE "ledger_transactions" ADD CONSTRAINT "ledger_transactions_kind_check"
+  CHECK ("kind" IN ('EARNING', 'FEE', 'ADJUSTMENT', 'PAYOUT', 'COMPENSATION'));
+ALTER TABLE "ledger_transactions" ADD COLUMN "cancellation_id" bigint;
+ALTER TABLE "ledger_transactions" ADD FOREIGN KEY ("cancellation_id") REFERENCES "shift_cancellations" ("id");
+CREATE UNIQUE INDEX "ledger_transactions_cancellation_kind" ON "ledger_transactions" ("cancellation_id", "kind") WHERE "cancellation_id" IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_status_check";
+ALTER TABLE "shifts" DROP CONSTRAINT IF EXISTS "shifts_status_check";
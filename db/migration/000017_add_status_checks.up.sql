@@ -0,0 +1,7 @@
+-- Shift and application statuses follow the lifecycles in
+-- internal/core/service/lifecycle_service.go; the database only accepts
+-- statuses those lifecycles know about
+ALTER TABLE "shifts" ADD CONSTRAINT "shifts_status_check"
+  CHECK ("status" IN ('OPEN', 'FILLED', 'CANCELLED'));
+ALTER TABLE "applications" ADD CONSTRAINT "applications_status_check"
+  CHECK ("status" IN ('PENDING', 'ACCEPTED', 'REJECTED', 'CANCELLED'));
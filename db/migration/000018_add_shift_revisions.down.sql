@@ -0,0 +1,3 @@
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "reconfirm_by";
+DROP TABLE IF EXISTS "shift_revisions";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "version";
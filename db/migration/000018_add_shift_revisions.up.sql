@@ -0,0 +1,32 @@
+-- Every edit of a shift is kept as a numbered snapshot. Version 1 is the shift
+-- as posted and is written on its first edit.
+ALTER TABLE "shifts" ADD COLUMN "version" int NOT NULL DEFAULT 1;
+
+CREATE TABLE "shift_revisions" (
+  "id" bigserial PRIMARY KEY,
+  "shift_id" bigint NOT NULL,
+  "version" int NOT NULL,
+  "edited_by" bigint NOT NULL,
+  "title" varchar NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "pay_rate_minor" bigint NOT NULL,
+  "pay_currency" varchar(3) NOT NULL,
+  "pay_unit" varchar NOT NULL,
+  "lat" float8 NOT NULL,
+  "lng" float8 NOT NULL,
+  "category_id" bigint,
+  "starts_at" timestamptz,
+  "ends_at" timestamptz,
+  "material_changes" text[] NOT NULL DEFAULT '{}', -- PAY_DOWN, MOVED, RESCHEDULED
+  "reconfirm_by" timestamptz, -- Set when applicants were asked to re-confirm
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("shift_id", "version")
+);
+
+ALTER TABLE "shift_revisions" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "shift_revisions" ADD FOREIGN KEY ("edited_by") REFERENCES "users" ("id");
+
+-- PENDING and ACCEPTED applicants must re-confirm a material change before
+-- this deadline or their application is cancelled
+ALTER TABLE "applications" ADD COLUMN "reconfirm_by" timestamptz;
+CREATE INDEX ON "applications" ("reconfirm_by") WHERE "reconfirm_by" IS NOT NULL;
@@ -0,0 +1,6 @@
+UPDATE "applications" SET "status" = 'PENDING' WHERE "status" IN ('STANDBY', 'OFFERED');
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "offer_expires_at";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "standby_rank";
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_status_check";
+ALTER TABLE "applications" ADD CONSTRAINT "applications_status_check"
+  CHECK ("status" IN ('PENDING', 'ACCEPTED', 'REJECTED', 'CANCELLED'));
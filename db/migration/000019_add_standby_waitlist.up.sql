@@ -0,0 +1,10 @@
+-- Standby waitlist: the business ranks applicants as STANDBY; when a spot
+-- frees up the best ranked one is OFFERED it until offer_expires_at
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_status_check";
+ALTER TABLE "applications" ADD CONSTRAINT "applications_status_check"
+  CHECK ("status" IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED', 'REJECTED', 'CANCELLED'));
+
+ALTER TABLE "applications" ADD COLUMN "standby_rank" int; -- 1 = offered first
+ALTER TABLE "applications" ADD COLUMN "offer_expires_at" timestamptz;
+CREATE INDEX ON "applications" ("shift_id", "standby_rank") WHERE "status" = 'STANDBY';
+CREATE INDEX ON "applications" ("offer_expires_at") WHERE "status" = 'OFFERED';
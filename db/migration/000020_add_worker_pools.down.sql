@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "shift_invites";
+DROP TABLE IF EXISTS "pool_members";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "release_at";
+ALTER TABLE "shifts" DROP CONSTRAINT IF EXISTS "shifts_visibility_check";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "visibility";
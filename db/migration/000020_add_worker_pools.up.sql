@@ -0,0 +1,32 @@
+-- Who may see a shift: PUBLIC (everyone), POOL (the business's worker pool
+-- and invited workers) or INVITE (invited workers only). A restricted shift
+-- still unfilled at release_at becomes PUBLIC.
+ALTER TABLE "shifts" ADD COLUMN "visibility" varchar NOT NULL DEFAULT 'PUBLIC';
+ALTER TABLE "shifts" ADD CONSTRAINT "shifts_visibility_check"
+  CHECK ("visibility" IN ('PUBLIC', 'POOL', 'INVITE'));
+ALTER TABLE "shifts" ADD COLUMN "release_at" timestamptz;
+CREATE INDEX ON "shifts" ("release_at") WHERE "release_at" IS NOT NULL;
+
+-- A business's preferred workers (favourites)
+CREATE TABLE "pool_members" (
+  "business_id" bigint NOT NULL,
+  "worker_id" bigint NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("business_id", "worker_id")
+);
+
+ALTER TABLE "pool_members" ADD FOREIGN KEY ("business_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "pool_members" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "pool_members" ("worker_id");
+
+CREATE TABLE "shift_invites" (
+  "shift_id" bigint NOT NULL,
+  "worker_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("shift_id", "worker_id")
+);
+
+ALTER TABLE "shift_invites" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+ALTER TABLE "shift_invites" ADD FOREIGN KEY ("worker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "shift_invites" ("worker_id");
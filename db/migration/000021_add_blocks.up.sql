@@ -0,0 +1,15 @@
+-- A block works both ways: the worker no longer sees or applies to the
+-- business's shifts and the business can no longer invite or pool them
+CREATE TABLE "blocks" (
+  "id" bigserial PRIMARY KEY,
+  "blocker_id" bigint NOT NULL,
+  "blocked_id" bigint NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("blocker_id", "blocked_id"),
+  CHECK ("blocker_id" <> "blocked_id")
+);
+
+ALTER TABLE "blocks" ADD FOREIGN KEY ("blocker_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "blocks" ADD FOREIGN KEY ("blocked_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "blocks" ("blocked_id");
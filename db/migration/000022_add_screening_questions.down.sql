@@ -0,0 +1,3 @@
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "cover_note";
+DROP TABLE IF EXISTS "application_answers";
+DROP TABLE IF EXISTS "screening_questions";
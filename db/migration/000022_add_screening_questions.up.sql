@@ -0,0 +1,29 @@
+-- Questions a business asks everyone applying to a shift. An answer listed in
+-- knockout_answers rejects the application straight away.
+CREATE TABLE "screening_questions" (
+  "id" bigserial PRIMARY KEY,
+  "shift_id" bigint NOT NULL,
+  "position" int NOT NULL,
+  "kind" varchar NOT NULL,
+  "prompt" varchar NOT NULL,
+  "options" text[] NOT NULL DEFAULT '{}',
+  "knockout_answers" text[] NOT NULL DEFAULT '{}',
+  "required" boolean NOT NULL DEFAULT true,
+  UNIQUE ("shift_id", "position"),
+  CONSTRAINT "screening_questions_kind_check" CHECK ("kind" IN ('YES_NO', 'MULTIPLE_CHOICE', 'FREE_TEXT'))
+);
+
+ALTER TABLE "screening_questions" ADD FOREIGN KEY ("shift_id") REFERENCES "shifts" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "application_answers" (
+  "application_id" bigint NOT NULL,
+  "question_id" bigint NOT NULL,
+  "answer" varchar NOT NULL,
+  "knockout" boolean NOT NULL DEFAULT false,
+  PRIMARY KEY ("application_id", "question_id")
+);
+
+ALTER TABLE "application_answers" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE CASCADE;
+ALTER TABLE "application_answers" ADD FOREIGN KEY ("question_id") REFERENCES "screening_questions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "applications" ADD COLUMN "cover_note" varchar NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+DELETE FROM "applications" WHERE "status" = 'WITHDRAWN';
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "decided_at";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "reason_note";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "reason_code";
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_status_check";
+ALTER TABLE "applications" ADD CONSTRAINT "applications_status_check"
+  CHECK ("status" IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED', 'REJECTED', 'CANCELLED'));
@@ -0,0 +1,10 @@
+-- Workers withdraw applications instead of deleting them, and rejections and
+-- withdrawals carry a reason code (see entity.ApplicationReasons) and note
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_status_check";
+ALTER TABLE "applications" ADD CONSTRAINT "applications_status_check"
+  CHECK ("status" IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED', 'REJECTED', 'CANCELLED', 'WITHDRAWN'));
+
+ALTER TABLE "applications" ADD COLUMN "reason_code" varchar NOT NULL DEFAULT '';
+ALTER TABLE "applications" ADD COLUMN "reason_note" varchar NOT NULL DEFAULT '';
+ALTER TABLE "applications" ADD COLUMN "decided_at" timestamptz;
+CREATE INDEX ON "applications" ("decided_at") WHERE "status" IN ('REJECTED', 'WITHDRAWN');
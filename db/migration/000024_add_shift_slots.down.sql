@@ -0,0 +1,2 @@
+ALTER TABLE "shifts" DROP CONSTRAINT IF EXISTS "shifts_slots_check";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "slots";
@@ -0,0 +1,3 @@
+-- How many workers a shift takes. It stays OPEN until that many are accepted.
+ALTER TABLE "shifts" ADD COLUMN "slots" int NOT NULL DEFAULT 1;
+ALTER TABLE "shifts" ADD CONSTRAINT "shifts_slots_check" CHECK ("slots" >= 1);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "rate_offers";
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_rate_turn_check";
+ALTER TABLE "applications" DROP CONSTRAINT IF EXISTS "applications_rate_check";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "rate_turn";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "rate_currency";
+ALTER TABLE "applications" DROP COLUMN IF EXISTS "rate_minor";
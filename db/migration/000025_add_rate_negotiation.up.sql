@@ -0,0 +1,29 @@
+-- A worker may apply asking for a different rate and the business may counter.
+-- The rate on the table lives on the application: rate_turn is the side that
+-- has to answer it, and once nobody has to it is agreed. Every step is kept in
+-- rate_offers.
+ALTER TABLE "applications" ADD COLUMN "rate_minor" bigint;
+ALTER TABLE "applications" ADD COLUMN "rate_currency" char(3);
+ALTER TABLE "applications" ADD COLUMN "rate_turn" varchar;
+ALTER TABLE "applications" ADD CONSTRAINT "applications_rate_check"
+  CHECK (("rate_minor" IS NULL) = ("rate_currency" IS NULL) AND ("rate_minor" IS NULL OR "rate_minor" > 0));
+ALTER TABLE "applications" ADD CONSTRAINT "applications_rate_turn_check"
+  CHECK ("rate_turn" IN ('WORKER', 'BUSINESS') AND "rate_minor" IS NOT NULL OR "rate_turn" IS NULL);
+
+CREATE TABLE "rate_offers" (
+  "id" bigserial PRIMARY KEY,
+  "application_id" bigint NOT NULL,
+  "author_id" bigint NOT NULL,
+  "side" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "rate_minor" bigint,
+  "rate_currency" char(3),
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "rate_offers_side_check" CHECK ("side" IN ('WORKER', 'BUSINESS')),
+  CONSTRAINT "rate_offers_action_check" CHECK ("action" IN ('PROPOSE', 'COUNTER', 'ACCEPT', 'DECLINE'))
+);
+
+ALTER TABLE "rate_offers" ADD FOREIGN KEY ("application_id") REFERENCES "applications" ("id") ON DELETE CASCADE;
+ALTER TABLE "rate_offers" ADD FOREIGN KEY ("author_id") REFERENCES "users" ("id");
+CREATE INDEX ON "rate_offers" ("application_id", "created_at");
@@ -0,0 +1,2 @@
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "event_id";
+DROP TABLE IF EXISTS "events";
@@ -0,0 +1,21 @@
+-- An event staffs several positions at once (e.g. 10 waiters, 2 bartenders and
+-- a supervisor for a wedding). Each position is a shift of the event with its
+-- own headcount (slots), pay, required skills and applications.
+CREATE TABLE "events" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "title" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "lat" float8 NOT NULL,
+  "lng" float8 NOT NULL,
+  "starts_at" timestamptz,
+  "ends_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "events" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+CREATE INDEX ON "events" ("owner_id");
+
+ALTER TABLE "shifts" ADD COLUMN "event_id" bigint;
+ALTER TABLE "shifts" ADD FOREIGN KEY ("event_id") REFERENCES "events" ("id");
+CREATE INDEX ON "shifts" ("event_id") WHERE "event_id" IS NOT NULL;
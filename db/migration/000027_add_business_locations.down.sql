@@ -0,0 +1,3 @@
+ALTER TABLE "events" DROP COLUMN IF EXISTS "location_id";
+ALTER TABLE "shifts" DROP COLUMN IF EXISTS "location_id";
+DROP TABLE IF EXISTS "business_locations";
@@ -0,0 +1,26 @@
+-- A business saves its work sites once (a chain posts to the same outlets over
+-- and over). Shifts and events at a saved site reference it and take its
+-- coordinates; moving the site moves its OPEN shifts with it.
+CREATE TABLE "business_locations" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "address" varchar NOT NULL DEFAULT '',
+  "lat" float8 NOT NULL,
+  "lng" float8 NOT NULL,
+  "instructions" varchar NOT NULL DEFAULT '',
+  "contact_name" varchar NOT NULL DEFAULT '',
+  "contact_phone" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "business_locations" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+CREATE UNIQUE INDEX ON "business_locations" ("owner_id", lower("name"));
+
+ALTER TABLE "shifts" ADD COLUMN "location_id" bigint;
+ALTER TABLE "shifts" ADD FOREIGN KEY ("location_id") REFERENCES "business_locations" ("id");
+CREATE INDEX ON "shifts" ("location_id") WHERE "location_id" IS NOT NULL;
+
+ALTER TABLE "events" ADD COLUMN "location_id" bigint;
+ALTER TABLE "events" ADD FOREIGN KEY ("location_id") REFERENCES "business_locations" ("id");
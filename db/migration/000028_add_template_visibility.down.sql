@@ -0,0 +1,6 @@
+ALTER TABLE "shift_templates" DROP CONSTRAINT IF EXISTS "shift_templates_slots_check";
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "slots";
+ALTER TABLE "shift_templates" DROP CONSTRAINT IF EXISTS "shift_templates_release_check";
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "release_after_hours";
+ALTER TABLE "shift_templates" DROP CONSTRAINT IF EXISTS "shift_templates_visibility_check";
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "visibility";
@@ -0,0 +1,10 @@
+-- Recurring shifts can be restricted to the business's pool, released to
+-- everyone later and need several workers, like one-off shifts. Invites are
+-- made per shift, so a template can't be INVITE only.
+ALTER TABLE "shift_templates" ADD COLUMN "visibility" varchar NOT NULL DEFAULT 'PUBLIC';
+ALTER TABLE "shift_templates" ADD CONSTRAINT "shift_templates_visibility_check"
+  CHECK ("visibility" IN ('PUBLIC', 'POOL'));
+ALTER TABLE "shift_templates" ADD COLUMN "release_after_hours" float8 NOT NULL DEFAULT 0;
+ALTER TABLE "shift_templates" ADD CONSTRAINT "shift_templates_release_check" CHECK ("release_after_hours" >= 0);
+ALTER TABLE "shift_templates" ADD COLUMN "slots" int NOT NULL DEFAULT 1;
+ALTER TABLE "shift_templates" ADD CONSTRAINT "shift_templates_slots_check" CHECK ("slots" >= 1);
@@ -0,0 +1 @@
+ALTER TABLE "shift_templates" DROP COLUMN IF EXISTS "location_id";
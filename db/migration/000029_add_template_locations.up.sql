@@ -0,0 +1,6 @@
+-- Templates are posted at a saved work site like one-off shifts, so moving the
+-- site moves the shifts its series create. Older templates keep their
+-- coordinates until they are edited.
+ALTER TABLE "shift_templates" ADD COLUMN "location_id" bigint;
+ALTER TABLE "shift_templates" ADD FOREIGN KEY ("location_id") REFERENCES "business_locations" ("id");
+CREATE INDEX ON "shift_templates" ("location_id") WHERE "location_id" IS NOT NULL;
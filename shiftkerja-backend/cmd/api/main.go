@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
+	_ "time/tzdata" // Availability calendars need timezones even on minimal images
 
 	"shiftkerja-backend/internal/adapter/handler"
+	"shiftkerja-backend/internal/adapter/payout"
 	"shiftkerja-backend/internal/adapter/repository"
+	"shiftkerja-backend/internal/adapter/wagerules"
 	"shiftkerja-backend/internal/core/service"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -56,20 +61,167 @@ func main() {
 	redisRepo := repository.NewRedisGeoRepo(rdb)
 	pgShiftRepo := repository.NewPostgresShiftRepo(pool)
 	userRepo := repository.NewPostgresUserRepo(pool)
+	taxonomyRepo := repository.NewPostgresTaxonomyRepo(pool)
+	workerProfileRepo := repository.NewPostgresWorkerProfileRepo(pool)
+	workerStatsRepo := repository.NewPostgresWorkerStatsRepo(pool)
+	templateRepo := repository.NewPostgresTemplateRepo(pool)
+	attendanceRepo := repository.NewPostgresAttendanceRepo(pool)
+	timesheetRepo := repository.NewPostgresTimesheetRepo(pool)
+	ledgerRepo := repository.NewPostgresLedgerRepo(pool)
+	ratingRepo := repository.NewPostgresRatingRepo(pool)
+	reliabilityRepo := repository.NewPostgresReliabilityRepo(pool)
+	cancellationRepo := repository.NewPostgresCancellationRepo(pool)
+	revisionRepo := repository.NewPostgresShiftRevisionRepo(pool)
+	waitlistRepo := repository.NewPostgresWaitlistRepo(pool)
+	poolRepo := repository.NewPostgresPoolRepo(pool)
+	blockRepo := repository.NewPostgresBlockRepo(pool)
+	screeningRepo := repository.NewPostgresScreeningRepo(pool)
+	analyticsRepo := repository.NewPostgresAnalyticsRepo(pool)
+	negotiationRepo := repository.NewPostgresNegotiationRepo(pool)
+	eventRepo := repository.NewPostgresEventRepo(pool)
+	locationRepo := repository.NewPostgresLocationRepo(pool)
+
+	// Minimum wage regions and public holidays, e.g. WAGE_RULES_DIR=/etc/shiftkerja/wage-rules
+	wageRules, err := wagerules.Load(os.Getenv("WAGE_RULES_DIR"), envFloat("HOLIDAY_PAY_MULTIPLIER", 0))
+	if err != nil {
+		fmt.Printf("❌ Unable to load wage rules: %v\n", err)
+		os.Exit(1)
+	}
+	thisYear := time.Now().Year()
+	if missing := wageRules.MissingYears(thisYear, thisYear+1); len(missing) > 0 {
+		fmt.Printf("⚠️ Holiday calendar doesn't cover %v: shifts then get no holiday pay until holidays.json is refreshed\n", missing)
+	}
 
 	// --- 4. SERVICES (Business Logic Layer) ---
-	shiftService := service.NewShiftService(pgShiftRepo, redisRepo)
+	wageConfig := service.DefaultWageRuleConfig()
+	wageConfig.EnforceMinimum = os.Getenv("ENFORCE_MINIMUM_WAGE") != "false"
+	wageService := service.NewWageRuleService(wageRules, wageConfig)
 
-	// --- 5. HANDLERS & ROUTES ---
+	// No-show detection and strike policy, e.g. SUSPENSION_THRESHOLDS=3:168h,5:720h
+	reliabilityConfig := service.DefaultReliabilityConfig()
+	reliabilityConfig.NoShowGrace = time.Duration(envFloat("NO_SHOW_GRACE_MINUTES", reliabilityConfig.NoShowGrace.Minutes())) * time.Minute
+	reliabilityConfig.LateWithdrawalCutoff = time.Duration(envFloat("LATE_WITHDRAWAL_HOURS", reliabilityConfig.LateWithdrawalCutoff.Hours()) * float64(time.Hour))
+	reliabilityConfig.Window = time.Duration(envFloat("RELIABILITY_WINDOW_DAYS", reliabilityConfig.Window.Hours()/24) * float64(24*time.Hour))
+	reliabilityConfig.NoShowStrikes = int(envFloat("NO_SHOW_STRIKES", float64(reliabilityConfig.NoShowStrikes)))
+	reliabilityConfig.LateWithdrawalStrikes = int(envFloat("LATE_WITHDRAWAL_STRIKES", float64(reliabilityConfig.LateWithdrawalStrikes)))
+	if raw := os.Getenv("SUSPENSION_THRESHOLDS"); raw != "" {
+		thresholds, err := service.ParseSuspensionThresholds(raw)
+		if err != nil {
+			fmt.Printf("❌ Invalid SUSPENSION_THRESHOLDS: %v\n", err)
+			os.Exit(1)
+		}
+		reliabilityConfig.Suspensions = thresholds
+	}
+	reliabilityService := service.NewReliabilityService(reliabilityRepo, reliabilityConfig)
 
-	// WebSocket Hub (created first to pass to handlers)
+	// Every shift and application status change goes through the lifecycle
+	lifecycleService := service.NewLifecycleService(pgShiftRepo, redisRepo, taxonomyRepo)
+
+	// WebSocket Hub (created before the services that notify from background jobs)
 	wsHub := handler.NewHub()
 
+	// Two-way block lists between workers and businesses
+	blockService := service.NewBlockService(blockRepo, userRepo)
+
+	// Screening questions asked of every applicant, with knockout answers
+	screeningService := service.NewScreeningService(screeningRepo, pgShiftRepo)
+	negotiationService := service.NewNegotiationService(negotiationRepo, pgShiftRepo, wageService)
+
+	// Worker pools, invites and who may see each shift
+	poolService := service.NewPoolService(poolRepo, pgShiftRepo, userRepo, lifecycleService, blockService, wsHub)
+
+	// Material shift edits, e.g. MATERIAL_MOVE_KM=2 RECONFIRM_WINDOW_HOURS=24
+	revisionConfig := service.DefaultRevisionConfig()
+	revisionConfig.MaterialDistanceKm = envFloat("MATERIAL_MOVE_KM", revisionConfig.MaterialDistanceKm)
+	revisionConfig.ReconfirmWindow = time.Duration(envFloat("RECONFIRM_WINDOW_HOURS", revisionConfig.ReconfirmWindow.Hours()) * float64(time.Hour))
+	revisionService := service.NewShiftRevisionService(revisionRepo, pgShiftRepo, lifecycleService, revisionConfig)
+
+	shiftService := service.NewShiftService(pgShiftRepo, redisRepo, taxonomyRepo, workerProfileRepo, ratingRepo, eventRepo, locationRepo, wageService, reliabilityService, lifecycleService, revisionService, poolService, blockService, screeningService, negotiationService)
+	eventService := service.NewEventService(eventRepo, taxonomyRepo, shiftService, poolService)
+	locationService := service.NewLocationService(locationRepo, wageService, revisionService, lifecycleService)
+	taxonomyService := service.NewTaxonomyService(taxonomyRepo)
+	workerProfileService := service.NewWorkerProfileService(workerProfileRepo)
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+
+	// Recommendation weights can be tuned without a rebuild, e.g. MATCH_WEIGHT_PAY=0.4
+	defaults := service.DefaultMatchWeights()
+	matchWeights := service.MatchWeights{
+		Distance:     envFloat("MATCH_WEIGHT_DISTANCE", defaults.Distance),
+		Pay:          envFloat("MATCH_WEIGHT_PAY", defaults.Pay),
+		Skills:       envFloat("MATCH_WEIGHT_SKILLS", defaults.Skills),
+		History:      envFloat("MATCH_WEIGHT_HISTORY", defaults.History),
+		Availability: envFloat("MATCH_WEIGHT_AVAILABILITY", defaults.Availability),
+	}
+	matchingService := service.NewMatchingService(pgShiftRepo, redisRepo, taxonomyRepo, workerProfileRepo, poolService, matchWeights)
+	rankingService := service.NewApplicantRankingService(pgShiftRepo, taxonomyRepo, workerProfileRepo, workerStatsRepo, service.DefaultApplicantWeights())
+	seriesService := service.NewSeriesService(templateRepo, pgShiftRepo, redisRepo, taxonomyRepo, locationRepo, wageService, lifecycleService, service.DefaultMaterialiseHorizon)
+
+	// Geofence radius and late grace period, e.g. GEOFENCE_RADIUS_M=300
+	attendanceConfig := service.DefaultAttendanceConfig()
+	attendanceConfig.GeofenceRadiusM = envFloat("GEOFENCE_RADIUS_M", attendanceConfig.GeofenceRadiusM)
+	attendanceConfig.LateGrace = time.Duration(envFloat("LATE_GRACE_MINUTES", attendanceConfig.LateGrace.Minutes())) * time.Minute
+	attendanceConfig.RequireQR = os.Getenv("REQUIRE_QR_CHECKIN") == "true"
+	attendanceConfig.QRSecret = []byte(os.Getenv("QR_CHECKIN_SECRET"))
+	if len(attendanceConfig.QRSecret) == 0 {
+		attendanceConfig.QRSecret = make([]byte, 32)
+		if _, err := rand.Read(attendanceConfig.QRSecret); err != nil {
+			fmt.Printf("❌ Unable to generate QR check-in secret: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("⚠️ QR_CHECKIN_SECRET not set, using a random key (codes change on restart)")
+	}
+	attendanceService := service.NewAttendanceService(attendanceRepo, pgShiftRepo, timesheetRepo, wageService, attendanceConfig)
+
+	// Platform fee and payout file layout, e.g. PLATFORM_FEE_RATE=0.08 PAYOUT_BANK_FORMAT=bca
+	ledgerConfig := service.DefaultLedgerConfig()
+	ledgerConfig.FeeRate = envFloat("PLATFORM_FEE_RATE", ledgerConfig.FeeRate)
+	if format := os.Getenv("PAYOUT_BANK_FORMAT"); format != "" {
+		ledgerConfig.BankFormat = format
+	}
+	ledgerConfig.ExportDir = os.Getenv("PAYOUT_EXPORT_DIR")
+	payoutFormats := payout.Formats()
+	if _, ok := payoutFormats[ledgerConfig.BankFormat]; !ok {
+		fmt.Printf("❌ Unknown PAYOUT_BANK_FORMAT %q\n", ledgerConfig.BankFormat)
+		os.Exit(1)
+	}
+	ledgerService := service.NewLedgerService(ledgerRepo, payoutFormats, ledgerConfig)
+	timesheetService := service.NewTimesheetService(timesheetRepo, ledgerService, wageService)
+
+	// Days after the work ends that both sides can rate, e.g. RATING_WINDOW_DAYS=7
+	ratingConfig := service.DefaultRatingConfig()
+	ratingConfig.Window = time.Duration(envFloat("RATING_WINDOW_DAYS", ratingConfig.Window.Hours()/24) * float64(24*time.Hour))
+	ratingService := service.NewRatingService(ratingRepo, pgShiftRepo, ratingConfig)
+
+	// Late business cancellations, e.g. BUSINESS_CANCEL_CUTOFF_HOURS=12 CANCEL_COMPENSATION_RATE=0.5
+	cancellationConfig := service.DefaultCancellationConfig()
+	cancellationConfig.BusinessCutoff = time.Duration(envFloat("BUSINESS_CANCEL_CUTOFF_HOURS", cancellationConfig.BusinessCutoff.Hours()) * float64(time.Hour))
+	cancellationConfig.CompensationRate = envFloat("CANCEL_COMPENSATION_RATE", cancellationConfig.CompensationRate)
+	cancellationService := service.NewCancellationService(cancellationRepo, pgShiftRepo, lifecycleService, ledgerService, reliabilityService, cancellationConfig)
+
+	// Standby offers, e.g. STANDBY_OFFER_MINUTES=30
+	waitlistConfig := service.DefaultWaitlistConfig()
+	waitlistConfig.OfferWindow = time.Duration(envFloat("STANDBY_OFFER_MINUTES", waitlistConfig.OfferWindow.Minutes())) * time.Minute
+	waitlistService := service.NewWaitlistService(waitlistRepo, pgShiftRepo, lifecycleService, rankingService, reliabilityService, blockService, wsHub, waitlistConfig)
+
+	// --- 4b. BACKGROUND JOBS ---
+	appCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go service.RunEvery(appCtx, "recurrence", time.Hour, seriesService.MaterialiseAll)
+	go service.RunEvery(appCtx, "payouts", 24*time.Hour, ledgerService.PayoutJob)
+	go service.RunEvery(appCtx, "ratings", time.Hour, ratingService.PublishDue)
+	go service.RunEvery(appCtx, "no-shows", 15*time.Minute, reliabilityService.DetectNoShows)
+	go service.RunEvery(appCtx, "reconfirmations", 15*time.Minute, revisionService.ExpireReconfirmations)
+	go service.RunEvery(appCtx, "standby-backfill", time.Minute, waitlistService.RunBackfill)
+	go service.RunEvery(appCtx, "shift-release", 5*time.Minute, poolService.ReleaseDue)
+
+	// --- 5. HANDLERS & ROUTES ---
+
 	// A. Shift Handlers (with WebSocket hub for broadcasting)
-	shiftHandler := handler.NewShiftHandler(shiftService, wsHub)
+	shiftHandler := handler.NewShiftHandler(shiftService, rankingService, wsHub)
 
 	// Shift Routes
 	http.HandleFunc("/shifts", handler.AuthMiddleware(shiftHandler.GetNearby))
+	http.HandleFunc("/shifts/detail", handler.AuthMiddleware(shiftHandler.GetShift))
 	http.HandleFunc("/shifts/create", handler.AuthMiddleware(shiftHandler.Create))
 	http.HandleFunc("/shifts/update", handler.AuthMiddleware(shiftHandler.UpdateShift))
 	http.HandleFunc("/shifts/delete", handler.AuthMiddleware(shiftHandler.DeleteShift))
@@ -77,10 +229,146 @@ func main() {
 	http.HandleFunc("/shifts/my-shifts", handler.AuthMiddleware(shiftHandler.GetMyShifts))
 	http.HandleFunc("/shifts/applications", handler.AuthMiddleware(shiftHandler.GetShiftApplications))
 	http.HandleFunc("/shifts/applications/update", handler.AuthMiddleware(shiftHandler.UpdateApplicationStatus))
+	http.HandleFunc("/shifts/applications/batch", handler.AuthMiddleware(shiftHandler.BatchUpdateApplicationStatus))
+
+	// Recommendation Routes
+	matchingHandler := handler.NewMatchingHandler(matchingService)
+	http.HandleFunc("/shifts/recommended", handler.AuthMiddleware(matchingHandler.GetRecommended))
 	
 	// Worker Routes
 	http.HandleFunc("/my-applications", handler.AuthMiddleware(shiftHandler.GetMyApplications))
 	http.HandleFunc("/my-applications/delete", handler.AuthMiddleware(shiftHandler.DeleteApplication))
+	http.HandleFunc("/applications/reasons", handler.AuthMiddleware(shiftHandler.GetApplicationReasons))
+
+	// Taxonomy Routes (categories & skills)
+	taxonomyHandler := handler.NewTaxonomyHandler(taxonomyService)
+	http.HandleFunc("/categories", handler.AuthMiddleware(taxonomyHandler.ListCategories))
+	http.HandleFunc("/categories/create", handler.AuthMiddleware(taxonomyHandler.CreateCategory))
+	http.HandleFunc("/skills", handler.AuthMiddleware(taxonomyHandler.ListSkills))
+	http.HandleFunc("/skills/create", handler.AuthMiddleware(taxonomyHandler.CreateSkill))
+	http.HandleFunc("/my-skills", handler.AuthMiddleware(taxonomyHandler.GetMySkills))
+	http.HandleFunc("/my-skills/update", handler.AuthMiddleware(taxonomyHandler.UpdateMySkills))
+
+	// Worker Profile Routes
+	workerProfileHandler := handler.NewWorkerProfileHandler(workerProfileService)
+	http.HandleFunc("/my-preferences", handler.AuthMiddleware(workerProfileHandler.GetMyPreferences))
+	http.HandleFunc("/my-preferences/update", handler.AuthMiddleware(workerProfileHandler.UpdateMyPreferences))
+	http.HandleFunc("/my-availability", handler.AuthMiddleware(workerProfileHandler.GetMyAvailability))
+	http.HandleFunc("/my-availability/update", handler.AuthMiddleware(workerProfileHandler.UpdateMyAvailability))
+
+	// Template & Recurring Shift Routes
+	seriesHandler := handler.NewSeriesHandler(seriesService)
+	http.HandleFunc("/templates", handler.AuthMiddleware(seriesHandler.GetMyTemplates))
+	http.HandleFunc("/templates/create", handler.AuthMiddleware(seriesHandler.CreateTemplate))
+	http.HandleFunc("/templates/update", handler.AuthMiddleware(seriesHandler.UpdateTemplate))
+	http.HandleFunc("/templates/delete", handler.AuthMiddleware(seriesHandler.DeleteTemplate))
+	http.HandleFunc("/series", handler.AuthMiddleware(seriesHandler.GetMySeries))
+	http.HandleFunc("/series/create", handler.AuthMiddleware(seriesHandler.CreateSeries))
+	http.HandleFunc("/series/cancel", handler.AuthMiddleware(seriesHandler.CancelSeries))
+
+	// Attendance Routes (clock-in / clock-out)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService, wsHub)
+	http.HandleFunc("/attendance", handler.AuthMiddleware(attendanceHandler.GetShiftAttendance))
+	http.HandleFunc("/attendance/clock-in", handler.AuthMiddleware(attendanceHandler.ClockIn))
+	http.HandleFunc("/attendance/clock-out", handler.AuthMiddleware(attendanceHandler.ClockOut))
+	http.HandleFunc("/attendance/qr", handler.AuthMiddleware(attendanceHandler.GetCheckInToken))
+
+	// Timesheet Routes
+	timesheetHandler := handler.NewTimesheetHandler(timesheetService, wsHub)
+	http.HandleFunc("/timesheets", handler.AuthMiddleware(timesheetHandler.GetTimesheets))
+	http.HandleFunc("/timesheets/detail", handler.AuthMiddleware(timesheetHandler.GetTimesheet))
+	http.HandleFunc("/timesheets/review", handler.AuthMiddleware(timesheetHandler.ReviewTimesheet))
+	http.HandleFunc("/timesheets/respond", handler.AuthMiddleware(timesheetHandler.RespondTimesheet))
+
+	// Wage Rule Routes
+	wageRuleHandler := handler.NewWageRuleHandler(wageService)
+	http.HandleFunc("/wage-rules", handler.AuthMiddleware(wageRuleHandler.GetWageRules))
+
+	// Rating Routes
+	ratingHandler := handler.NewRatingHandler(ratingService, wsHub)
+	http.HandleFunc("/ratings", handler.AuthMiddleware(ratingHandler.GetApplicationRatings))
+	http.HandleFunc("/ratings/create", handler.AuthMiddleware(ratingHandler.CreateRating))
+	http.HandleFunc("/ratings/tags", handler.AuthMiddleware(ratingHandler.GetRatingTags))
+	http.HandleFunc("/users/ratings", handler.AuthMiddleware(ratingHandler.GetUserRatings))
+
+	// Reliability Routes (no-shows, strikes, appeals)
+	reliabilityHandler := handler.NewReliabilityHandler(reliabilityService)
+	http.HandleFunc("/my-reliability", handler.AuthMiddleware(reliabilityHandler.GetMyReliability))
+	http.HandleFunc("/reliability", handler.AuthMiddleware(reliabilityHandler.GetWorkerReliability))
+	http.HandleFunc("/reliability/appeals", handler.AuthMiddleware(reliabilityHandler.GetAppeals))
+	http.HandleFunc("/reliability/appeals/create", handler.AuthMiddleware(reliabilityHandler.CreateAppeal))
+	http.HandleFunc("/reliability/appeals/decide", handler.AuthMiddleware(reliabilityHandler.DecideAppeal))
+
+	// Cancellation Routes (filled shifts and accepted spots)
+	cancellationHandler := handler.NewCancellationHandler(cancellationService, wsHub)
+	http.HandleFunc("/shifts/cancel", handler.AuthMiddleware(cancellationHandler.CancelShift))
+	http.HandleFunc("/shifts/cancellations", handler.AuthMiddleware(cancellationHandler.GetCancellations))
+	http.HandleFunc("/my-applications/cancel", handler.AuthMiddleware(cancellationHandler.CancelApplication))
+
+	// Shift History Routes (versions and re-confirmation of material changes)
+	revisionHandler := handler.NewShiftRevisionHandler(revisionService, wsHub)
+	http.HandleFunc("/shifts/history", handler.AuthMiddleware(revisionHandler.GetShiftHistory))
+	http.HandleFunc("/my-applications/reconfirm", handler.AuthMiddleware(revisionHandler.ReconfirmApplication))
+
+	// Standby Routes (ranked waitlist and backfill offers)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistService, wsHub)
+	http.HandleFunc("/shifts/standby", handler.AuthMiddleware(waitlistHandler.GetStandby))
+	http.HandleFunc("/shifts/standby/update", handler.AuthMiddleware(waitlistHandler.SetStandby))
+	http.HandleFunc("/my-applications/offer", handler.AuthMiddleware(waitlistHandler.RespondToOffer))
+
+	// Worker Pool Routes (favourites, invites and shift visibility)
+	poolHandler := handler.NewPoolHandler(poolService, wsHub)
+	http.HandleFunc("/my-pool", handler.AuthMiddleware(poolHandler.GetMyPool))
+	http.HandleFunc("/my-pool/add", handler.AuthMiddleware(poolHandler.AddToPool))
+	http.HandleFunc("/my-pool/remove", handler.AuthMiddleware(poolHandler.RemoveFromPool))
+	http.HandleFunc("/shifts/visibility", handler.AuthMiddleware(poolHandler.SetVisibility))
+	http.HandleFunc("/shifts/invite", handler.AuthMiddleware(poolHandler.InviteWorkers))
+	http.HandleFunc("/shifts/invites", handler.AuthMiddleware(poolHandler.GetInvites))
+
+	// Block Routes (two-way block lists, admin pattern report)
+	blockHandler := handler.NewBlockHandler(blockService)
+	http.HandleFunc("/my-blocks", handler.AuthMiddleware(blockHandler.GetMyBlocks))
+	http.HandleFunc("/my-blocks/add", handler.AuthMiddleware(blockHandler.Block))
+	http.HandleFunc("/my-blocks/remove", handler.AuthMiddleware(blockHandler.Unblock))
+	http.HandleFunc("/blocks", handler.AuthMiddleware(blockHandler.GetUserBlocks))
+	http.HandleFunc("/blocks/patterns", handler.AuthMiddleware(blockHandler.GetBlockPatterns))
+
+	// Screening Routes (questions asked of every applicant)
+	screeningHandler := handler.NewScreeningHandler(screeningService)
+	http.HandleFunc("/shifts/questions", handler.AuthMiddleware(screeningHandler.SetQuestions))
+
+	// Negotiation Routes (counter-offers on the pay rate of an application)
+	negotiationHandler := handler.NewNegotiationHandler(negotiationService, wsHub)
+	http.HandleFunc("/applications/negotiate", handler.AuthMiddleware(negotiationHandler.Respond))
+	http.HandleFunc("/applications/negotiation", handler.AuthMiddleware(negotiationHandler.GetThread))
+
+	// Event Routes (one posting staffing several positions)
+	eventHandler := handler.NewEventHandler(eventService, wsHub)
+	http.HandleFunc("/events/create", handler.AuthMiddleware(eventHandler.Create))
+	http.HandleFunc("/events/positions/add", handler.AuthMiddleware(eventHandler.AddPosition))
+	http.HandleFunc("/events/detail", handler.AuthMiddleware(eventHandler.GetEvent))
+	http.HandleFunc("/my-events", handler.AuthMiddleware(eventHandler.GetMyEvents))
+
+	// Location Routes (saved work sites of a business)
+	locationHandler := handler.NewLocationHandler(locationService, wsHub)
+	http.HandleFunc("/my-locations", handler.AuthMiddleware(locationHandler.GetMyLocations))
+	http.HandleFunc("/my-locations/create", handler.AuthMiddleware(locationHandler.Create))
+	http.HandleFunc("/my-locations/update", handler.AuthMiddleware(locationHandler.Update))
+	http.HandleFunc("/my-locations/delete", handler.AuthMiddleware(locationHandler.Delete))
+
+	// Analytics Routes (why applications were rejected or withdrawn)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	http.HandleFunc("/my-analytics/reasons", handler.AuthMiddleware(analyticsHandler.GetReasonBreakdown))
+
+	// Earnings & Payout Routes
+	ledgerHandler := handler.NewLedgerHandler(ledgerService)
+	http.HandleFunc("/my-earnings", handler.AuthMiddleware(ledgerHandler.GetMyEarnings))
+	http.HandleFunc("/my-bank-account", handler.AuthMiddleware(ledgerHandler.GetMyBankAccount))
+	http.HandleFunc("/my-bank-account/update", handler.AuthMiddleware(ledgerHandler.UpdateMyBankAccount))
+	http.HandleFunc("/ledger/adjustments/create", handler.AuthMiddleware(ledgerHandler.CreateAdjustment))
+	http.HandleFunc("/payouts", handler.AuthMiddleware(ledgerHandler.GetPayoutBatches))
+	http.HandleFunc("/payouts/run", handler.AuthMiddleware(ledgerHandler.RunPayoutBatch))
+	http.HandleFunc("/payouts/download", handler.AuthMiddleware(ledgerHandler.DownloadPayoutBatch))
 
 	// B. Auth Handlers
 	authHandler := handler.NewAuthHandler(userRepo)
@@ -104,4 +392,18 @@ func main() {
 	if err := http.ListenAndServe(":8080", router); err != nil {
 		fmt.Println("Error:", err)
 	}
-}
\ No newline at end of file
+}
+
+// envFloat reads a float setting from the environment, falling back to def
+func envFloat(name string, def float64) float64 {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		fmt.Printf("⚠️ Ignoring invalid %s=%q: %v\n", name, raw, err)
+		return def
+	}
+	return v
+}
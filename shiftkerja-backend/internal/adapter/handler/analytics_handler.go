@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type AnalyticsHandler struct {
+	Service *service.AnalyticsService
+}
+
+func NewAnalyticsHandler(svc *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{Service: svc}
+}
+
+// GetReasonBreakdown sums up why applications to the business's shifts were
+// rejected or withdrawn.
+// Query params: days (optional, default 90), business_id (admins only)
+func (h *AnalyticsHandler) GetReasonBreakdown(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	q := r.URL.Query()
+	businessID := userID
+	switch role {
+	case "business":
+	case "admin":
+		id, err := strconv.ParseInt(q.Get("business_id"), 10, 64)
+		if err != nil || id <= 0 {
+			util.RespondBadRequest(w, "Invalid business_id: must be a positive integer")
+			return
+		}
+		businessID = id
+	default:
+		util.RespondForbidden(w, "Only businesses and admins can view analytics")
+		return
+	}
+	days, _ := strconv.Atoi(q.Get("days"))
+
+	breakdown, err := h.Service.GetReasonBreakdown(r.Context(), businessID, days)
+	if err != nil {
+		fmt.Printf("❌ GetReasonBreakdown Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to load analytics")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, breakdown)
+}
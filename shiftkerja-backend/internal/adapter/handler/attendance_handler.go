@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type AttendanceHandler struct {
+	Service *service.AttendanceService
+	Hub     *Hub
+}
+
+func NewAttendanceHandler(svc *service.AttendanceService, hub *Hub) *AttendanceHandler {
+	return &AttendanceHandler{Service: svc, Hub: hub}
+}
+
+// ClockIn records the calling worker's arrival at an accepted shift
+func (h *AttendanceHandler) ClockIn(w http.ResponseWriter, r *http.Request) {
+	h.clock(w, r, service.ActionClockIn)
+}
+
+// ClockOut records the calling worker leaving an accepted shift
+func (h *AttendanceHandler) ClockOut(w http.ResponseWriter, r *http.Request) {
+	h.clock(w, r, service.ActionClockOut)
+}
+
+func (h *AttendanceHandler) clock(w http.ResponseWriter, r *http.Request, action string) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can clock in or out")
+		return
+	}
+
+	var req dto.ClockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id")
+		return
+	}
+	if req.Lat < -90 || req.Lat > 90 || req.Lng < -180 || req.Lng > 180 {
+		util.RespondBadRequest(w, "Invalid coordinates")
+		return
+	}
+
+	var event *entity.AttendanceEvent
+	var err error
+	if action == service.ActionClockIn {
+		event, err = h.Service.ClockIn(r.Context(), req.ShiftID, userID, req.Lat, req.Lng, req.QRToken)
+	} else {
+		event, err = h.Service.ClockOut(r.Context(), req.ShiftID, userID, req.Lat, req.Lng, req.BreakMinutes)
+	}
+	if err != nil {
+		fmt.Printf("❌ %s Error: %v\n", action, err)
+
+		if errors.Is(err, service.ErrInvalidTimesheet) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		switch err {
+		case service.ErrShiftNotFound:
+			util.RespondNotFound(w, "Shift not found")
+		case service.ErrNotAcceptedForShift:
+			util.RespondForbidden(w, err.Error())
+		case service.ErrAlreadyClockedIn, service.ErrAlreadyClockedOut:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+		case service.ErrShiftNotActive, service.ErrClockInTooEarly, service.ErrShiftAlreadyEnded, service.ErrNotClockedIn:
+			util.RespondBadRequest(w, err.Error())
+		case service.ErrCheckInTokenRequired, service.ErrInvalidCheckInToken, service.ErrExpiredCheckInToken:
+			util.RespondForbidden(w, err.Error())
+		default:
+			util.RespondInternalError(w, "Failed to record attendance")
+		}
+		return
+	}
+
+	// SEND ATTENDANCE EVENT to the business (flags tell it something needs a look)
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":        "attendance_recorded",
+			"action":      event.Action,
+			"shift_id":    event.Attendance.ShiftID,
+			"worker_id":   event.Attendance.WorkerID,
+			"flags":       event.Flags,
+			"flagged":     len(event.Flags) > 0,
+			"qr_verified": event.Attendance.ClockInQRVerified,
+		}
+		h.Hub.SendToUser(event.BusinessID, msg)
+		fmt.Printf("📡 Sent %s to business %d: Worker %d -> Shift %d %v\n", event.Action, event.BusinessID, userID, req.ShiftID, event.Flags)
+	}
+
+	message := "Clocked in successfully"
+	if action == service.ActionClockOut {
+		message = "Clocked out successfully"
+	}
+	util.RespondSuccess(w, message, event)
+}
+
+// GetCheckInToken returns the current rotating QR token of a shift (owner only).
+// The business's screen should poll it again at expires_at.
+func (h *AttendanceHandler) GetCheckInToken(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can display check-in codes")
+		return
+	}
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	token, err := h.Service.GetCheckInToken(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetCheckInToken Error: %v\n", err)
+
+		switch err {
+		case service.ErrShiftNotFound:
+			util.RespondNotFound(w, "Shift not found")
+		case service.ErrUnauthorized:
+			util.RespondForbidden(w, "You don't have permission to display this shift's code")
+		case service.ErrShiftNotActive:
+			util.RespondBadRequest(w, err.Error())
+		default:
+			util.RespondInternalError(w, "Failed to generate check-in code")
+		}
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, token)
+}
+
+// GetShiftAttendance returns the clock-in / clock-out records of a shift (owner only)
+func (h *AttendanceHandler) GetShiftAttendance(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can view shift attendance")
+		return
+	}
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	records, err := h.Service.GetShiftAttendance(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetShiftAttendance Error: %v\n", err)
+
+		switch err {
+		case service.ErrShiftNotFound:
+			util.RespondNotFound(w, "Shift not found")
+		case service.ErrUnauthorized:
+			util.RespondForbidden(w, "You don't have permission to view this shift's attendance")
+		default:
+			util.RespondInternalError(w, "Failed to retrieve attendance")
+		}
+		return
+	}
+
+	if records == nil {
+		records = []entity.Attendance{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"geofence_radius_m": h.Service.GeofenceRadiusM(),
+		"records":           records,
+	})
+}
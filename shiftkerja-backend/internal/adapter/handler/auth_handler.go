@@ -36,6 +36,13 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only workers and businesses sign themselves up; admins are created with
+	// cmd/create-admin
+	if req.Role != "worker" && req.Role != "business" {
+		http.Error(w, "Role must be worker or business", http.StatusBadRequest)
+		return
+	}
+
 	// 2. Hash the Password
 	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
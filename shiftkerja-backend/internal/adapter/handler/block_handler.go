@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type BlockHandler struct {
+	Service *service.BlockService
+}
+
+func NewBlockHandler(svc *service.BlockService) *BlockHandler {
+	return &BlockHandler{Service: svc}
+}
+
+// GetMyBlocks lists the users the caller has blocked
+func (h *BlockHandler) GetMyBlocks(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	blocks, err := h.Service.GetMyBlocks(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyBlocks Error: %v\n", err)
+		respondBlockError(w, err)
+		return
+	}
+
+	if blocks == nil {
+		blocks = []entity.Block{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, blocks)
+}
+
+// Block blocks a business (as a worker) or a worker (as a business)
+func (h *BlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" && role != "business" {
+		util.RespondForbidden(w, "Only workers and businesses can block users")
+		return
+	}
+
+	var req dto.BlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.UserID <= 0 {
+		util.RespondBadRequest(w, "Invalid user_id")
+		return
+	}
+
+	block, err := h.Service.Block(r.Context(), userID, role, req.UserID, req.Reason)
+	if err != nil {
+		fmt.Printf("❌ Block Error: %v\n", err)
+		respondBlockError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "User blocked", block)
+}
+
+// Unblock lifts a block the caller made
+func (h *BlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	var req dto.BlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.UserID <= 0 {
+		util.RespondBadRequest(w, "Invalid user_id")
+		return
+	}
+
+	if err := h.Service.Unblock(r.Context(), userID, req.UserID); err != nil {
+		fmt.Printf("❌ Unblock Error: %v\n", err)
+		respondBlockError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "User unblocked", map[string]interface{}{
+		"user_id": req.UserID,
+	})
+}
+
+// GetBlockPatterns lists users blocked by, or blocking, many others (admin only).
+// Query params: days (optional, default 90), min_count (optional, default 3)
+func (h *BlockHandler) GetBlockPatterns(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can review block patterns")
+		return
+	}
+
+	q := r.URL.Query()
+	days, _ := strconv.Atoi(q.Get("days"))
+	minCount, _ := strconv.Atoi(q.Get("min_count"))
+
+	patterns, err := h.Service.GetPatterns(r.Context(), days, minCount)
+	if err != nil {
+		fmt.Printf("❌ GetBlockPatterns Error: %v\n", err)
+		respondBlockError(w, err)
+		return
+	}
+
+	if patterns == nil {
+		patterns = []entity.BlockPattern{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, patterns)
+}
+
+// GetUserBlocks lists the blocks made by or against a user (admin only).
+// Query params: user_id
+func (h *BlockHandler) GetUserBlocks(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can review blocks")
+		return
+	}
+
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		util.RespondBadRequest(w, "Invalid user_id: must be a positive integer")
+		return
+	}
+
+	blocks, err := h.Service.GetUserBlocks(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetUserBlocks Error: %v\n", err)
+		respondBlockError(w, err)
+		return
+	}
+
+	if blocks == nil {
+		blocks = []entity.Block{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, blocks)
+}
+
+func respondBlockError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrBlockNotFound:
+		util.RespondNotFound(w, err.Error())
+	default:
+		if errors.Is(err, service.ErrInvalidBlock) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to process block")
+	}
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type CancellationHandler struct {
+	Service *service.CancellationService
+	Hub     *Hub
+}
+
+func NewCancellationHandler(svc *service.CancellationService, hub *Hub) *CancellationHandler {
+	return &CancellationHandler{Service: svc, Hub: hub}
+}
+
+// CancelShift lets a business call off a shift, including a filled one
+func (h *CancellationHandler) CancelShift(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can cancel shifts")
+		return
+	}
+
+	var req dto.CancelShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id")
+		return
+	}
+
+	result, err := h.Service.CancelShift(r.Context(), req.ShiftID, userID, req.Reason)
+	if err != nil {
+		fmt.Printf("❌ CancelShift Error: %v\n", err)
+		respondCancellationError(w, err)
+		return
+	}
+
+	// Live update: each affected worker hears about their own spot (and
+	// compensation), the business gets the summary
+	if h.Hub != nil {
+		own := map[int64]entity.Cancellation{}
+		for _, c := range result.Cancellations {
+			if c.WorkerID != nil {
+				own[*c.WorkerID] = c
+			}
+		}
+		for _, workerID := range result.Affected {
+			msg := map[string]interface{}{
+				"type":     "shift_cancelled",
+				"shift_id": req.ShiftID,
+				"reason":   result.Cancellations[0].Reason,
+			}
+			if c, ok := own[workerID]; ok {
+				msg["cancellation"] = c
+			}
+			h.Hub.SendToUser(workerID, msg)
+		}
+		h.Hub.SendToUser(userID, map[string]interface{}{
+			"type":          "shift_cancelled",
+			"shift_id":      req.ShiftID,
+			"worker_ids":    result.Affected,
+			"cancellations": result.Cancellations,
+		})
+		fmt.Printf("📡 Sent shift cancelled: Shift %d to %d workers\n", req.ShiftID, len(result.Affected))
+	}
+
+	util.RespondSuccess(w, "Shift cancelled successfully", result)
+}
+
+// CancelApplication lets a worker give up a spot they were accepted for
+func (h *CancellationHandler) CancelApplication(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can cancel their spot")
+		return
+	}
+
+	var req dto.CancelApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ApplicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	result, err := h.Service.CancelSpot(r.Context(), req.ApplicationID, userID, req.Reason)
+	if err != nil {
+		fmt.Printf("❌ CancelApplication Error: %v\n", err)
+		respondCancellationError(w, err)
+		return
+	}
+
+	// Live update: the business and the worker hear about the cancelled spot
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":           "spot_cancelled",
+			"shift_id":       result.Shift.ID,
+			"application_id": req.ApplicationID,
+			"worker_id":      userID,
+			"reason":         result.Cancellations[0].Reason,
+		}
+		h.Hub.SendToUser(result.Shift.OwnerID, msg)
+		h.Hub.SendToUser(userID, msg)
+		fmt.Printf("📡 Sent spot cancelled: Application %d\n", req.ApplicationID)
+
+		// The shift is back on the map, tell the pending applicants
+		if result.Reopened {
+			reopened := map[string]interface{}{
+				"type":  "shift_reopened",
+				"shift": result.Shift,
+			}
+			for _, workerID := range result.Waitlist {
+				h.Hub.SendToUser(workerID, reopened)
+			}
+			fmt.Printf("📡 Sent shift reopened: Shift %d to %d applicants\n", result.Shift.ID, len(result.Waitlist))
+		}
+	}
+
+	util.RespondSuccess(w, "Spot cancelled successfully", result)
+}
+
+// GetCancellations lists a shift's cancellations (business owner only).
+// Query params: shift_id
+func (h *CancellationHandler) GetCancellations(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can view cancellations")
+		return
+	}
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	cancellations, err := h.Service.GetCancellations(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetCancellations Error: %v\n", err)
+		respondCancellationError(w, err)
+		return
+	}
+
+	if cancellations == nil {
+		cancellations = []entity.Cancellation{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, cancellations)
+}
+
+func respondCancellationError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrShiftNotFound, service.ErrApplicationNotFound:
+		util.RespondNotFound(w, err.Error())
+	case service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only cancel your own shifts and spots")
+	case service.ErrCancellationReasonMissing:
+		util.RespondBadRequest(w, err.Error())
+	case service.ErrApplicationNotCancellable, service.ErrCancelTooLate, service.ErrStatusChanged:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		if errors.Is(err, service.ErrIllegalTransition) {
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to process cancellation")
+	}
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type EventHandler struct {
+	Service *service.EventService
+	Hub     *Hub
+}
+
+func NewEventHandler(svc *service.EventService, hub *Hub) *EventHandler {
+	return &EventHandler{Service: svc, Hub: hub}
+}
+
+// Create posts an event with its positions (Business only)
+func (h *EventHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" && role != "admin" {
+		util.RespondForbidden(w, "Only businesses can post events")
+		return
+	}
+
+	var req dto.CreateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	// Businesses post at a saved work site; admins may still give coordinates
+	if role == "business" && req.LocationID == nil {
+		util.RespondBadRequest(w, service.ErrLocationRequired.Error())
+		return
+	}
+	if req.LocationID == nil && req.Lat == 0 && req.Lng == 0 {
+		util.RespondBadRequest(w, "lat and lng are required without a location_id")
+		return
+	}
+	if req.Lat < -90 || req.Lat > 90 {
+		util.RespondBadRequest(w, "Latitude must be between -90 and 90")
+		return
+	}
+	if req.Lng < -180 || req.Lng > 180 {
+		util.RespondBadRequest(w, "Longitude must be between -180 and 180")
+		return
+	}
+
+	event := &entity.Event{
+		OwnerID:     userID,
+		Title:       req.Title,
+		Description: req.Description,
+		Lat:         req.Lat,
+		Lng:         req.Lng,
+		LocationID:  req.LocationID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}
+	positions := make([]entity.Shift, len(req.Positions))
+	for i, p := range req.Positions {
+		if p.Title == "" {
+			util.RespondBadRequest(w, fmt.Sprintf("Position %d: title is required", i+1))
+			return
+		}
+		if p.ReleaseAfterHours < 0 {
+			util.RespondBadRequest(w, "release_after_hours can't be negative")
+			return
+		}
+		positions[i] = positionShift(p)
+	}
+
+	event, err := h.Service.CreateEvent(r.Context(), event, positions)
+	if err != nil {
+		respondEventError(w, err)
+		return
+	}
+
+	// Live update: the event shows up once, with the positions anyone may see
+	if h.Hub != nil {
+		now := time.Now()
+		public := []entity.PositionAvailability{}
+		for i, position := range positions {
+			if position.IsPublic(now) {
+				public = append(public, event.Positions[i])
+			}
+		}
+		if len(public) > 0 {
+			h.Hub.Broadcast(map[string]interface{}{
+				"type":      "event_created",
+				"id":        event.ID,
+				"title":     event.Title,
+				"lat":       event.Lat,
+				"lng":       event.Lng,
+				"positions": public,
+			})
+			fmt.Printf("📡 Broadcasted event creation: %s\n", event.Title)
+		}
+	}
+
+	util.RespondCreated(w, "Event created successfully", event)
+}
+
+// AddPosition adds a position to one of the business's events
+func (h *EventHandler) AddPosition(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can add event positions")
+		return
+	}
+
+	var req dto.AddEventPositionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.EventID <= 0 {
+		util.RespondBadRequest(w, "Invalid event_id")
+		return
+	}
+	if req.Title == "" {
+		util.RespondBadRequest(w, "Title is required")
+		return
+	}
+	if req.ReleaseAfterHours < 0 {
+		util.RespondBadRequest(w, "release_after_hours can't be negative")
+		return
+	}
+
+	shift := positionShift(req.EventPositionRequest)
+	if err := h.Service.AddPosition(r.Context(), req.EventID, userID, &shift); err != nil {
+		respondEventError(w, err)
+		return
+	}
+
+	if h.Hub != nil && shift.IsPublic(time.Now()) {
+		h.Hub.Broadcast(map[string]interface{}{
+			"type":     "event_position_added",
+			"event_id": req.EventID,
+			"id":       shift.ID,
+			"title":    shift.Title,
+			"pay_rate": shift.PayRate,
+			"pay_unit": shift.PayUnit,
+			"slots":    shift.Slots,
+		})
+		fmt.Printf("📡 Broadcasted event position: %s\n", shift.Title)
+	}
+
+	util.RespondCreated(w, "Position added successfully", shift)
+}
+
+// GetEvent returns an event with the availability of its positions
+func (h *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	eventID, err := strconv.ParseInt(r.URL.Query().Get("event_id"), 10, 64)
+	if err != nil || eventID <= 0 {
+		util.RespondBadRequest(w, "Invalid event_id: must be a positive integer")
+		return
+	}
+
+	event, err := h.Service.GetEvent(r.Context(), eventID, userID, role)
+	if err != nil {
+		respondEventError(w, err)
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, event)
+}
+
+// GetMyEvents returns the business's events with all their positions
+func (h *EventHandler) GetMyEvents(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have events")
+		return
+	}
+
+	events, err := h.Service.GetMyEvents(r.Context(), userID)
+	if err != nil {
+		respondEventError(w, err)
+		return
+	}
+	if events == nil {
+		events = []entity.Event{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, events)
+}
+
+// positionShift converts a requested position to a shift, placed by the service
+func positionShift(req dto.EventPositionRequest) entity.Shift {
+	shift := entity.Shift{
+		Title:       req.Title,
+		Description: req.Description,
+		PayRate:     req.PayRate,
+		PayUnit:     req.PayUnit,
+		Status:      entity.ShiftOpen,
+		CategoryID:  req.CategoryID,
+		Slots:       req.Slots,
+		Visibility:  req.Visibility,
+
+		ScreeningQuestions: req.ScreeningQuestions,
+	}
+	if req.ReleaseAfterHours > 0 {
+		releaseAt := time.Now().Add(time.Duration(req.ReleaseAfterHours * float64(time.Hour)))
+		shift.ReleaseAt = &releaseAt
+	}
+	for _, skillID := range req.SkillIDs {
+		shift.RequiredSkills = append(shift.RequiredSkills, entity.Skill{ID: skillID})
+	}
+	return shift
+}
+
+func respondEventError(w http.ResponseWriter, err error) {
+	fmt.Printf("❌ Event Error: %v\n", err)
+	switch {
+	case err == service.ErrEventNotFound:
+		util.RespondNotFound(w, "Event not found")
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You don't have permission to change this event")
+	case errors.Is(err, service.ErrInvalidEvent), errors.Is(err, service.ErrInvalidSchedule),
+		errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrSkillNotFound),
+		errors.Is(err, service.ErrInvalidPay), errors.Is(err, service.ErrBelowMinimumWage),
+		errors.Is(err, service.ErrInvalidVisibility), errors.Is(err, service.ErrInvalidQuestions),
+		errors.Is(err, service.ErrInvalidSlots), errors.Is(err, service.ErrLocationNotFound):
+		util.RespondBadRequest(w, err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process the event")
+	}
+}
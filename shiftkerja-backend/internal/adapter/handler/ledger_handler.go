@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type LedgerHandler struct {
+	Service *service.LedgerService
+}
+
+func NewLedgerHandler(svc *service.LedgerService) *LedgerHandler {
+	return &LedgerHandler{Service: svc}
+}
+
+// GetMyEarnings returns the calling worker's earnings for a month.
+// Query params: month (YYYY-MM, default current month), currency (default IDR)
+func (h *LedgerHandler) GetMyEarnings(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have earnings")
+		return
+	}
+
+	query := r.URL.Query()
+	summary, err := h.Service.GetEarnings(r.Context(), userID, query.Get("month"), query.Get("currency"))
+	if err != nil {
+		fmt.Printf("❌ GetMyEarnings Error: %v\n", err)
+		if err == service.ErrInvalidMonth || errors.Is(err, entity.ErrUnknownCurrency) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to retrieve earnings")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, summary)
+}
+
+// GetMyBankAccount returns the calling worker's payout details
+func (h *LedgerHandler) GetMyBankAccount(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have payout details")
+		return
+	}
+
+	account, err := h.Service.GetBankAccount(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyBankAccount Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve bank account")
+		return
+	}
+	if account == nil {
+		util.RespondNotFound(w, "No bank account saved yet")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, account)
+}
+
+// UpdateMyBankAccount saves where the calling worker's payouts are sent
+func (h *LedgerHandler) UpdateMyBankAccount(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have payout details")
+		return
+	}
+
+	var req dto.UpdateBankAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	account := &entity.WorkerBankAccount{
+		WorkerID:      userID,
+		BankCode:      req.BankCode,
+		AccountNumber: req.AccountNumber,
+		AccountHolder: req.AccountHolder,
+	}
+	if err := h.Service.UpdateBankAccount(r.Context(), account); err != nil {
+		fmt.Printf("❌ UpdateMyBankAccount Error: %v\n", err)
+		if errors.Is(err, service.ErrInvalidBankAccount) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to save bank account")
+		return
+	}
+
+	util.RespondSuccess(w, "Bank account saved successfully", account)
+}
+
+// CreateAdjustment posts a manual correction to a worker's balance (admin only)
+func (h *LedgerHandler) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can post adjustments")
+		return
+	}
+
+	var req dto.CreateAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	txn, err := h.Service.AddAdjustment(r.Context(), userID, req.WorkerID, req.Amount, req.Description)
+	if err != nil {
+		fmt.Printf("❌ CreateAdjustment Error: %v\n", err)
+		if errors.Is(err, service.ErrInvalidAdjustment) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to post adjustment")
+		return
+	}
+
+	util.RespondCreated(w, "Adjustment posted successfully", txn)
+}
+
+// GetPayoutBatches lists payout batches (admin only)
+func (h *LedgerHandler) GetPayoutBatches(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can view payouts")
+		return
+	}
+
+	batches, err := h.Service.GetPayoutBatches(r.Context())
+	if err != nil {
+		fmt.Printf("❌ GetPayoutBatches Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve payout batches")
+		return
+	}
+
+	if batches == nil {
+		batches = []entity.PayoutBatch{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, batches)
+}
+
+// RunPayoutBatch creates a payout batch now instead of waiting for the daily job (admin only)
+func (h *LedgerHandler) RunPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can run payouts")
+		return
+	}
+
+	batch, err := h.Service.RunPayoutBatch(r.Context())
+	if err != nil {
+		fmt.Printf("❌ RunPayoutBatch Error: %v\n", err)
+		util.RespondInternalError(w, err.Error())
+		return
+	}
+	if batch == nil {
+		util.RespondSuccess(w, "Nothing to pay out", nil)
+		return
+	}
+
+	util.RespondCreated(w, "Payout batch created successfully", batch)
+}
+
+// DownloadPayoutBatch returns the bulk-transfer CSV of a batch (admin only)
+func (h *LedgerHandler) DownloadPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can download payout files")
+		return
+	}
+
+	batchID, err := strconv.ParseInt(r.URL.Query().Get("batch_id"), 10, 64)
+	if err != nil || batchID <= 0 {
+		util.RespondBadRequest(w, "Invalid batch_id: must be a positive integer")
+		return
+	}
+
+	batch, err := h.Service.GetPayoutBatch(r.Context(), batchID)
+	if err != nil {
+		util.RespondNotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", batch.FileName))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(batch.FileContent))
+}
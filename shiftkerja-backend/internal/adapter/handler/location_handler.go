@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type LocationHandler struct {
+	Service *service.LocationService
+	Hub     *Hub
+}
+
+func NewLocationHandler(svc *service.LocationService, hub *Hub) *LocationHandler {
+	return &LocationHandler{Service: svc, Hub: hub}
+}
+
+// Create saves a work site for the business
+func (h *LocationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can save locations")
+		return
+	}
+
+	var req dto.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	location := locationFromRequest(req)
+	location.OwnerID = userID
+	if err := h.Service.CreateLocation(r.Context(), &location); err != nil {
+		respondLocationError(w, err)
+		return
+	}
+
+	util.RespondCreated(w, "Location saved successfully", location)
+}
+
+// GetMyLocations returns the business's work sites
+func (h *LocationHandler) GetMyLocations(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have locations")
+		return
+	}
+
+	locations, err := h.Service.GetMyLocations(r.Context(), userID)
+	if err != nil {
+		respondLocationError(w, err)
+		return
+	}
+	if locations == nil {
+		locations = []entity.Location{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, locations)
+}
+
+// Update edits a work site. Moving it moves its OPEN shifts on the map.
+func (h *LocationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can update locations")
+		return
+	}
+
+	var req dto.UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ID <= 0 {
+		util.RespondBadRequest(w, "Invalid location ID")
+		return
+	}
+
+	location := locationFromRequest(req.LocationRequest)
+	location.ID = req.ID
+	revisions, err := h.Service.UpdateLocation(r.Context(), &location, userID)
+	if err != nil {
+		respondLocationError(w, err)
+		return
+	}
+
+	if h.Hub != nil && len(revisions) > 0 {
+		shiftIDs := make([]int64, len(revisions))
+		for i, rev := range revisions {
+			shiftIDs[i] = rev.ShiftID
+		}
+		h.Hub.SendToUser(userID, map[string]interface{}{
+			"type":        "location_moved",
+			"location_id": location.ID,
+			"lat":         location.Lat,
+			"lng":         location.Lng,
+			"shift_ids":   shiftIDs,
+		})
+		fmt.Printf("📡 Sent location move: %d shifts\n", len(shiftIDs))
+
+		// Applicants affected by a material change have to re-confirm
+		for _, rev := range revisions {
+			if len(rev.ReconfirmWorkers) == 0 {
+				continue
+			}
+			msg := map[string]interface{}{
+				"type":             "shift_changed",
+				"shift_id":         rev.ShiftID,
+				"version":          rev.Version,
+				"material_changes": rev.MaterialChanges,
+				"reconfirm_by":     rev.ReconfirmBy,
+			}
+			for _, workerID := range rev.ReconfirmWorkers {
+				h.Hub.SendToUser(workerID, msg)
+			}
+			fmt.Printf("📡 Sent shift changed: Shift %d v%d to %d applicants\n", rev.ShiftID, rev.Version, len(rev.ReconfirmWorkers))
+		}
+	}
+
+	util.RespondSuccess(w, "Location updated successfully", map[string]interface{}{
+		"location":     location,
+		"moved_shifts": revisions,
+	})
+}
+
+// Delete deletes a work site nothing is posted at
+func (h *LocationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can delete locations")
+		return
+	}
+
+	locationID, err := strconv.ParseInt(r.URL.Query().Get("location_id"), 10, 64)
+	if err != nil || locationID <= 0 {
+		util.RespondBadRequest(w, "Invalid location_id: must be a positive integer")
+		return
+	}
+
+	if err := h.Service.DeleteLocation(r.Context(), locationID, userID); err != nil {
+		respondLocationError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Location deleted successfully", nil)
+}
+
+func locationFromRequest(req dto.LocationRequest) entity.Location {
+	return entity.Location{
+		Name:         req.Name,
+		Address:      req.Address,
+		Lat:          req.Lat,
+		Lng:          req.Lng,
+		Instructions: req.Instructions,
+		ContactName:  req.ContactName,
+		ContactPhone: req.ContactPhone,
+	}
+}
+
+func respondLocationError(w http.ResponseWriter, err error) {
+	fmt.Printf("❌ Location Error: %v\n", err)
+	switch {
+	case err == service.ErrLocationNotFound:
+		util.RespondNotFound(w, "Location not found")
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You don't have permission to change this location")
+	case errors.Is(err, service.ErrInvalidLocation), errors.Is(err, service.ErrBelowMinimumWage):
+		util.RespondBadRequest(w, err.Error())
+	case err == service.ErrLocationInUse:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process the location")
+	}
+}
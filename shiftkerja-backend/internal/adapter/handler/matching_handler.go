@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type MatchingHandler struct {
+	Service *service.MatchingService
+}
+
+func NewMatchingHandler(svc *service.MatchingService) *MatchingHandler {
+	return &MatchingHandler{Service: svc}
+}
+
+// GetRecommended returns OPEN shifts ranked for the calling worker, with the score breakdown.
+// Query params: lat, lng (optional, default home location), rad, page, page_size, available_only
+func (h *MatchingHandler) GetRecommended(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers get shift recommendations")
+		return
+	}
+
+	q := r.URL.Query()
+	query := service.RecommendationQuery{WorkerID: userID}
+
+	if q.Get("lat") != "" || q.Get("lng") != "" {
+		lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+		if err != nil || lat < -90 || lat > 90 {
+			util.RespondBadRequest(w, "Invalid latitude: must be between -90 and 90")
+			return
+		}
+		lng, err := strconv.ParseFloat(q.Get("lng"), 64)
+		if err != nil || lng < -180 || lng > 180 {
+			util.RespondBadRequest(w, "Invalid longitude: must be between -180 and 180")
+			return
+		}
+		query.Lat, query.Lng = &lat, &lng
+	}
+
+	rad, _ := strconv.ParseFloat(q.Get("rad"), 64)
+	if rad > 100 {
+		util.RespondBadRequest(w, "Radius cannot exceed 100km")
+		return
+	}
+	query.RadiusKm = rad
+	query.Page, _ = strconv.Atoi(q.Get("page"))
+	query.PageSize, _ = strconv.Atoi(q.Get("page_size"))
+	query.AvailableOnly = q.Get("available_only") == "true"
+
+	page, err := h.Service.Recommend(r.Context(), query)
+	if err != nil {
+		fmt.Printf("❌ GetRecommended Error: %v\n", err)
+		if err == service.ErrNoSearchLocation {
+			util.RespondBadRequest(w, "Provide lat/lng or save a home location in your preferences")
+			return
+		}
+		util.RespondInternalError(w, "Failed to compute recommendations")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, page)
+}
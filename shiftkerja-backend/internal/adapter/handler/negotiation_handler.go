@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type NegotiationHandler struct {
+	Service *service.NegotiationService
+	Hub     *Hub
+}
+
+func NewNegotiationHandler(svc *service.NegotiationService, hub *Hub) *NegotiationHandler {
+	return &NegotiationHandler{Service: svc, Hub: hub}
+}
+
+// Respond lets the worker or the business accept, decline or counter the rate
+// on the table of an application
+func (h *NegotiationHandler) Respond(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" && role != "business" {
+		util.RespondForbidden(w, "Only the worker and the business negotiate a rate")
+		return
+	}
+
+	var req dto.NegotiateRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ApplicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	app, offer, err := h.Service.Respond(r.Context(), req.ApplicationID, userID, req.Action, req.Rate, req.Note)
+	if err != nil {
+		respondNegotiationError(w, err)
+		return
+	}
+
+	// Live update: the rate and note are only for the two sides negotiating
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":           "rate_offer",
+			"application_id": app.ID,
+			"shift_id":       app.ShiftID,
+			"worker_id":      app.WorkerID,
+			"side":           offer.Side,
+			"action":         offer.Action,
+			"rate":           offer.Rate,
+			"note":           offer.Note,
+			"rate_turn":      app.RateTurn,
+		}
+		h.Hub.SendToUser(app.WorkerID, msg)
+		h.Hub.SendToUser(app.ShiftOwnerID, msg)
+		fmt.Printf("📡 Sent rate offer: Application %d %s %s\n", app.ID, offer.Side, offer.Action)
+	}
+
+	util.RespondSuccess(w, "Rate offer recorded", app)
+}
+
+// GetThread returns the negotiation thread of an application
+func (h *NegotiationHandler) GetThread(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	applicationID, err := strconv.ParseInt(r.URL.Query().Get("application_id"), 10, 64)
+	if err != nil || applicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	app, err := h.Service.GetThread(r.Context(), applicationID, userID, role)
+	if err != nil {
+		respondNegotiationError(w, err)
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, app)
+}
+
+func respondNegotiationError(w http.ResponseWriter, err error) {
+	fmt.Printf("❌ Negotiation Error: %v\n", err)
+	switch {
+	case err == service.ErrApplicationNotFound:
+		util.RespondNotFound(w, "Application not found")
+	case err == service.ErrShiftNotFound:
+		util.RespondNotFound(w, "Shift not found")
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "Only the worker who applied and the shift's business negotiate its rate")
+	case errors.Is(err, service.ErrInvalidRateOffer), errors.Is(err, service.ErrBelowMinimumWage):
+		util.RespondBadRequest(w, err.Error())
+	case err == service.ErrNotYourTurn, err == service.ErrStatusChanged, errors.Is(err, service.ErrRateLocked):
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to negotiate the rate")
+	}
+}
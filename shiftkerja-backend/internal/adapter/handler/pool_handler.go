@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type PoolHandler struct {
+	Service *service.PoolService
+	Hub     *Hub
+}
+
+func NewPoolHandler(svc *service.PoolService, hub *Hub) *PoolHandler {
+	return &PoolHandler{Service: svc, Hub: hub}
+}
+
+// GetMyPool lists the workers in the business's pool
+func (h *PoolHandler) GetMyPool(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have worker pools")
+		return
+	}
+
+	members, err := h.Service.GetPool(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyPool Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	if members == nil {
+		members = []entity.PoolMember{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, members)
+}
+
+// AddToPool adds a worker to the business's pool
+func (h *PoolHandler) AddToPool(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have worker pools")
+		return
+	}
+
+	var req dto.PoolMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.WorkerID <= 0 {
+		util.RespondBadRequest(w, "Invalid worker_id")
+		return
+	}
+
+	member, err := h.Service.AddToPool(r.Context(), userID, req.WorkerID, req.Note)
+	if err != nil {
+		fmt.Printf("❌ AddToPool Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Worker added to your pool", member)
+}
+
+// RemoveFromPool takes a worker out of the business's pool
+func (h *PoolHandler) RemoveFromPool(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have worker pools")
+		return
+	}
+
+	var req dto.PoolMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.WorkerID <= 0 {
+		util.RespondBadRequest(w, "Invalid worker_id")
+		return
+	}
+
+	if err := h.Service.RemoveFromPool(r.Context(), userID, req.WorkerID); err != nil {
+		fmt.Printf("❌ RemoveFromPool Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Worker removed from your pool", map[string]interface{}{
+		"worker_id": req.WorkerID,
+	})
+}
+
+// SetVisibility changes who may see a shift and when it is released to everyone
+func (h *PoolHandler) SetVisibility(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can change shift visibility")
+		return
+	}
+
+	var req dto.ShiftVisibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id")
+		return
+	}
+
+	releaseAfter := time.Duration(req.ReleaseAfterHours * float64(time.Hour))
+	shift, err := h.Service.SetVisibility(r.Context(), req.ShiftID, userID, req.Visibility, releaseAfter)
+	if err != nil {
+		fmt.Printf("❌ SetVisibility Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Shift visibility updated successfully", shift)
+}
+
+// InviteWorkers lets workers see and apply to a restricted shift
+func (h *PoolHandler) InviteWorkers(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can invite workers")
+		return
+	}
+
+	var req dto.InviteWorkersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 || len(req.WorkerIDs) == 0 {
+		util.RespondBadRequest(w, "shift_id and at least one worker_id are required")
+		return
+	}
+
+	invites, err := h.Service.Invite(r.Context(), req.ShiftID, userID, req.WorkerIDs)
+	if err != nil {
+		fmt.Printf("❌ InviteWorkers Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	// Live update: only the invited workers may know the shift exists
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":     "shift_invite",
+			"shift_id": req.ShiftID,
+		}
+		for _, workerID := range req.WorkerIDs {
+			h.Hub.SendToUser(workerID, msg)
+		}
+		fmt.Printf("📡 Sent shift invite: Shift %d, %d workers\n", req.ShiftID, len(req.WorkerIDs))
+	}
+
+	util.RespondSuccess(w, "Workers invited successfully", invites)
+}
+
+// GetInvites lists the workers invited to a shift.
+// Query params: shift_id
+func (h *PoolHandler) GetInvites(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	invites, err := h.Service.GetInvites(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetInvites Error: %v\n", err)
+		respondPoolError(w, err)
+		return
+	}
+
+	if invites == nil {
+		invites = []entity.ShiftInvite{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, invites)
+}
+
+func respondPoolError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrShiftNotFound, service.ErrNotInPool:
+		util.RespondNotFound(w, err.Error())
+	case service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only manage your own shifts")
+	default:
+		if errors.Is(err, service.ErrInvalidVisibility) || errors.Is(err, service.ErrNotAWorker) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrBlocked) {
+			util.RespondForbidden(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to process worker pool")
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type RatingHandler struct {
+	Service *service.RatingService
+	Hub     *Hub
+}
+
+func NewRatingHandler(svc *service.RatingService, hub *Hub) *RatingHandler {
+	return &RatingHandler{Service: svc, Hub: hub}
+}
+
+// CreateRating lets a worker rate the business, or the business rate the worker,
+// after a completed accepted application
+func (h *RatingHandler) CreateRating(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" && role != "business" {
+		util.RespondForbidden(w, "Only workers and businesses can leave ratings")
+		return
+	}
+
+	var req dto.CreateRatingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ApplicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	rating, err := h.Service.Rate(r.Context(), userID, service.RatingInput{
+		ApplicationID: req.ApplicationID,
+		Stars:         req.Stars,
+		Tags:          req.Tags,
+		Comment:       req.Comment,
+	})
+	if err != nil {
+		fmt.Printf("❌ CreateRating Error: %v\n", err)
+		respondRatingError(w, err)
+		return
+	}
+
+	// Tell both sides once the ratings are revealed; the content stays behind the API
+	if h.Hub != nil && rating.PublishedAt != nil {
+		msg := map[string]interface{}{
+			"type":           "ratings_published",
+			"application_id": rating.ApplicationID,
+			"shift_id":       rating.ShiftID,
+		}
+		h.Hub.SendToUser(rating.RaterID, msg)
+		h.Hub.SendToUser(rating.RateeID, msg)
+		fmt.Printf("📡 Sent ratings published: Application %d\n", rating.ApplicationID)
+	}
+
+	util.RespondCreated(w, "Rating submitted successfully", rating)
+}
+
+// GetApplicationRatings returns the ratings of an application visible to the caller.
+// Query params: application_id
+func (h *RatingHandler) GetApplicationRatings(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	applicationID, err := strconv.ParseInt(r.URL.Query().Get("application_id"), 10, 64)
+	if err != nil || applicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id: must be a positive integer")
+		return
+	}
+
+	ratings, err := h.Service.GetApplicationRatings(r.Context(), applicationID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetApplicationRatings Error: %v\n", err)
+		respondRatingError(w, err)
+		return
+	}
+
+	if ratings == nil {
+		ratings = []entity.Rating{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, ratings)
+}
+
+// GetUserRatings returns a user's aggregate score and newest published ratings.
+// Query params: user_id, limit (optional, default 20)
+func (h *RatingHandler) GetUserRatings(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, err := strconv.ParseInt(q.Get("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		util.RespondBadRequest(w, "Invalid user_id: must be a positive integer")
+		return
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	summary, ratings, err := h.Service.GetUserRatings(r.Context(), userID, limit)
+	if err != nil {
+		fmt.Printf("❌ GetUserRatings Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve ratings")
+		return
+	}
+
+	if ratings == nil {
+		ratings = []entity.Rating{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"summary": summary,
+		"ratings": ratings,
+	})
+}
+
+// GetRatingTags lists the tags each direction may use
+func (h *RatingHandler) GetRatingTags(w http.ResponseWriter, r *http.Request) {
+	util.RespondJSON(w, http.StatusOK, entity.RatingTags)
+}
+
+func respondRatingError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrApplicationNotFound, err == service.ErrShiftNotFound:
+		util.RespondNotFound(w, err.Error())
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only rate shifts you took part in")
+	case err == service.ErrAlreadyRated:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	case err == service.ErrNotRatable, err == service.ErrRatingWindowClosed, errors.Is(err, service.ErrInvalidRating):
+		util.RespondBadRequest(w, err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process rating")
+	}
+}
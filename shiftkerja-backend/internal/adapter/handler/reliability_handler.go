@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type ReliabilityHandler struct {
+	Service *service.ReliabilityService
+}
+
+func NewReliabilityHandler(svc *service.ReliabilityService) *ReliabilityHandler {
+	return &ReliabilityHandler{Service: svc}
+}
+
+// GetMyReliability returns the calling worker's score, strikes, suspension and events
+func (h *ReliabilityHandler) GetMyReliability(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have a reliability record")
+		return
+	}
+
+	rel, err := h.Service.GetReliability(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyReliability Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve reliability")
+		return
+	}
+
+	if rel.Events == nil {
+		rel.Events = []entity.ReliabilityEvent{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, rel)
+}
+
+// GetWorkerReliability returns a worker's reliability to a business (score and
+// counts only) or an admin (with events).
+// Query params: worker_id
+func (h *ReliabilityHandler) GetWorkerReliability(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "business" && role != "admin" {
+		util.RespondForbidden(w, "Only businesses and admins can look up a worker's reliability")
+		return
+	}
+
+	workerID, err := strconv.ParseInt(r.URL.Query().Get("worker_id"), 10, 64)
+	if err != nil || workerID <= 0 {
+		util.RespondBadRequest(w, "Invalid worker_id: must be a positive integer")
+		return
+	}
+
+	rel, err := h.Service.GetReliability(r.Context(), workerID)
+	if err != nil {
+		fmt.Printf("❌ GetWorkerReliability Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve reliability")
+		return
+	}
+	if role != "admin" {
+		rel.Events = nil
+	}
+
+	util.RespondJSON(w, http.StatusOK, rel)
+}
+
+// CreateAppeal lets a worker contest one of their no-shows or late withdrawals
+func (h *ReliabilityHandler) CreateAppeal(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can appeal")
+		return
+	}
+
+	var req dto.CreateAppealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.EventID <= 0 {
+		util.RespondBadRequest(w, "Invalid event_id")
+		return
+	}
+
+	event, err := h.Service.Appeal(r.Context(), userID, req.EventID, req.Reason)
+	if err != nil {
+		fmt.Printf("❌ CreateAppeal Error: %v\n", err)
+		respondReliabilityError(w, err)
+		return
+	}
+
+	util.RespondCreated(w, "Appeal submitted successfully", event)
+}
+
+// GetAppeals lists appeals for review (admin only).
+// Query params: status (PENDING by default, UPHELD, OVERTURNED)
+func (h *ReliabilityHandler) GetAppeals(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can review appeals")
+		return
+	}
+
+	events, err := h.Service.GetAppeals(r.Context(), strings.ToUpper(r.URL.Query().Get("status")))
+	if err != nil {
+		fmt.Printf("❌ GetAppeals Error: %v\n", err)
+		respondReliabilityError(w, err)
+		return
+	}
+
+	if events == nil {
+		events = []entity.ReliabilityEvent{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, events)
+}
+
+// DecideAppeal upholds or overturns an appeal (admin only)
+func (h *ReliabilityHandler) DecideAppeal(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can decide appeals")
+		return
+	}
+
+	var req dto.DecideAppealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.EventID <= 0 {
+		util.RespondBadRequest(w, "Invalid event_id")
+		return
+	}
+
+	event, err := h.Service.DecideAppeal(r.Context(), userID, req.EventID, req.Decision, req.Note)
+	if err != nil {
+		fmt.Printf("❌ DecideAppeal Error: %v\n", err)
+		respondReliabilityError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Appeal decided successfully", event)
+}
+
+func respondReliabilityError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrReliabilityEventNotFound:
+		util.RespondNotFound(w, err.Error())
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only appeal your own record")
+	case errors.Is(err, service.ErrAppealNotAllowed):
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, service.ErrInvalidAppeal):
+		util.RespondBadRequest(w, err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process appeal")
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type ScreeningHandler struct {
+	Service *service.ScreeningService
+}
+
+func NewScreeningHandler(svc *service.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{Service: svc}
+}
+
+// SetQuestions replaces the screening questions of a shift nobody has applied to yet
+func (h *ScreeningHandler) SetQuestions(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can set screening questions")
+		return
+	}
+
+	var req dto.SetScreeningQuestionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id")
+		return
+	}
+
+	questions, err := h.Service.SetQuestions(r.Context(), req.ShiftID, userID, req.Questions)
+	if err != nil {
+		fmt.Printf("❌ SetQuestions Error: %v\n", err)
+		switch err {
+		case service.ErrShiftNotFound:
+			util.RespondNotFound(w, "Shift not found")
+		case service.ErrUnauthorized:
+			util.RespondForbidden(w, "You can only manage your own shifts")
+		default:
+			if errors.Is(err, service.ErrInvalidQuestions) {
+				util.RespondBadRequest(w, err.Error())
+				return
+			}
+			if errors.Is(err, service.ErrQuestionsLocked) {
+				util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+				return
+			}
+			util.RespondInternalError(w, "Failed to save screening questions")
+		}
+		return
+	}
+
+	if questions == nil {
+		questions = []entity.ScreeningQuestion{}
+	}
+
+	util.RespondSuccess(w, "Screening questions updated successfully", questions)
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type SeriesHandler struct {
+	Service *service.SeriesService
+}
+
+func NewSeriesHandler(svc *service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{Service: svc}
+}
+
+// GetMyTemplates returns the calling business's shift templates
+func (h *SeriesHandler) GetMyTemplates(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have shift templates")
+		return
+	}
+
+	templates, err := h.Service.GetMyTemplates(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyTemplates Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve templates")
+		return
+	}
+
+	if templates == nil {
+		templates = []entity.ShiftTemplate{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, templates)
+}
+
+// CreateTemplate saves a reusable shift definition
+func (h *SeriesHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can create shift templates")
+		return
+	}
+
+	var req dto.ShiftTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	template := templateFromRequest(req, userID)
+	if err := h.Service.CreateTemplate(r.Context(), template); err != nil {
+		fmt.Printf("❌ CreateTemplate Error: %v\n", err)
+		respondSeriesError(w, err)
+		return
+	}
+
+	util.RespondCreated(w, "Template created successfully", template)
+}
+
+// UpdateTemplate changes a template; already created shifts are not affected
+func (h *SeriesHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can update shift templates")
+		return
+	}
+
+	var req dto.ShiftTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ID <= 0 {
+		util.RespondBadRequest(w, "Invalid template ID")
+		return
+	}
+
+	template := templateFromRequest(req, userID)
+	if err := h.Service.UpdateTemplate(r.Context(), template, userID); err != nil {
+		fmt.Printf("❌ UpdateTemplate Error: %v\n", err)
+		respondSeriesError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Template updated successfully", template)
+}
+
+// DeleteTemplate removes a template that no series uses
+func (h *SeriesHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can delete shift templates")
+		return
+	}
+
+	templateID, err := strconv.ParseInt(r.URL.Query().Get("template_id"), 10, 64)
+	if err != nil || templateID <= 0 {
+		util.RespondBadRequest(w, "Invalid template_id: must be a positive integer")
+		return
+	}
+
+	if err := h.Service.DeleteTemplate(r.Context(), templateID, userID); err != nil {
+		fmt.Printf("❌ DeleteTemplate Error: %v\n", err)
+		respondSeriesError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Template deleted successfully", map[string]interface{}{
+		"template_id": templateID,
+	})
+}
+
+// GetMySeries returns the calling business's recurring series
+func (h *SeriesHandler) GetMySeries(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses have recurring shifts")
+		return
+	}
+
+	series, err := h.Service.GetMySeries(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMySeries Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve series")
+		return
+	}
+
+	if series == nil {
+		series = []entity.ShiftSeries{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, series)
+}
+
+// CreateSeries repeats a template according to a recurrence rule
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can create recurring shifts")
+		return
+	}
+
+	var req dto.CreateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.TemplateID <= 0 {
+		util.RespondBadRequest(w, "Invalid template_id")
+		return
+	}
+
+	series := &entity.ShiftSeries{
+		TemplateID: req.TemplateID,
+		OwnerID:    userID,
+		RRule:      req.RRule,
+		StartsOn:   req.StartsOn,
+	}
+	if err := h.Service.CreateSeries(r.Context(), series); err != nil {
+		fmt.Printf("❌ CreateSeries Error: %v\n", err)
+		respondSeriesError(w, err)
+		return
+	}
+
+	util.RespondCreated(w, "Recurring shift created successfully", series)
+}
+
+// CancelSeries stops a series and cancels its upcoming OPEN occurrences
+func (h *SeriesHandler) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can cancel recurring shifts")
+		return
+	}
+
+	seriesID, err := strconv.ParseInt(r.URL.Query().Get("series_id"), 10, 64)
+	if err != nil || seriesID <= 0 {
+		util.RespondBadRequest(w, "Invalid series_id: must be a positive integer")
+		return
+	}
+
+	if err := h.Service.CancelSeries(r.Context(), seriesID, userID); err != nil {
+		fmt.Printf("❌ CancelSeries Error: %v\n", err)
+		respondSeriesError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Recurring shift cancelled successfully", map[string]interface{}{
+		"series_id": seriesID,
+	})
+}
+
+func templateFromRequest(req dto.ShiftTemplateRequest, ownerID int64) *entity.ShiftTemplate {
+	return &entity.ShiftTemplate{
+		ID:              req.ID,
+		OwnerID:         ownerID,
+		Name:            req.Name,
+		Title:           req.Title,
+		Description:     req.Description,
+		PayRate:         req.PayRate,
+		PayUnit:         req.PayUnit,
+		LocationID:      req.LocationID,
+		CategoryID:      req.CategoryID,
+		SkillIDs:        req.SkillIDs,
+		StartMinute:     req.StartMinute,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        req.Timezone,
+
+		Visibility:        req.Visibility,
+		ReleaseAfterHours: req.ReleaseAfterHours,
+		Slots:             req.Slots,
+	}
+}
+
+func respondSeriesError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You don't have permission to manage this resource")
+	case err == service.ErrTemplateNotFound, err == service.ErrSeriesNotFound:
+		util.RespondNotFound(w, err.Error())
+	case err == service.ErrTemplateInUse:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrInvalidRRule),
+		errors.Is(err, service.ErrBelowMinimumWage), errors.Is(err, service.ErrInvalidVisibility),
+		errors.Is(err, service.ErrInvalidSlots), err == service.ErrCategoryNotFound,
+		err == service.ErrLocationNotFound, err == service.ErrLocationRequired, err == service.ErrSkillNotFound:
+		util.RespondBadRequest(w, err.Error())
+	default:
+		util.RespondInternalError(w, err.Error())
+	}
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"shiftkerja-backend/internal/core/dto"
 	"shiftkerja-backend/internal/core/entity"
@@ -14,13 +17,15 @@ import (
 
 type ShiftHandler struct {
 	Service *service.ShiftService
+	Ranking *service.ApplicantRankingService
 	Hub     *Hub
 }
 
 // Constructor using service layer (Clean Architecture)
-func NewShiftHandler(svc *service.ShiftService, hub *Hub) *ShiftHandler {
+func NewShiftHandler(svc *service.ShiftService, ranking *service.ApplicantRankingService, hub *Hub) *ShiftHandler {
 	return &ShiftHandler{
 		Service: svc,
+		Ranking: ranking,
 		Hub:     hub,
 	}
 }
@@ -50,8 +55,31 @@ func (h *ShiftHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var filter service.NearbyFilter
+	if raw := q.Get("category_id"); raw != "" {
+		categoryID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || categoryID <= 0 {
+			util.RespondBadRequest(w, "Invalid category_id: must be a positive integer")
+			return
+		}
+		filter.CategoryID = categoryID
+	}
+	if q.Get("available_only") == "true" {
+		role, _ := r.Context().Value("role").(string)
+		if role != "worker" {
+			util.RespondBadRequest(w, "available_only is only supported for workers")
+			return
+		}
+		filter.AvailableOnly = true
+		filter.WorkerID = int64(r.Context().Value("user_id").(float64))
+	}
+
+	// Pool-only and invite-only shifts are hidden from everyone else
+	filter.ViewerID = int64(r.Context().Value("user_id").(float64))
+	filter.ViewerRole, _ = r.Context().Value("role").(string)
+
 	// Call service layer
-	shifts, err := h.Service.GetNearbyShifts(r.Context(), lat, lng, rad)
+	shifts, err := h.Service.GetNearbyShifts(r.Context(), lat, lng, rad, filter)
 	if err != nil {
 		fmt.Printf("❌ GetNearby Error: %v\n", err)
 		util.RespondInternalError(w, "Failed to search for shifts")
@@ -66,6 +94,32 @@ func (h *ShiftHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
 	util.RespondJSON(w, http.StatusOK, shifts)
 }
 
+// GetShift returns one shift if the user may see it.
+// Query params: shift_id
+func (h *ShiftHandler) GetShift(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	shift, err := h.Service.GetShift(r.Context(), shiftID, userID, role)
+	if err != nil {
+		fmt.Printf("❌ GetShift Error: %v\n", err)
+		if err == service.ErrShiftNotFound {
+			util.RespondNotFound(w, "Shift not found")
+			return
+		}
+		util.RespondInternalError(w, "Failed to load shift")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, shift)
+}
+
 // Create handles shift creation (Business only)
 func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// 1. Security Check
@@ -89,10 +143,19 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 		util.RespondBadRequest(w, "Title is required")
 		return
 	}
-	if req.PayRate <= 0 {
+	if !req.PayRate.IsPositive() {
 		util.RespondBadRequest(w, "Pay rate must be greater than 0")
 		return
 	}
+	// Businesses post at a saved work site; admins may still give coordinates
+	if role == "business" && req.LocationID == nil {
+		util.RespondBadRequest(w, service.ErrLocationRequired.Error())
+		return
+	}
+	if req.LocationID == nil && req.Lat == 0 && req.Lng == 0 {
+		util.RespondBadRequest(w, "lat and lng are required without a location_id")
+		return
+	}
 	if req.Lat < -90 || req.Lat > 90 {
 		util.RespondBadRequest(w, "Latitude must be between -90 and 90")
 		return
@@ -101,6 +164,10 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 		util.RespondBadRequest(w, "Longitude must be between -180 and 180")
 		return
 	}
+	if req.ReleaseAfterHours < 0 {
+		util.RespondBadRequest(w, "release_after_hours can't be negative")
+		return
+	}
 
 	// 4. Convert to entity
 	shift := &entity.Shift{
@@ -108,20 +175,44 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Title:       req.Title,
 		Description: req.Description,
 		PayRate:     req.PayRate,
+		PayUnit:     req.PayUnit,
 		Lat:         req.Lat,
 		Lng:         req.Lng,
-		Status:      "OPEN",
+		LocationID:  req.LocationID,
+		Status:      entity.ShiftOpen,
+		CategoryID:  req.CategoryID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Slots:       req.Slots,
+		Visibility:  req.Visibility,
+
+		ScreeningQuestions: req.ScreeningQuestions,
+	}
+	if req.ReleaseAfterHours > 0 {
+		releaseAt := time.Now().Add(time.Duration(req.ReleaseAfterHours * float64(time.Hour)))
+		shift.ReleaseAt = &releaseAt
+	}
+	for _, skillID := range req.SkillIDs {
+		shift.RequiredSkills = append(shift.RequiredSkills, entity.Skill{ID: skillID})
 	}
 
 	// 5. Call service layer (handles dual-write)
 	if err := h.Service.CreateShift(r.Context(), shift); err != nil {
 		fmt.Printf("❌ Create Shift Error: %v\n", err)
-		util.RespondInternalError(w, err.Error())
+		switch {
+		case err == service.ErrCategoryNotFound, err == service.ErrSkillNotFound, err == service.ErrInvalidSchedule,
+			errors.Is(err, service.ErrInvalidPay), errors.Is(err, service.ErrBelowMinimumWage),
+			errors.Is(err, service.ErrInvalidVisibility), errors.Is(err, service.ErrInvalidQuestions),
+			errors.Is(err, service.ErrInvalidSlots), err == service.ErrLocationNotFound:
+			util.RespondBadRequest(w, err.Error())
+		default:
+			util.RespondInternalError(w, err.Error())
+		}
 		return
 	}
 
-	// 6. BROADCAST TO WEBSOCKET (Live Updates)
-	if h.Hub != nil {
+	// 6. BROADCAST TO WEBSOCKET (Live Updates, public shifts only)
+	if h.Hub != nil && shift.IsPublic(time.Now()) {
 		broadcastMsg := map[string]interface{}{
 			"type":     "shift_created",
 			"id":       shift.ID,
@@ -129,8 +220,12 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 			"lat":      shift.Lat,
 			"lng":      shift.Lng,
 			"pay_rate": shift.PayRate,
+			"pay_unit": shift.PayUnit,
 			"status":   shift.Status,
 		}
+		if shift.CategoryID != nil {
+			broadcastMsg["category_id"] = *shift.CategoryID
+		}
 		h.Hub.Broadcast(broadcastMsg)
 		fmt.Printf("📡 Broadcasted shift creation: %s\n", shift.Title)
 	}
@@ -175,23 +270,38 @@ func (h *ShiftHandler) Apply(w http.ResponseWriter, r *http.Request) {
 	fmt.Printf("🔄 Worker %d applying for shift %d\n", userID, req.ShiftID)
 
 	// 4. Call service layer
-	err := h.Service.ApplyForShift(r.Context(), req.ShiftID, userID)
+	app, err := h.Service.ApplyForShift(r.Context(), req.ShiftID, userID, req.CoverNote, req.Answers, req.ProposedRate)
 	if err != nil {
 		fmt.Printf("❌ Apply Error: %v\n", err)
 		
+		if errors.Is(err, service.ErrWorkerSuspended) {
+			util.RespondForbidden(w, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrInvalidAnswers) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		
 		// Send appropriate error response based on error type
 		switch err {
 		case service.ErrShiftNotFound:
 			util.RespondNotFound(w, "Shift not found")
 		case service.ErrApplicationExists:
 			util.RespondBadRequest(w, "You have already applied to this shift")
+		case service.ErrScheduleConflict:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
 		default:
 			util.RespondBadRequest(w, err.Error())
 		}
 		return
 	}
 
-	fmt.Printf("✅ Application successful: Worker %d -> Shift %d\n", userID, req.ShiftID)
+	if app.Status == entity.ApplicationRejected {
+		fmt.Printf("🚷 Application knocked out by screening: Worker %d -> Shift %d\n", userID, req.ShiftID)
+	} else {
+		fmt.Printf("✅ Application successful: Worker %d -> Shift %d\n", userID, req.ShiftID)
+	}
 
 	// 5. BROADCAST DETAILED APPLICATION EVENT
 	if h.Hub != nil {
@@ -202,31 +312,47 @@ func (h *ShiftHandler) Apply(w http.ResponseWriter, r *http.Request) {
 			"type":      "new_application",
 			"shift_id":  req.ShiftID,
 			"worker_id": userID,
-			"status":    "PENDING",
+			"status":    app.Status,
+		}
+		if app.RateTurn != "" {
+			broadcastMsg["proposed_rate"] = app.NegotiatedRate
 		}
 		
-		// Find the application we just created and add details
+		// Find the application we just created and add details; only the
+		// shift's business hears about it (the proposed rate and the title of
+		// a pool-only shift are nobody else's business)
 		for _, app := range applications {
 			if app.ShiftID == req.ShiftID {
 				broadcastMsg["application_id"] = app.ID
 				broadcastMsg["shift_title"] = app.ShiftTitle
 				broadcastMsg["shift_pay_rate"] = app.ShiftPayRate
 				broadcastMsg["created_at"] = app.CreatedAt
+				
+				h.Hub.SendToUser(app.ShiftOwnerID, broadcastMsg)
+				fmt.Printf("📡 Sent new application: Worker %d -> Shift %d\n", userID, req.ShiftID)
 				break
 			}
 		}
-		
-		h.Hub.Broadcast(broadcastMsg)
-		fmt.Printf("📡 Broadcasted new application: Worker %d -> Shift %d\n", userID, req.ShiftID)
 	}
 
-	util.RespondSuccess(w, "Application submitted successfully", map[string]interface{}{
-		"shift_id":  req.ShiftID,
-		"worker_id": userID,
-		"status":    "PENDING",
+	message := "Application submitted successfully"
+	if app.Status == entity.ApplicationRejected {
+		message = "Application submitted, but an answer to a screening question rules you out for this shift"
+	}
+	util.RespondSuccess(w, message, map[string]interface{}{
+		"application_id": app.ID,
+		"shift_id":       req.ShiftID,
+		"worker_id":      userID,
+		"status":         app.Status,
+		"proposed_rate":  app.NegotiatedRate,
 	})
 }
 
+// GetApplicationReasons lists the reason codes for rejections and withdrawals
+func (h *ShiftHandler) GetApplicationReasons(w http.ResponseWriter, r *http.Request) {
+	util.RespondJSON(w, http.StatusOK, entity.ApplicationReasons)
+}
+
 // GetMyShifts returns all shifts posted by the business owner
 func (h *ShiftHandler) GetMyShifts(w http.ResponseWriter, r *http.Request) {
 	userID := int64(r.Context().Value("user_id").(float64))
@@ -277,7 +403,8 @@ func (h *ShiftHandler) GetMyApplications(w http.ResponseWriter, r *http.Request)
 	util.RespondJSON(w, http.StatusOK, applications)
 }
 
-// GetShiftApplications returns all applications for a specific shift (business owner only)
+// GetShiftApplications returns all applications for a specific shift (business owner only).
+// With ?sort=ranked applicants are ordered by reliability, rating, distance and skill match.
 func (h *ShiftHandler) GetShiftApplications(w http.ResponseWriter, r *http.Request) {
 	userID := int64(r.Context().Value("user_id").(float64))
 	
@@ -308,7 +435,23 @@ func (h *ShiftHandler) GetShiftApplications(w http.ResponseWriter, r *http.Reque
 		applications = []entity.Application{}
 	}
 
-	util.RespondJSON(w, http.StatusOK, applications)
+	switch r.URL.Query().Get("sort") {
+	case "", "created_at":
+		util.RespondJSON(w, http.StatusOK, applications)
+	case "ranked":
+		ranked, err := h.Ranking.RankApplicants(r.Context(), shiftID, applications)
+		if err != nil {
+			fmt.Printf("❌ RankApplicants Error: %v\n", err)
+			util.RespondInternalError(w, "Failed to rank applications")
+			return
+		}
+		util.RespondJSON(w, http.StatusOK, map[string]interface{}{
+			"applicants": ranked,
+			"weights":    h.Ranking.Weights(),
+		})
+	default:
+		util.RespondBadRequest(w, "sort must be either created_at or ranked")
+	}
 }
 
 // UpdateApplicationStatus handles accepting/rejecting applications
@@ -332,12 +475,12 @@ func (h *ShiftHandler) UpdateApplicationStatus(w http.ResponseWriter, r *http.Re
 		util.RespondBadRequest(w, "Invalid application_id")
 		return
 	}
-	if req.Status != "ACCEPTED" && req.Status != "REJECTED" {
+	if req.Status != entity.ApplicationAccepted && req.Status != entity.ApplicationRejected {
 		util.RespondBadRequest(w, "Status must be either ACCEPTED or REJECTED")
 		return
 	}
 
-	err := h.Service.UpdateApplicationStatus(r.Context(), req.ApplicationID, userID, req.Status)
+	app, err := h.Service.UpdateApplicationStatus(r.Context(), req.ApplicationID, userID, req.Status, req.ReasonCode, req.ReasonNote)
 	if err != nil {
 		fmt.Printf("❌ Update Status Error: %v\n", err)
 		
@@ -348,22 +491,36 @@ func (h *ShiftHandler) UpdateApplicationStatus(w http.ResponseWriter, r *http.Re
 			util.RespondNotFound(w, "Shift not found")
 		case service.ErrInvalidStatus:
 			util.RespondBadRequest(w, "Invalid status transition")
+		case service.ErrBlocked:
+			util.RespondForbidden(w, err.Error())
+		case service.ErrWorkerDoubleBooked, service.ErrStatusChanged, service.ErrAwaitingReconfirmation:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
 		default:
+			if errors.Is(err, service.ErrIllegalTransition) {
+				util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+				return
+			}
 			util.RespondBadRequest(w, err.Error())
 		}
 		return
 	}
 
-	// BROADCAST APPLICATION STATUS UPDATE
+	// NOTIFY THE WORKER AND THE BUSINESS (the reason is for them only)
 	if h.Hub != nil {
-		broadcastMsg := map[string]interface{}{
+		msg := map[string]interface{}{
 			"type":           "application_status_updated",
 			"application_id": req.ApplicationID,
+			"shift_id":       app.ShiftID,
 			"new_status":     req.Status,
 			"updated_by":     userID,
 		}
-		h.Hub.Broadcast(broadcastMsg)
-		fmt.Printf("📡 Broadcasted status update: Application %d -> %s\n", req.ApplicationID, req.Status)
+		if req.Status == entity.ApplicationRejected {
+			msg["reason_code"] = app.ReasonCode
+			msg["reason_note"] = app.ReasonNote
+		}
+		h.Hub.SendToUser(app.WorkerID, msg)
+		h.Hub.SendToUser(userID, msg)
+		fmt.Printf("📡 Sent status update: Application %d -> %s\n", req.ApplicationID, req.Status)
 	}
 
 	util.RespondSuccess(w, "Application status updated successfully", map[string]interface{}{
@@ -372,6 +529,88 @@ func (h *ShiftHandler) UpdateApplicationStatus(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// BatchUpdateApplicationStatus accepts or rejects several applications at once.
+// Either all of them change or none do; each affected worker gets one message.
+func (h *ShiftHandler) BatchUpdateApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	// Only the shift's owner decides on its applicants, so admins are refused
+	// here rather than getting every item back as "not found"
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can update application status")
+		return
+	}
+
+	var req dto.BatchApplicationStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.Status != entity.ApplicationAccepted && req.Status != entity.ApplicationRejected {
+		util.RespondBadRequest(w, "Status must be either ACCEPTED or REJECTED")
+		return
+	}
+
+	results, err := h.Service.BatchUpdateApplicationStatus(r.Context(), req.ApplicationIDs, userID, req.Status, req.ReasonCode, req.ReasonNote)
+	if err != nil {
+		fmt.Printf("❌ Batch Update Status Error: %v\n", err)
+
+		switch {
+		case err == service.ErrBatchRejected:
+			util.RespondJSON(w, http.StatusConflict, dto.BatchErrorResponse{
+				ErrorResponse: dto.ErrorResponse{Error: "Conflict", Message: err.Error(), Code: http.StatusConflict},
+				Results:       results,
+			})
+		case err == service.ErrStatusChanged:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+		case err == service.ErrInvalidStatus, errors.Is(err, service.ErrInvalidBatch), errors.Is(err, service.ErrInvalidReason):
+			util.RespondBadRequest(w, err.Error())
+		default:
+			util.RespondInternalError(w, err.Error())
+		}
+		return
+	}
+
+	// One message per worker, however many of their applications changed, sent
+	// to that worker alone
+	if h.Hub != nil {
+		type workerUpdate struct{ applicationIDs, shiftIDs []int64 }
+		updates := map[int64]*workerUpdate{}
+		var workerIDs []int64
+		for _, res := range results {
+			u, ok := updates[res.WorkerID]
+			if !ok {
+				u = &workerUpdate{}
+				updates[res.WorkerID] = u
+				workerIDs = append(workerIDs, res.WorkerID)
+			}
+			u.applicationIDs = append(u.applicationIDs, res.ApplicationID)
+			if !slices.Contains(u.shiftIDs, res.ShiftID) {
+				u.shiftIDs = append(u.shiftIDs, res.ShiftID)
+			}
+		}
+		for _, workerID := range workerIDs {
+			msg := map[string]interface{}{
+				"type":            "applications_batch_updated",
+				"worker_id":       workerID,
+				"application_ids": updates[workerID].applicationIDs,
+				"shift_ids":       updates[workerID].shiftIDs,
+				"new_status":      req.Status,
+				"updated_by":      userID,
+			}
+			if req.Status == entity.ApplicationRejected {
+				msg["reason_code"] = req.ReasonCode
+				msg["reason_note"] = req.ReasonNote
+			}
+			h.Hub.SendToUser(workerID, msg)
+		}
+		fmt.Printf("📡 Sent batch status update: %d applications -> %s to %d workers\n", len(results), req.Status, len(workerIDs))
+	}
+
+	util.RespondSuccess(w, "Application statuses updated successfully", results)
+}
+
 // UpdateShift updates an existing shift
 func (h *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
 	userID := int64(r.Context().Value("user_id").(float64))
@@ -397,12 +636,12 @@ func (h *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
 		util.RespondBadRequest(w, "Title is required")
 		return
 	}
-	if req.PayRate <= 0 {
+	if !req.PayRate.IsPositive() {
 		util.RespondBadRequest(w, "Pay rate must be greater than 0")
 		return
 	}
-	if req.Status != "OPEN" && req.Status != "FILLED" {
-		util.RespondBadRequest(w, "Status must be either OPEN or FILLED")
+	if req.Status != "" && req.Status != entity.ShiftOpen && req.Status != entity.ShiftFilled {
+		util.RespondBadRequest(w, "Status must be either OPEN or FILLED (cancel through /shifts/cancel)")
 		return
 	}
 
@@ -413,12 +652,19 @@ func (h *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
 		Title:       req.Title,
 		Description: req.Description,
 		PayRate:     req.PayRate,
-		Lat:         req.Lat,
-		Lng:         req.Lng,
+		PayUnit:     req.PayUnit,
+		LocationID:  req.LocationID,
 		Status:      req.Status,
+		CategoryID:  req.CategoryID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Slots:       req.Slots,
+	}
+	for _, skillID := range req.SkillIDs {
+		shift.RequiredSkills = append(shift.RequiredSkills, entity.Skill{ID: skillID})
 	}
 
-	err := h.Service.UpdateShift(r.Context(), shift, userID)
+	revision, err := h.Service.UpdateShift(r.Context(), shift, userID)
 	if err != nil {
 		fmt.Printf("❌ Update Shift Error: %v\n", err)
 		
@@ -427,12 +673,33 @@ func (h *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
 			util.RespondForbidden(w, "You don't have permission to update this shift")
 		case service.ErrShiftNotFound:
 			util.RespondNotFound(w, "Shift not found")
+		case service.ErrStatusChanged:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
 		default:
+			if errors.Is(err, service.ErrIllegalTransition) {
+				util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+				return
+			}
 			util.RespondBadRequest(w, err.Error())
 		}
 		return
 	}
 
+	// Applicants affected by a material change have to re-confirm
+	if h.Hub != nil && revision != nil && len(revision.ReconfirmWorkers) > 0 {
+		msg := map[string]interface{}{
+			"type":             "shift_changed",
+			"shift_id":         shift.ID,
+			"version":          revision.Version,
+			"material_changes": revision.MaterialChanges,
+			"reconfirm_by":     revision.ReconfirmBy,
+		}
+		for _, workerID := range revision.ReconfirmWorkers {
+			h.Hub.SendToUser(workerID, msg)
+		}
+		fmt.Printf("📡 Sent shift changed: Shift %d v%d to %d applicants\n", shift.ID, revision.Version, len(revision.ReconfirmWorkers))
+	}
+
 	util.RespondSuccess(w, "Shift updated successfully", shift)
 }
 
@@ -467,6 +734,8 @@ func (h *ShiftHandler) DeleteShift(w http.ResponseWriter, r *http.Request) {
 			util.RespondForbidden(w, "You don't have permission to delete this shift")
 		case service.ErrShiftNotFound:
 			util.RespondNotFound(w, "Shift not found")
+		case service.ErrShiftHasAccepted, service.ErrShiftHasLedger:
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
 		default:
 			util.RespondInternalError(w, "Failed to delete shift")
 		}
@@ -501,21 +770,45 @@ func (h *ShiftHandler) DeleteApplication(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.Service.DeleteApplication(r.Context(), appID, userID)
+	q := r.URL.Query()
+	app, err := h.Service.WithdrawApplication(r.Context(), appID, userID, q.Get("reason_code"), q.Get("reason_note"))
 	if err != nil {
 		fmt.Printf("❌ Delete Application Error: %v\n", err)
 		
 		switch err {
 		case service.ErrUnauthorized:
 			util.RespondForbidden(w, "You don't have permission to delete this application")
+		case service.ErrApplicationNotFound:
+			util.RespondNotFound(w, "Application not found")
 		default:
+			if errors.Is(err, service.ErrIllegalTransition) || err == service.ErrStatusChanged {
+				util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+				return
+			}
 			util.RespondBadRequest(w, err.Error())
 		}
 		return
 	}
 
 	fmt.Printf("✅ Application %d withdrawn by worker %d\n", appID, userID)
+
+	// Let the business know (and why), and nobody else
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":           "application_withdrawn",
+			"application_id": app.ID,
+			"shift_id":       app.ShiftID,
+			"worker_id":      userID,
+			"reason_code":    app.ReasonCode,
+			"reason_note":    app.ReasonNote,
+		}
+		h.Hub.SendToUser(app.ShiftOwnerID, msg)
+		fmt.Printf("📡 Sent withdrawal: Application %d\n", app.ID)
+	}
+
 	util.RespondSuccess(w, "Application withdrawn successfully", map[string]interface{}{
 		"application_id": appID,
+		"status":         app.Status,
+		"reason_code":    app.ReasonCode,
 	})
 }
\ No newline at end of file
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type ShiftRevisionHandler struct {
+	Service *service.ShiftRevisionService
+	Hub     *Hub
+}
+
+func NewShiftRevisionHandler(svc *service.ShiftRevisionService, hub *Hub) *ShiftRevisionHandler {
+	return &ShiftRevisionHandler{Service: svc, Hub: hub}
+}
+
+// GetShiftHistory returns every version of a shift to its owner or its applicants.
+// Query params: shift_id
+func (h *ShiftRevisionHandler) GetShiftHistory(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	revisions, err := h.Service.GetHistory(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetShiftHistory Error: %v\n", err)
+		respondRevisionError(w, err)
+		return
+	}
+
+	if revisions == nil {
+		revisions = []entity.ShiftRevision{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, revisions)
+}
+
+// ReconfirmApplication lets a worker accept or decline a material change of a shift
+func (h *ShiftRevisionHandler) ReconfirmApplication(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can re-confirm their applications")
+		return
+	}
+
+	var req dto.ReconfirmApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ApplicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	released, err := h.Service.Reconfirm(r.Context(), req.ApplicationID, userID, req.Confirm)
+	if err != nil {
+		fmt.Printf("❌ ReconfirmApplication Error: %v\n", err)
+		respondRevisionError(w, err)
+		return
+	}
+
+	if released == nil {
+		util.RespondSuccess(w, "Application re-confirmed successfully", map[string]interface{}{
+			"application_id": req.ApplicationID,
+		})
+		return
+	}
+
+	// Live update for the shift's business (and the worker's other sessions)
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":           "application_released",
+			"application_id": released.ApplicationID,
+			"shift_id":       released.ShiftID,
+			"worker_id":      released.WorkerID,
+			"shift_reopened": released.ShiftReopened,
+		}
+		h.Hub.SendToUser(released.BusinessID, msg)
+		h.Hub.SendToUser(released.WorkerID, msg)
+		fmt.Printf("📡 Sent application released: Application %d\n", released.ApplicationID)
+	}
+
+	util.RespondSuccess(w, "Application withdrawn after the shift changed", released)
+}
+
+func respondRevisionError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrShiftNotFound, service.ErrApplicationNotFound:
+		util.RespondNotFound(w, err.Error())
+	case service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only see shifts you posted or applied to")
+	case service.ErrNothingToReconfirm:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process shift history")
+	}
+}
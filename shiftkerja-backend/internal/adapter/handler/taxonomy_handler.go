@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type TaxonomyHandler struct {
+	Service *service.TaxonomyService
+}
+
+func NewTaxonomyHandler(svc *service.TaxonomyService) *TaxonomyHandler {
+	return &TaxonomyHandler{Service: svc}
+}
+
+// ListCategories returns every job category
+func (h *TaxonomyHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.Service.ListCategories(r.Context())
+	if err != nil {
+		fmt.Printf("❌ ListCategories Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve categories")
+		return
+	}
+
+	if categories == nil {
+		categories = []entity.Category{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, categories)
+}
+
+// CreateCategory adds a job category (admin only)
+func (h *TaxonomyHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can manage categories")
+		return
+	}
+
+	var req dto.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	category := &entity.Category{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := h.Service.CreateCategory(r.Context(), category); err != nil {
+		fmt.Printf("❌ CreateCategory Error: %v\n", err)
+		if err == service.ErrTaxonomyNameNeeded {
+			util.RespondBadRequest(w, "Name is required")
+			return
+		}
+		util.RespondBadRequest(w, "Failed to create category (name might exist)")
+		return
+	}
+
+	util.RespondCreated(w, "Category created successfully", category)
+}
+
+// ListSkills returns all skills, optionally filtered with ?category_id=
+func (h *TaxonomyHandler) ListSkills(w http.ResponseWriter, r *http.Request) {
+	var categoryID int64
+	if raw := r.URL.Query().Get("category_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			util.RespondBadRequest(w, "Invalid category_id: must be a positive integer")
+			return
+		}
+		categoryID = id
+	}
+
+	skills, err := h.Service.ListSkills(r.Context(), categoryID)
+	if err != nil {
+		fmt.Printf("❌ ListSkills Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve skills")
+		return
+	}
+
+	if skills == nil {
+		skills = []entity.Skill{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, skills)
+}
+
+// CreateSkill adds a skill (admin only)
+func (h *TaxonomyHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
+	role := r.Context().Value("role").(string)
+	if role != "admin" {
+		util.RespondForbidden(w, "Only admins can manage skills")
+		return
+	}
+
+	var req dto.CreateSkillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	skill := &entity.Skill{
+		Name:       req.Name,
+		CategoryID: req.CategoryID,
+	}
+	if err := h.Service.CreateSkill(r.Context(), skill); err != nil {
+		fmt.Printf("❌ CreateSkill Error: %v\n", err)
+		switch err {
+		case service.ErrTaxonomyNameNeeded:
+			util.RespondBadRequest(w, "Name is required")
+		case service.ErrCategoryNotFound:
+			util.RespondNotFound(w, "Category not found")
+		default:
+			util.RespondBadRequest(w, "Failed to create skill (name might exist)")
+		}
+		return
+	}
+
+	util.RespondCreated(w, "Skill created successfully", skill)
+}
+
+// GetMySkills returns the skill profile of the calling worker
+func (h *TaxonomyHandler) GetMySkills(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have a skill profile")
+		return
+	}
+
+	skills, err := h.Service.GetWorkerSkills(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMySkills Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve skills")
+		return
+	}
+
+	if skills == nil {
+		skills = []entity.WorkerSkill{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, skills)
+}
+
+// UpdateMySkills replaces the skill profile of the calling worker
+func (h *TaxonomyHandler) UpdateMySkills(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have a skill profile")
+		return
+	}
+
+	var req dto.UpdateWorkerSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	skills := make([]entity.WorkerSkill, 0, len(req.Skills))
+	for _, in := range req.Skills {
+		if in.SkillID <= 0 {
+			util.RespondBadRequest(w, "Invalid skill_id: must be greater than 0")
+			return
+		}
+		skills = append(skills, entity.WorkerSkill{
+			SkillID:         in.SkillID,
+			Level:           in.Level,
+			YearsExperience: in.YearsExperience,
+		})
+	}
+
+	if err := h.Service.UpdateWorkerSkills(r.Context(), userID, skills); err != nil {
+		fmt.Printf("❌ UpdateMySkills Error: %v\n", err)
+		util.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	updated, _ := h.Service.GetWorkerSkills(r.Context(), userID)
+	if updated == nil {
+		updated = []entity.WorkerSkill{}
+	}
+
+	util.RespondSuccess(w, "Skills updated successfully", updated)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type TimesheetHandler struct {
+	Service *service.TimesheetService
+	Hub     *Hub
+}
+
+func NewTimesheetHandler(svc *service.TimesheetService, hub *Hub) *TimesheetHandler {
+	return &TimesheetHandler{Service: svc, Hub: hub}
+}
+
+// GetTimesheets lists the caller's timesheets (worker: own, business: their shifts).
+// Query params: status (optional)
+func (h *TimesheetHandler) GetTimesheets(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" && role != "business" {
+		util.RespondForbidden(w, "Only workers and businesses have timesheets")
+		return
+	}
+
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+	timesheets, err := h.Service.GetTimesheets(r.Context(), userID, role, status)
+	if err != nil {
+		fmt.Printf("❌ GetTimesheets Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve timesheets")
+		return
+	}
+
+	if timesheets == nil {
+		timesheets = []entity.Timesheet{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, timesheets)
+}
+
+// GetTimesheet returns one timesheet with its full change history
+func (h *TimesheetHandler) GetTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	timesheetID, err := strconv.ParseInt(r.URL.Query().Get("timesheet_id"), 10, 64)
+	if err != nil || timesheetID <= 0 {
+		util.RespondBadRequest(w, "Invalid timesheet_id: must be a positive integer")
+		return
+	}
+
+	ts, history, err := h.Service.GetTimesheet(r.Context(), timesheetID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetTimesheet Error: %v\n", err)
+		respondTimesheetError(w, err)
+		return
+	}
+
+	if history == nil {
+		history = []entity.TimesheetHistoryEntry{}
+	}
+
+	util.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"timesheet": ts,
+		"history":   history,
+	})
+}
+
+// ReviewTimesheet lets the business approve, edit or dispute a timesheet
+func (h *TimesheetHandler) ReviewTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can review timesheets")
+		return
+	}
+
+	var req dto.ReviewTimesheetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.TimesheetID <= 0 {
+		util.RespondBadRequest(w, "Invalid timesheet_id")
+		return
+	}
+
+	ts, err := h.Service.Review(r.Context(), req.TimesheetID, userID, service.TimesheetReview{
+		Action:       strings.ToUpper(req.Action),
+		StartedAt:    req.StartedAt,
+		EndedAt:      req.EndedAt,
+		BreakMinutes: req.BreakMinutes,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		fmt.Printf("❌ ReviewTimesheet Error: %v\n", err)
+		respondTimesheetError(w, err)
+		return
+	}
+
+	h.notify(ts, userID)
+	util.RespondSuccess(w, "Timesheet updated successfully", ts)
+}
+
+// RespondTimesheet lets the worker accept or contest an edited timesheet
+func (h *TimesheetHandler) RespondTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can respond to timesheet edits")
+		return
+	}
+
+	var req dto.RespondTimesheetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.TimesheetID <= 0 {
+		util.RespondBadRequest(w, "Invalid timesheet_id")
+		return
+	}
+
+	ts, err := h.Service.Respond(r.Context(), req.TimesheetID, userID, req.Action, req.Reason)
+	if err != nil {
+		fmt.Printf("❌ RespondTimesheet Error: %v\n", err)
+		respondTimesheetError(w, err)
+		return
+	}
+
+	h.notify(ts, userID)
+	util.RespondSuccess(w, "Timesheet updated successfully", ts)
+}
+
+// notify tells the timesheet's worker and business about the change
+func (h *TimesheetHandler) notify(ts *entity.Timesheet, actorID int64) {
+	if h.Hub == nil {
+		return
+	}
+	msg := map[string]interface{}{
+		"type":         "timesheet_updated",
+		"timesheet_id": ts.ID,
+		"shift_id":     ts.ShiftID,
+		"worker_id":    ts.WorkerID,
+		"business_id":  ts.BusinessID,
+		"new_status":   ts.Status,
+		"updated_by":   actorID,
+	}
+	h.Hub.SendToUser(ts.WorkerID, msg)
+	h.Hub.SendToUser(ts.BusinessID, msg)
+	fmt.Printf("📡 Sent timesheet update: Timesheet %d -> %s\n", ts.ID, ts.Status)
+}
+
+func respondTimesheetError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrTimesheetNotFound:
+		util.RespondNotFound(w, err.Error())
+	case err == service.ErrUnauthorized:
+		util.RespondForbidden(w, "You don't have permission to access this timesheet")
+	case err == service.ErrTimesheetChanged:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, service.ErrTimesheetAction), errors.Is(err, service.ErrInvalidTimesheet),
+		err == service.ErrTimesheetReasonMissing:
+		util.RespondBadRequest(w, err.Error())
+	default:
+		util.RespondInternalError(w, "Failed to process timesheet")
+	}
+}
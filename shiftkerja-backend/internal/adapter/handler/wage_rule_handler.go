@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type WageRuleHandler struct {
+	Service *service.WageRuleService
+}
+
+func NewWageRuleHandler(svc *service.WageRuleService) *WageRuleHandler {
+	return &WageRuleHandler{Service: svc}
+}
+
+// GetWageRules returns the minimum wage region and public holiday for a place and day,
+// so businesses can see the floor before posting.
+// Query params: lat, lng, date (YYYY-MM-DD, default today)
+func (h *WageRuleHandler) GetWageRules(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		util.RespondBadRequest(w, "Invalid latitude: must be between -90 and 90")
+		return
+	}
+	lng, err := strconv.ParseFloat(q.Get("lng"), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		util.RespondBadRequest(w, "Invalid longitude: must be between -180 and 180")
+		return
+	}
+
+	day := time.Now()
+	if raw := q.Get("date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			util.RespondBadRequest(w, "Invalid date: must be YYYY-MM-DD")
+			return
+		}
+		day = parsed.Add(6 * time.Hour) // Still the same date in every Indonesian timezone
+	}
+
+	util.RespondJSON(w, http.StatusOK, h.Service.Check(lat, lng, day))
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type WaitlistHandler struct {
+	Service *service.WaitlistService
+	Hub     *Hub
+}
+
+func NewWaitlistHandler(svc *service.WaitlistService, hub *Hub) *WaitlistHandler {
+	return &WaitlistHandler{Service: svc, Hub: hub}
+}
+
+// SetStandby lets a business rank the applicants who are offered the shift
+// if the spot frees up
+func (h *WaitlistHandler) SetStandby(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "business" {
+		util.RespondForbidden(w, "Only businesses can manage standby lists")
+		return
+	}
+
+	var req dto.StandbyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ShiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id")
+		return
+	}
+
+	standby, err := h.Service.SetStandby(r.Context(), req.ShiftID, userID, req.ApplicationIDs, req.AutoRank)
+	if err != nil {
+		fmt.Printf("❌ SetStandby Error: %v\n", err)
+		respondWaitlistError(w, err)
+		return
+	}
+
+	util.RespondSuccess(w, "Standby list updated successfully", standby)
+}
+
+// GetStandby returns a shift's standby list to its owner.
+// Query params: shift_id
+func (h *WaitlistHandler) GetStandby(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+
+	shiftID, err := strconv.ParseInt(r.URL.Query().Get("shift_id"), 10, 64)
+	if err != nil || shiftID <= 0 {
+		util.RespondBadRequest(w, "Invalid shift_id: must be a positive integer")
+		return
+	}
+
+	standby, err := h.Service.GetStandby(r.Context(), shiftID, userID)
+	if err != nil {
+		fmt.Printf("❌ GetStandby Error: %v\n", err)
+		respondWaitlistError(w, err)
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, standby)
+}
+
+// RespondToOffer lets a standby worker take or turn down an offered spot
+func (h *WaitlistHandler) RespondToOffer(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers can respond to offers")
+		return
+	}
+
+	var req dto.OfferResponseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+	if req.ApplicationID <= 0 {
+		util.RespondBadRequest(w, "Invalid application_id")
+		return
+	}
+
+	app, err := h.Service.RespondToOffer(r.Context(), req.ApplicationID, userID, req.Accept)
+	if err != nil {
+		fmt.Printf("❌ RespondToOffer Error: %v\n", err)
+		respondWaitlistError(w, err)
+		return
+	}
+
+	// Live update for the worker and the shift's business
+	if h.Hub != nil {
+		msg := map[string]interface{}{
+			"type":           "application_status_updated",
+			"application_id": app.ID,
+			"shift_id":       app.ShiftID,
+			"worker_id":      app.WorkerID,
+			"status":         app.Status,
+		}
+		h.Hub.SendToUser(app.WorkerID, msg)
+		h.Hub.SendToUser(app.ShiftOwnerID, msg)
+		fmt.Printf("📡 Sent status update: Application %d -> %s\n", app.ID, app.Status)
+	}
+
+	if req.Accept {
+		util.RespondSuccess(w, "Spot accepted successfully", app)
+		return
+	}
+	util.RespondSuccess(w, "Offer declined", app)
+}
+
+func respondWaitlistError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrShiftNotFound, service.ErrApplicationNotFound:
+		util.RespondNotFound(w, err.Error())
+	case service.ErrUnauthorized:
+		util.RespondForbidden(w, "You can only manage your own shifts and offers")
+	case service.ErrBlocked:
+		util.RespondForbidden(w, err.Error())
+	case service.ErrNoOpenOffer, service.ErrScheduleConflict, service.ErrStatusChanged:
+		util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		switch {
+		case errors.Is(err, service.ErrNotOnStandby):
+			util.RespondBadRequest(w, err.Error())
+		case errors.Is(err, service.ErrWorkerSuspended):
+			util.RespondForbidden(w, err.Error())
+		case errors.Is(err, service.ErrIllegalTransition):
+			util.RespondError(w, http.StatusConflict, "Conflict", err.Error())
+		default:
+			util.RespondInternalError(w, "Failed to process standby list")
+		}
+	}
+}
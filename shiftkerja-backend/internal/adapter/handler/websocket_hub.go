@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"sync"
 
+	"shiftkerja-backend/internal/core/service"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -18,15 +20,16 @@ var upgrader = websocket.Upgrader{
 
 // Hub maintains the set of active clients
 type Hub struct {
-	// A map to track active clients (Thread-safe)
-	Clients map[*websocket.Conn]bool
+	// A map to track active clients and the user each one signed in as
+	// (0 for anonymous clients) (Thread-safe)
+	Clients map[*websocket.Conn]int64
 	// Mutex to lock the map when adding/removing clients
 	Mutex sync.Mutex
 }
 
 func NewHub() *Hub {
 	return &Hub{
-		Clients: make(map[*websocket.Conn]bool),
+		Clients: make(map[*websocket.Conn]int64),
 	}
 }
 
@@ -45,8 +48,42 @@ func (h *Hub) Broadcast(message interface{}) {
 	}
 }
 
+// SendToUser sends a message only to the clients signed in as the user, for
+// anything that isn't everyone's business (e.g. a rejection note)
+func (h *Hub) SendToUser(userID int64, message interface{}) {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+
+	for client, clientUserID := range h.Clients {
+		if clientUserID != userID {
+			continue
+		}
+		err := client.WriteJSON(message)
+		if err != nil {
+			fmt.Printf("❌ Failed to send to user %d: %v\n", userID, err)
+			client.Close()
+			delete(h.Clients, client)
+		}
+	}
+}
+
 // HandleWS is the endpoint: ws://localhost:8080/ws
+// Clients that pass their JWT as ?token= also get the messages meant for them
+// alone (see SendToUser).
 func (h *Hub) HandleWS(w http.ResponseWriter, r *http.Request) {
+	// Browsers can't set headers on a WebSocket, so the token comes in the query
+	var userID int64
+	if token := r.URL.Query().Get("token"); token != "" {
+		claims, err := service.ValidateToken(token)
+		if err != nil {
+			http.Error(w, "Invalid or Expired Token", http.StatusUnauthorized)
+			return
+		}
+		if id, ok := claims["user_id"].(float64); ok {
+			userID = int64(id)
+		}
+	}
+
 	// 1. Upgrade HTTP -> WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -56,7 +93,7 @@ func (h *Hub) HandleWS(w http.ResponseWriter, r *http.Request) {
 
 	// 2. Register Client
 	h.Mutex.Lock()
-	h.Clients[conn] = true
+	h.Clients[conn] = userID
 	h.Mutex.Unlock()
 	
 	fmt.Println("🟢 New Client Connected!")
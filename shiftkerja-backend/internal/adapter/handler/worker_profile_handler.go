@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"shiftkerja-backend/internal/core/dto"
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/service"
+	"shiftkerja-backend/pkg/util"
+)
+
+type WorkerProfileHandler struct {
+	Service *service.WorkerProfileService
+}
+
+func NewWorkerProfileHandler(svc *service.WorkerProfileService) *WorkerProfileHandler {
+	return &WorkerProfileHandler{Service: svc}
+}
+
+// GetMyPreferences returns the calling worker's preferences
+func (h *WorkerProfileHandler) GetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have preferences")
+		return
+	}
+
+	prefs, err := h.Service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyPreferences Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve preferences")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, prefs)
+}
+
+// UpdateMyPreferences saves the calling worker's preferences
+func (h *WorkerProfileHandler) UpdateMyPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have preferences")
+		return
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	prefs := &entity.WorkerPreferences{
+		WorkerID:      userID,
+		MinPayRate:    req.MinPayRate,
+		HomeLat:       req.HomeLat,
+		HomeLng:       req.HomeLng,
+		MaxDistanceKm: req.MaxDistanceKm,
+		Timezone:      req.Timezone,
+	}
+	if err := h.Service.UpdatePreferences(r.Context(), prefs); err != nil {
+		fmt.Printf("❌ UpdateMyPreferences Error: %v\n", err)
+		if errors.Is(err, service.ErrInvalidPreferences) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to save preferences")
+		return
+	}
+
+	util.RespondSuccess(w, "Preferences updated successfully", prefs)
+}
+
+// GetMyAvailability returns the calling worker's availability calendar
+func (h *WorkerProfileHandler) GetMyAvailability(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have an availability calendar")
+		return
+	}
+
+	av, err := h.Service.GetAvailability(r.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ GetMyAvailability Error: %v\n", err)
+		util.RespondInternalError(w, "Failed to retrieve availability")
+		return
+	}
+
+	util.RespondJSON(w, http.StatusOK, av)
+}
+
+// UpdateMyAvailability replaces the calling worker's availability calendar
+func (h *WorkerProfileHandler) UpdateMyAvailability(w http.ResponseWriter, r *http.Request) {
+	userID := int64(r.Context().Value("user_id").(float64))
+	role := r.Context().Value("role").(string)
+
+	if role != "worker" {
+		util.RespondForbidden(w, "Only workers have an availability calendar")
+		return
+	}
+
+	var req dto.UpdateAvailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.RespondBadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	av := &entity.WorkerAvailability{
+		WorkerID:  userID,
+		Timezone:  req.Timezone,
+		Windows:   make([]entity.AvailabilityWindow, 0, len(req.Windows)),
+		Blackouts: make([]entity.BlackoutDate, 0, len(req.Blackouts)),
+	}
+	for _, in := range req.Windows {
+		av.Windows = append(av.Windows, entity.AvailabilityWindow{
+			Weekday:     in.Weekday,
+			StartMinute: in.StartMinute,
+			EndMinute:   in.EndMinute,
+		})
+	}
+	for _, in := range req.Blackouts {
+		av.Blackouts = append(av.Blackouts, entity.BlackoutDate{Date: in.Date, Reason: in.Reason})
+	}
+
+	if err := h.Service.UpdateAvailability(r.Context(), av); err != nil {
+		fmt.Printf("❌ UpdateMyAvailability Error: %v\n", err)
+		if errors.Is(err, service.ErrInvalidAvailability) {
+			util.RespondBadRequest(w, err.Error())
+			return
+		}
+		util.RespondInternalError(w, "Failed to save availability")
+		return
+	}
+
+	util.RespondSuccess(w, "Availability updated successfully", av)
+}
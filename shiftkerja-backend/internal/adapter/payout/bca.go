@@ -0,0 +1,51 @@
+package payout
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+const bankCodeBCA = "014"
+
+// BCA writes a header record followed by one record per transfer, split into
+// in-house (BCA to BCA) and inter-bank (LLG) transfers. Check the column order
+// against the current KlikBCA Bisnis bulk upload template before going live.
+type BCA struct{}
+
+func (BCA) Code() string { return "bca" }
+
+func (BCA) FileName(batch *entity.PayoutBatch) string {
+	return fmt.Sprintf("BCA_BULK_%d_%s.csv", batch.ID, batch.CreatedAt.Format("20060102"))
+}
+
+func (BCA) Write(w io.Writer, batch *entity.PayoutBatch, lines []entity.PayoutLine) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"H",
+		batch.CreatedAt.Format("20060102"),
+		strconv.Itoa(len(lines)),
+		formatAmount(batch.TotalAmount),
+	})
+	for i, l := range lines {
+		method := "LLG"
+		if l.BankCode == bankCodeBCA {
+			method = "BCA"
+		}
+		cw.Write([]string{
+			"D",
+			strconv.Itoa(i + 1),
+			method,
+			l.BankCode,
+			l.AccountNumber,
+			l.AccountHolder,
+			formatAmount(l.Amount),
+			reference(batch, l),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
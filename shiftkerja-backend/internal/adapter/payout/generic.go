@@ -0,0 +1,58 @@
+package payout
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// Formats returns every supported bulk-transfer layout keyed by its code
+func Formats() map[string]port.PayoutFormatter {
+	formats := map[string]port.PayoutFormatter{}
+	for _, f := range []port.PayoutFormatter{Generic{}, BCA{}, Mandiri{}} {
+		formats[f.Code()] = f
+	}
+	return formats
+}
+
+// Generic is a plain CSV with a header row, for reconciliation or banks without a dedicated layout
+type Generic struct{}
+
+func (Generic) Code() string { return "generic" }
+
+func (Generic) FileName(batch *entity.PayoutBatch) string {
+	return fmt.Sprintf("payout_%d_%s.csv", batch.ID, batch.CreatedAt.Format("20060102"))
+}
+
+func (Generic) Write(w io.Writer, batch *entity.PayoutBatch, lines []entity.PayoutLine) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"batch_id", "worker_id", "bank_code", "account_number", "account_holder", "amount", "currency", "reference"})
+	for _, l := range lines {
+		cw.Write([]string{
+			strconv.FormatInt(batch.ID, 10),
+			strconv.FormatInt(l.WorkerID, 10),
+			l.BankCode,
+			l.AccountNumber,
+			l.AccountHolder,
+			formatAmount(l.Amount),
+			l.Amount.Currency,
+			reference(batch, l),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatAmount writes amounts in major units without thousand separators, e.g. 150000.00
+func formatAmount(amount entity.Money) string {
+	return amount.Decimal()
+}
+
+// reference is the transfer remark shown on the worker's bank statement
+func reference(batch *entity.PayoutBatch, l entity.PayoutLine) string {
+	return fmt.Sprintf("SHIFTKERJA %d-%d", batch.ID, l.WorkerID)
+}
@@ -0,0 +1,36 @@
+package payout
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// Mandiri writes one record per transfer with the currency on every line and
+// no header. Check the column order against the current Mandiri Cash
+// Management bulk template before going live.
+type Mandiri struct{}
+
+func (Mandiri) Code() string { return "mandiri" }
+
+func (Mandiri) FileName(batch *entity.PayoutBatch) string {
+	return fmt.Sprintf("MCM_%s_%d.csv", batch.CreatedAt.Format("20060102"), batch.ID)
+}
+
+func (Mandiri) Write(w io.Writer, batch *entity.PayoutBatch, lines []entity.PayoutLine) error {
+	cw := csv.NewWriter(w)
+	for _, l := range lines {
+		cw.Write([]string{
+			l.AccountNumber,
+			l.AccountHolder,
+			l.Amount.Currency,
+			formatAmount(l.Amount),
+			reference(batch, l),
+			l.BankCode,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
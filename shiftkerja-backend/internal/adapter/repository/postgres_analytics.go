@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAnalyticsRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresAnalyticsRepo(db *pgxpool.Pool) *PostgresAnalyticsRepo {
+	return &PostgresAnalyticsRepo{DB: db}
+}
+
+// GetReasonCounts counts rejections and withdrawals per reason code, most
+// frequent first
+func (r *PostgresAnalyticsRepo) GetReasonCounts(ctx context.Context, businessID int64, since time.Time) ([]entity.ReasonCount, error) {
+	query := `
+		SELECT a.status, COALESCE(NULLIF(a.reason_code, ''), 'UNSPECIFIED') AS code, COUNT(*)
+		FROM applications a
+		JOIN shifts s ON s.id = a.shift_id
+		WHERE s.owner_id = $1 AND a.status IN ('REJECTED', 'WITHDRAWN') AND a.decided_at >= $2
+		GROUP BY a.status, code
+		ORDER BY a.status, COUNT(*) DESC, code
+	`
+	rows, err := r.DB.Query(ctx, query, businessID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reason counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []entity.ReasonCount
+	for rows.Next() {
+		var c entity.ReasonCount
+		if err := rows.Scan(&c.Status, &c.ReasonCode, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan reason count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAttendanceRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresAttendanceRepo(db *pgxpool.Pool) *PostgresAttendanceRepo {
+	return &PostgresAttendanceRepo{DB: db}
+}
+
+const attendanceColumns = `a.id, a.application_id, a.shift_id, a.worker_id,
+	a.clock_in_at, a.clock_in_lat, a.clock_in_lng, a.clock_in_distance_m, a.clock_in_within_fence, a.late_minutes,
+	a.clock_in_qr_verified, a.clock_out_at, a.clock_out_lat, a.clock_out_lng, a.clock_out_distance_m, a.clock_out_within_fence,
+	a.created_at, u.full_name`
+
+func scanAttendance(row pgx.Row, a *entity.Attendance) error {
+	return row.Scan(
+		&a.ID,
+		&a.ApplicationID,
+		&a.ShiftID,
+		&a.WorkerID,
+		&a.ClockInAt,
+		&a.ClockInLat,
+		&a.ClockInLng,
+		&a.ClockInDistanceM,
+		&a.ClockInWithinFence,
+		&a.LateMinutes,
+		&a.ClockInQRVerified,
+		&a.ClockOutAt,
+		&a.ClockOutLat,
+		&a.ClockOutLng,
+		&a.ClockOutDistanceM,
+		&a.ClockOutWithinFence,
+		&a.CreatedAt,
+		&a.WorkerName,
+	)
+}
+
+// CreateClockIn records a worker's clock-in
+func (r *PostgresAttendanceRepo) CreateClockIn(ctx context.Context, a *entity.Attendance) error {
+	query := `
+		INSERT INTO attendance (application_id, shift_id, worker_id, clock_in_at, clock_in_lat, clock_in_lng,
+			clock_in_distance_m, clock_in_within_fence, late_minutes, clock_in_qr_verified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (application_id) DO NOTHING
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		a.ApplicationID,
+		a.ShiftID,
+		a.WorkerID,
+		a.ClockInAt,
+		a.ClockInLat,
+		a.ClockInLng,
+		a.ClockInDistanceM,
+		a.ClockInWithinFence,
+		a.LateMinutes,
+		a.ClockInQRVerified,
+	).Scan(&a.ID, &a.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("already clocked in")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record clock-in: %w", err)
+	}
+	return nil
+}
+
+// UpdateClockOut records a worker's clock-out; a record can only be clocked out once
+func (r *PostgresAttendanceRepo) UpdateClockOut(ctx context.Context, a *entity.Attendance) error {
+	query := `
+		UPDATE attendance
+		SET clock_out_at = $1, clock_out_lat = $2, clock_out_lng = $3,
+			clock_out_distance_m = $4, clock_out_within_fence = $5
+		WHERE id = $6 AND clock_out_at IS NULL
+	`
+	result, err := r.DB.Exec(ctx, query,
+		a.ClockOutAt,
+		a.ClockOutLat,
+		a.ClockOutLng,
+		a.ClockOutDistanceM,
+		a.ClockOutWithinFence,
+		a.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record clock-out: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("already clocked out")
+	}
+	return nil
+}
+
+// GetAttendanceByApplication retrieves the attendance of one application (nil if none)
+func (r *PostgresAttendanceRepo) GetAttendanceByApplication(ctx context.Context, applicationID int64) (*entity.Attendance, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		JOIN users u ON a.worker_id = u.id
+		WHERE a.application_id = $1
+	`
+	var a entity.Attendance
+	err := scanAttendance(r.DB.QueryRow(ctx, query, applicationID), &a)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	return &a, nil
+}
+
+// GetAttendanceByShift retrieves all attendance records of a shift
+func (r *PostgresAttendanceRepo) GetAttendanceByShift(ctx context.Context, shiftID int64) ([]entity.Attendance, error) {
+	query := `
+		SELECT ` + attendanceColumns + `
+		FROM attendance a
+		JOIN users u ON a.worker_id = u.id
+		WHERE a.shift_id = $1
+		ORDER BY a.clock_in_at
+	`
+	rows, err := r.DB.Query(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var records []entity.Attendance
+	for rows.Next() {
+		var a entity.Attendance
+		if err := scanAttendance(rows, &a); err != nil {
+			return nil, fmt.Errorf("failed to scan attendance: %w", err)
+		}
+		records = append(records, a)
+	}
+	return records, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresBlockRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresBlockRepo(db *pgxpool.Pool) *PostgresBlockRepo {
+	return &PostgresBlockRepo{DB: db}
+}
+
+// CreateBlock stores a block and undoes the pool membership and invites between the pair
+func (r *PostgresBlockRepo) CreateBlock(ctx context.Context, b *entity.Block) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO blocks (blocker_id, blocked_id, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING id, created_at
+	`, b.BlockerID, b.BlockedID, b.Reason).Scan(&b.ID, &b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create block: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM pool_members
+		WHERE (business_id = $1 AND worker_id = $2) OR (business_id = $2 AND worker_id = $1)
+	`, b.BlockerID, b.BlockedID)
+	if err != nil {
+		return fmt.Errorf("failed to remove pool membership: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		DELETE FROM shift_invites i
+		USING shifts s
+		WHERE s.id = i.shift_id
+			AND ((s.owner_id = $1 AND i.worker_id = $2) OR (s.owner_id = $2 AND i.worker_id = $1))
+	`, b.BlockerID, b.BlockedID)
+	if err != nil {
+		return fmt.Errorf("failed to remove invites: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteBlock lifts a block made by the blocker
+func (r *PostgresBlockRepo) DeleteBlock(ctx context.Context, blockerID, blockedID int64) (bool, error) {
+	result, err := r.DB.Exec(ctx, `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete block: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// blockColumns is the column list read by queryBlocks
+const blockColumns = `b.id, b.blocker_id, b.blocked_id, b.reason, b.created_at, ur.full_name, ud.full_name`
+
+// GetBlocksBy lists the blocks a user made, newest first
+func (r *PostgresBlockRepo) GetBlocksBy(ctx context.Context, blockerID int64) ([]entity.Block, error) {
+	query := `
+		SELECT ` + blockColumns + `
+		FROM blocks b
+		JOIN users ur ON ur.id = b.blocker_id
+		JOIN users ud ON ud.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+	`
+	return r.queryBlocks(ctx, query, blockerID)
+}
+
+// GetBlocksInvolving lists the blocks made by or against a user, newest first
+func (r *PostgresBlockRepo) GetBlocksInvolving(ctx context.Context, userID int64) ([]entity.Block, error) {
+	query := `
+		SELECT ` + blockColumns + `
+		FROM blocks b
+		JOIN users ur ON ur.id = b.blocker_id
+		JOIN users ud ON ud.id = b.blocked_id
+		WHERE b.blocker_id = $1 OR b.blocked_id = $1
+		ORDER BY b.created_at DESC
+	`
+	return r.queryBlocks(ctx, query, userID)
+}
+
+func (r *PostgresBlockRepo) queryBlocks(ctx context.Context, query string, args ...interface{}) ([]entity.Block, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []entity.Block
+	for rows.Next() {
+		var b entity.Block
+		if err := rows.Scan(&b.ID, &b.BlockerID, &b.BlockedID, &b.Reason, &b.CreatedAt, &b.BlockerName, &b.BlockedName); err != nil {
+			return nil, fmt.Errorf("failed to scan block: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+// GetBlockedWith returns everyone the user blocked or was blocked by
+func (r *PostgresBlockRepo) GetBlockedWith(ctx context.Context, userID int64) (map[int64]bool, error) {
+	query := `
+		SELECT blocked_id FROM blocks WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = $1
+	`
+	rows, err := r.DB.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocks: %w", err)
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocks: %w", err)
+	}
+
+	blocked := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		blocked[id] = true
+	}
+	return blocked, nil
+}
+
+// GetBlockPatterns counts blocks made since the given time per user
+func (r *PostgresBlockRepo) GetBlockPatterns(ctx context.Context, since time.Time, minCount int) ([]entity.BlockPattern, error) {
+	query := `
+		WITH counts AS (
+			SELECT u.id,
+				COUNT(*) FILTER (WHERE b.blocked_id = u.id) AS times_blocked,
+				COUNT(*) FILTER (WHERE b.blocker_id = u.id) AS blocks_made,
+				MAX(b.created_at) FILTER (WHERE b.blocked_id = u.id) AS last_blocked_at,
+				MAX(b.created_at) FILTER (WHERE b.blocker_id = u.id) AS last_block_at
+			FROM users u
+			JOIN blocks b ON b.blocked_id = u.id OR b.blocker_id = u.id
+			WHERE b.created_at >= $1
+			GROUP BY u.id
+		)
+		SELECT u.id, u.full_name, u.email, u.role, c.times_blocked, c.blocks_made, c.last_blocked_at, c.last_block_at
+		FROM counts c
+		JOIN users u ON u.id = c.id
+		WHERE c.times_blocked >= $2 OR c.blocks_made >= $2
+		ORDER BY GREATEST(c.times_blocked, c.blocks_made) DESC, u.id
+		LIMIT 100
+	`
+	rows, err := r.DB.Query(ctx, query, since, minCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query block patterns: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []entity.BlockPattern
+	for rows.Next() {
+		var p entity.BlockPattern
+		err := rows.Scan(&p.UserID, &p.Name, &p.Email, &p.Role, &p.TimesBlocked, &p.BlocksMade, &p.LastBlockedAt, &p.LastBlockAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan block pattern: %w", err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, rows.Err()
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCancellationRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresCancellationRepo(db *pgxpool.Pool) *PostgresCancellationRepo {
+	return &PostgresCancellationRepo{DB: db}
+}
+
+const cancellationColumns = `c.id, c.shift_id, c.application_id, c.worker_id, c.cancelled_by, c.side, c.reason,
+	c.notice_minutes, c.within_cutoff, c.compensation_minor, c.compensation_currency, c.created_at, s.owner_id, s.title`
+
+const cancellationFrom = `
+	FROM shift_cancellations c
+	JOIN shifts s ON c.shift_id = s.id`
+
+func scanCancellation(row pgx.Row, c *entity.Cancellation) error {
+	return row.Scan(
+		&c.ID,
+		&c.ShiftID,
+		&c.ApplicationID,
+		&c.WorkerID,
+		&c.CancelledBy,
+		&c.Side,
+		&c.Reason,
+		&c.NoticeMinutes,
+		&c.WithinCutoff,
+		&c.Compensation.Minor,
+		&c.Compensation.Currency,
+		&c.CreatedAt,
+		&c.BusinessID,
+		&c.ShiftTitle,
+	)
+}
+
+// CancelShift cancels the shift, its open applications and records who lost their spot
+func (r *PostgresCancellationRepo) CancelShift(ctx context.Context, shiftID int64, cancellations []*entity.Cancellation) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `UPDATE shifts SET status = 'CANCELLED' WHERE id = $1 AND status IN ('OPEN', 'FILLED')`, shiftID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel shift: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE applications SET status = 'CANCELLED' WHERE shift_id = $1 AND status IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED')`, shiftID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel applications: %w", err)
+	}
+
+	for _, c := range cancellations {
+		if err := insertCancellation(ctx, tx, c); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// CancelSpot cancels one accepted application and optionally reopens its shift
+func (r *PostgresCancellationRepo) CancelSpot(ctx context.Context, c *entity.Cancellation, reopen bool) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `UPDATE applications SET status = 'CANCELLED' WHERE id = $1 AND status = 'ACCEPTED'`, c.ApplicationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel application: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if reopen {
+		_, err = tx.Exec(ctx, `UPDATE shifts SET status = 'OPEN' WHERE id = $1 AND status = 'FILLED'`, c.ShiftID)
+		if err != nil {
+			return false, fmt.Errorf("failed to reopen shift: %w", err)
+		}
+	}
+
+	if err := insertCancellation(ctx, tx, c); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func insertCancellation(ctx context.Context, tx pgx.Tx, c *entity.Cancellation) error {
+	query := `
+		INSERT INTO shift_cancellations (shift_id, application_id, worker_id, cancelled_by, side, reason,
+			notice_minutes, within_cutoff, compensation_minor, compensation_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, query,
+		c.ShiftID,
+		c.ApplicationID,
+		c.WorkerID,
+		c.CancelledBy,
+		c.Side,
+		c.Reason,
+		c.NoticeMinutes,
+		c.WithinCutoff,
+		c.Compensation.Minor,
+		c.Compensation.Currency,
+	).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert cancellation: %w", err)
+	}
+	return nil
+}
+
+// GetCancellationsByShift returns a shift's cancellations, oldest first
+func (r *PostgresCancellationRepo) GetCancellationsByShift(ctx context.Context, shiftID int64) ([]entity.Cancellation, error) {
+	query := `SELECT ` + cancellationColumns + cancellationFrom + `
+		WHERE c.shift_id = $1
+		ORDER BY c.created_at ASC`
+	rows, err := r.DB.Query(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cancellations: %w", err)
+	}
+	defer rows.Close()
+
+	var cancellations []entity.Cancellation
+	for rows.Next() {
+		var c entity.Cancellation
+		if err := scanCancellation(rows, &c); err != nil {
+			return nil, fmt.Errorf("failed to scan cancellation: %w", err)
+		}
+		cancellations = append(cancellations, c)
+	}
+	return cancellations, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEventRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresEventRepo(db *pgxpool.Pool) *PostgresEventRepo {
+	return &PostgresEventRepo{DB: db}
+}
+
+// eventColumns is the column list read by scanEvent
+const eventColumns = `id, owner_id, title, description, lat, lng, starts_at, ends_at, location_id, created_at`
+
+func scanEvent(row pgx.Row, e *entity.Event) error {
+	return row.Scan(&e.ID, &e.OwnerID, &e.Title, &e.Description, &e.Lat, &e.Lng, &e.StartsAt, &e.EndsAt, &e.LocationID, &e.CreatedAt)
+}
+
+// CreateEvent inserts an event and its positions, with their required skills
+// and screening questions, in one transaction
+func (r *PostgresEventRepo) CreateEvent(ctx context.Context, event *entity.Event, positions []entity.Shift) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO events (owner_id, title, description, lat, lng, starts_at, ends_at, location_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`, event.OwnerID, event.Title, event.Description, event.Lat, event.Lng, event.StartsAt, event.EndsAt, event.LocationID).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert event: %w", err)
+	}
+
+	for i := range positions {
+		position := &positions[i]
+		position.EventID = &event.ID
+		if err := insertShift(ctx, tx, position); err != nil {
+			return fmt.Errorf("position %d: %w", i+1, err)
+		}
+		skillIDs := make([]int64, len(position.RequiredSkills))
+		for j, skill := range position.RequiredSkills {
+			skillIDs[j] = skill.ID
+		}
+		if err := insertShiftSkills(ctx, tx, position.ID, skillIDs); err != nil {
+			return fmt.Errorf("position %d: %w", i+1, err)
+		}
+		if err := insertScreeningQuestions(ctx, tx, position.ID, position.ScreeningQuestions); err != nil {
+			return fmt.Errorf("position %d: %w", i+1, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetEvent retrieves an event by its ID
+func (r *PostgresEventRepo) GetEvent(ctx context.Context, id int64) (*entity.Event, error) {
+	var e entity.Event
+	err := scanEvent(r.DB.QueryRow(ctx, `SELECT `+eventColumns+` FROM events WHERE id = $1`, id), &e)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("event not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return &e, nil
+}
+
+// GetEvents retrieves events by their IDs
+func (r *PostgresEventRepo) GetEvents(ctx context.Context, ids []int64) (map[int64]*entity.Event, error) {
+	events, err := r.queryEvents(ctx, `SELECT `+eventColumns+` FROM events WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*entity.Event, len(events))
+	for i := range events {
+		byID[events[i].ID] = &events[i]
+	}
+	return byID, nil
+}
+
+// GetEventsByOwner retrieves a business's events, newest first
+func (r *PostgresEventRepo) GetEventsByOwner(ctx context.Context, ownerID int64) ([]entity.Event, error) {
+	return r.queryEvents(ctx, `SELECT `+eventColumns+` FROM events WHERE owner_id = $1 ORDER BY created_at DESC`, ownerID)
+}
+
+func (r *PostgresEventRepo) queryEvents(ctx context.Context, query string, args ...interface{}) ([]entity.Event, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	for rows.Next() {
+		var e entity.Event
+		if err := scanEvent(rows, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// GetPositions retrieves the shifts of the events
+func (r *PostgresEventRepo) GetPositions(ctx context.Context, eventIDs []int64) ([]entity.Shift, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+shiftColumns+` FROM shifts WHERE event_id = ANY($1) ORDER BY event_id, id`, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []entity.Shift
+	for rows.Next() {
+		var shift entity.Shift
+		if err := scanShift(rows, &shift); err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, rows.Err()
+}
+
+// CountAccepted counts the accepted applications of each shift
+func (r *PostgresEventRepo) CountAccepted(ctx context.Context, shiftIDs []int64) (map[int64]int, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT shift_id, COUNT(*) FROM applications
+		WHERE shift_id = ANY($1) AND status = 'ACCEPTED'
+		GROUP BY shift_id
+	`, shiftIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count accepted workers: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[int64]int{}
+	for rows.Next() {
+		var id int64
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan accepted count: %w", err)
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresLedgerRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresLedgerRepo(db *pgxpool.Pool) *PostgresLedgerRepo {
+	return &PostgresLedgerRepo{DB: db}
+}
+
+// PostTransactions stores transactions and their entries in one database transaction
+func (r *PostgresLedgerRepo) PostTransactions(ctx context.Context, txns ...*entity.LedgerTransaction) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, txn := range txns {
+		posted, err := postLedgerTransaction(ctx, tx, txn)
+		if err != nil || !posted {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit ledger transaction: %w", err)
+	}
+	return true, nil
+}
+
+func postLedgerTransaction(ctx context.Context, tx pgx.Tx, txn *entity.LedgerTransaction) (bool, error) {
+	query := `
+		INSERT INTO ledger_transactions (kind, timesheet_id, cancellation_id, description, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, query, txn.Kind, txn.TimesheetID, txn.CancellationID, txn.Description, txn.CreatedBy).
+		Scan(&txn.ID, &txn.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil // Already posted
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert ledger transaction: %w", err)
+	}
+
+	for i := range txn.Entries {
+		e := &txn.Entries[i]
+		accountID, err := ledgerAccountID(ctx, tx, e.AccountKind, e.OwnerID)
+		if err != nil {
+			return false, err
+		}
+		err = tx.QueryRow(ctx, `
+			INSERT INTO ledger_entries (transaction_id, account_id, amount_minor, currency)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		`, txn.ID, accountID, e.Amount.Minor, e.Amount.Currency).Scan(&e.ID, &e.CreatedAt)
+		if err != nil {
+			return false, fmt.Errorf("failed to insert ledger entry: %w", err)
+		}
+		e.TransactionID = txn.ID
+	}
+	return true, nil
+}
+
+// ledgerAccountID returns the account of a kind and owner, opening it on first use
+func ledgerAccountID(ctx context.Context, tx pgx.Tx, kind string, ownerID int64) (int64, error) {
+	query := `
+		INSERT INTO ledger_accounts (kind, owner_id)
+		VALUES ($1, $2)
+		ON CONFLICT (kind, owner_id) DO UPDATE SET kind = EXCLUDED.kind
+		RETURNING id
+	`
+	var id int64
+	if err := tx.QueryRow(ctx, query, kind, ownerID).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to open ledger account: %w", err)
+	}
+	return id, nil
+}
+
+// GetUnpostedTimesheets returns APPROVED timesheets whose earnings are not in the ledger yet
+func (r *PostgresLedgerRepo) GetUnpostedTimesheets(ctx context.Context) ([]entity.Timesheet, error) {
+	query := `SELECT ` + timesheetColumns + timesheetFrom + `
+		WHERE t.status = 'APPROVED'
+			AND NOT EXISTS (
+				SELECT 1 FROM ledger_transactions lt
+				WHERE lt.timesheet_id = t.id AND lt.kind = 'EARNING'
+			)
+		ORDER BY t.id`
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unposted timesheets: %w", err)
+	}
+	defer rows.Close()
+
+	var timesheets []entity.Timesheet
+	for rows.Next() {
+		var t entity.Timesheet
+		if err := scanTimesheet(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan timesheet: %w", err)
+		}
+		timesheets = append(timesheets, t)
+	}
+	return timesheets, nil
+}
+
+// GetUnpostedCompensations returns cancellations owing compensation that is not in the ledger yet
+func (r *PostgresLedgerRepo) GetUnpostedCompensations(ctx context.Context) ([]entity.Cancellation, error) {
+	query := `SELECT ` + cancellationColumns + cancellationFrom + `
+		WHERE c.compensation_minor > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM ledger_transactions lt
+				WHERE lt.cancellation_id = c.id AND lt.kind = 'COMPENSATION'
+			)
+		ORDER BY c.id`
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unposted compensations: %w", err)
+	}
+	defer rows.Close()
+
+	var cancellations []entity.Cancellation
+	for rows.Next() {
+		var c entity.Cancellation
+		if err := scanCancellation(rows, &c); err != nil {
+			return nil, fmt.Errorf("failed to scan cancellation: %w", err)
+		}
+		cancellations = append(cancellations, c)
+	}
+	return cancellations, nil
+}
+
+// GetAccountEntries retrieves the entries of one account in one currency within [from, to)
+func (r *PostgresLedgerRepo) GetAccountEntries(ctx context.Context, kind string, ownerID int64, currency string, from, to time.Time) ([]entity.LedgerEntry, error) {
+	query := `
+		SELECT e.id, e.transaction_id, acc.kind, acc.owner_id, e.amount_minor, e.currency, e.payout_batch_id,
+			e.created_at, lt.kind, lt.description
+		FROM ledger_entries e
+		JOIN ledger_accounts acc ON e.account_id = acc.id
+		JOIN ledger_transactions lt ON e.transaction_id = lt.id
+		WHERE acc.kind = $1 AND acc.owner_id = $2 AND e.currency = $3 AND e.created_at >= $4 AND e.created_at < $5
+		ORDER BY e.created_at, e.id
+	`
+	rows, err := r.DB.Query(ctx, query, kind, ownerID, currency, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []entity.LedgerEntry
+	for rows.Next() {
+		var e entity.LedgerEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.TransactionID,
+			&e.AccountKind,
+			&e.OwnerID,
+			&e.Amount.Minor,
+			&e.Amount.Currency,
+			&e.PayoutBatchID,
+			&e.CreatedAt,
+			&e.TransactionKind,
+			&e.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// GetAccountBalance returns the sum of an account's entries in one currency (debits positive)
+func (r *PostgresLedgerRepo) GetAccountBalance(ctx context.Context, kind string, ownerID int64, currency string) (entity.Money, error) {
+	query := `
+		SELECT COALESCE(SUM(e.amount_minor), 0)::bigint
+		FROM ledger_entries e
+		JOIN ledger_accounts acc ON e.account_id = acc.id
+		WHERE acc.kind = $1 AND acc.owner_id = $2 AND e.currency = $3
+	`
+	balance := entity.Money{Currency: currency}
+	if err := r.DB.QueryRow(ctx, query, kind, ownerID, currency).Scan(&balance.Minor); err != nil {
+		return entity.Money{}, fmt.Errorf("failed to get account balance: %w", err)
+	}
+	return balance, nil
+}
+
+// GetPayableLines returns, per worker with bank details, the unpaid payable
+// entries in one currency and the amount they add up to (only positive amounts are payable)
+func (r *PostgresLedgerRepo) GetPayableLines(ctx context.Context, currency string) ([]entity.PayoutLine, error) {
+	query := `
+		SELECT acc.owner_id, u.full_name, (-SUM(e.amount_minor))::bigint, e.currency,
+			b.bank_code, b.account_number, b.account_holder, array_agg(e.id ORDER BY e.id)
+		FROM ledger_entries e
+		JOIN ledger_accounts acc ON e.account_id = acc.id AND acc.kind = 'WORKER_PAYABLE'
+		JOIN users u ON acc.owner_id = u.id
+		JOIN worker_bank_accounts b ON acc.owner_id = b.worker_id
+		WHERE e.payout_batch_id IS NULL AND e.currency = $1
+		GROUP BY acc.owner_id, u.full_name, e.currency, b.bank_code, b.account_number, b.account_holder
+		HAVING -SUM(e.amount_minor) > 0
+		ORDER BY acc.owner_id
+	`
+	rows, err := r.DB.Query(ctx, query, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payable balances: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []entity.PayoutLine
+	for rows.Next() {
+		var l entity.PayoutLine
+		err := rows.Scan(
+			&l.WorkerID,
+			&l.WorkerName,
+			&l.Amount.Minor,
+			&l.Amount.Currency,
+			&l.BankCode,
+			&l.AccountNumber,
+			&l.AccountHolder,
+			&l.EntryIDs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payable balance: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// CreatePayoutBatch saves the batch, posts one PAYOUT transaction per line and
+// marks the settled entries as paid, all or nothing
+func (r *PostgresLedgerRepo) CreatePayoutBatch(ctx context.Context, batch *entity.PayoutBatch, lines []entity.PayoutLine, render func(*entity.PayoutBatch) error) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO payout_batches (bank_format, status, total_minor, currency, line_count, file_name, file_content)
+		VALUES ($1, $2, $3, $4, $5, '', '')
+		RETURNING id, created_at
+	`, batch.BankFormat, batch.Status, batch.TotalAmount.Minor, batch.TotalAmount.Currency, batch.LineCount,
+	).Scan(&batch.ID, &batch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert payout batch: %w", err)
+	}
+
+	if err := render(batch); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE payout_batches SET file_name = $1, file_content = $2 WHERE id = $3`,
+		batch.FileName, batch.FileContent, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save payout file: %w", err)
+	}
+
+	for _, line := range lines {
+		txn := &entity.LedgerTransaction{
+			Kind:        entity.LedgerPayout,
+			Description: fmt.Sprintf("Payout batch %d", batch.ID),
+			Entries: []entity.LedgerEntry{
+				{AccountKind: entity.AccountWorkerPayable, OwnerID: line.WorkerID, Amount: line.Amount},
+				{AccountKind: entity.AccountPayoutClearing, Amount: line.Amount.Neg()},
+			},
+		}
+		if _, err := postLedgerTransaction(ctx, tx, txn); err != nil {
+			return err
+		}
+
+		settled := append(append([]int64{}, line.EntryIDs...), txn.Entries[0].ID)
+		result, err := tx.Exec(ctx, `
+			UPDATE ledger_entries SET payout_batch_id = $1
+			WHERE id = ANY($2) AND payout_batch_id IS NULL
+		`, batch.ID, settled)
+		if err != nil {
+			return fmt.Errorf("failed to mark entries as paid: %w", err)
+		}
+		if result.RowsAffected() != int64(len(settled)) {
+			return fmt.Errorf("ledger changed while creating the payout batch")
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const payoutBatchColumns = `id, bank_format, status, total_minor, currency, line_count, file_name, file_content, created_at`
+
+func scanPayoutBatch(row pgx.Row, b *entity.PayoutBatch) error {
+	return row.Scan(
+		&b.ID,
+		&b.BankFormat,
+		&b.Status,
+		&b.TotalAmount.Minor,
+		&b.TotalAmount.Currency,
+		&b.LineCount,
+		&b.FileName,
+		&b.FileContent,
+		&b.CreatedAt,
+	)
+}
+
+// GetPayoutBatches retrieves all payout batches, newest first
+func (r *PostgresLedgerRepo) GetPayoutBatches(ctx context.Context) ([]entity.PayoutBatch, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+payoutBatchColumns+` FROM payout_batches ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payout batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []entity.PayoutBatch
+	for rows.Next() {
+		var b entity.PayoutBatch
+		if err := scanPayoutBatch(rows, &b); err != nil {
+			return nil, fmt.Errorf("failed to scan payout batch: %w", err)
+		}
+		batches = append(batches, b)
+	}
+	return batches, nil
+}
+
+// GetPayoutBatchByID retrieves a payout batch including its file
+func (r *PostgresLedgerRepo) GetPayoutBatchByID(ctx context.Context, id int64) (*entity.PayoutBatch, error) {
+	var b entity.PayoutBatch
+	err := scanPayoutBatch(r.DB.QueryRow(ctx, `SELECT `+payoutBatchColumns+` FROM payout_batches WHERE id = $1`, id), &b)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("payout batch not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payout batch: %w", err)
+	}
+	return &b, nil
+}
+
+// GetBankAccount retrieves a worker's bank details (nil if none saved yet)
+func (r *PostgresLedgerRepo) GetBankAccount(ctx context.Context, workerID int64) (*entity.WorkerBankAccount, error) {
+	query := `
+		SELECT worker_id, bank_code, account_number, account_holder, updated_at
+		FROM worker_bank_accounts
+		WHERE worker_id = $1
+	`
+	var a entity.WorkerBankAccount
+	err := r.DB.QueryRow(ctx, query, workerID).Scan(
+		&a.WorkerID,
+		&a.BankCode,
+		&a.AccountNumber,
+		&a.AccountHolder,
+		&a.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank account: %w", err)
+	}
+	return &a, nil
+}
+
+// UpsertBankAccount creates or replaces a worker's bank details
+func (r *PostgresLedgerRepo) UpsertBankAccount(ctx context.Context, a *entity.WorkerBankAccount) error {
+	query := `
+		INSERT INTO worker_bank_accounts (worker_id, bank_code, account_number, account_holder, updated_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (worker_id) DO UPDATE
+		SET bank_code = EXCLUDED.bank_code,
+			account_number = EXCLUDED.account_number,
+			account_holder = EXCLUDED.account_holder,
+			updated_at = now()
+		RETURNING updated_at
+	`
+	err := r.DB.QueryRow(ctx, query, a.WorkerID, a.BankCode, a.AccountNumber, a.AccountHolder).Scan(&a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save bank account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresLocationRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresLocationRepo(db *pgxpool.Pool) *PostgresLocationRepo {
+	return &PostgresLocationRepo{DB: db}
+}
+
+// locationColumns is the column list read by scanLocation
+const locationColumns = `id, owner_id, name, address, lat, lng, instructions, contact_name, contact_phone, created_at, updated_at`
+
+func scanLocation(row pgx.Row, l *entity.Location) error {
+	return row.Scan(&l.ID, &l.OwnerID, &l.Name, &l.Address, &l.Lat, &l.Lng,
+		&l.Instructions, &l.ContactName, &l.ContactPhone, &l.CreatedAt, &l.UpdatedAt)
+}
+
+// CreateLocation inserts a work site
+func (r *PostgresLocationRepo) CreateLocation(ctx context.Context, location *entity.Location) error {
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO business_locations (owner_id, name, address, lat, lng, instructions, contact_name, contact_phone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`, location.OwnerID, location.Name, location.Address, location.Lat, location.Lng,
+		location.Instructions, location.ContactName, location.ContactPhone).
+		Scan(&location.ID, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert location: %w", err)
+	}
+	return nil
+}
+
+// GetLocation retrieves a work site by its ID
+func (r *PostgresLocationRepo) GetLocation(ctx context.Context, id int64) (*entity.Location, error) {
+	var l entity.Location
+	err := scanLocation(r.DB.QueryRow(ctx, `SELECT `+locationColumns+` FROM business_locations WHERE id = $1`, id), &l)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("location not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return &l, nil
+}
+
+// GetLocationsByOwner retrieves a business's work sites by name
+func (r *PostgresLocationRepo) GetLocationsByOwner(ctx context.Context, ownerID int64) ([]entity.Location, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+locationColumns+` FROM business_locations WHERE owner_id = $1 ORDER BY lower(name)`, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []entity.Location
+	for rows.Next() {
+		var l entity.Location
+		if err := scanLocation(rows, &l); err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, l)
+	}
+	return locations, rows.Err()
+}
+
+// GetOpenShifts returns the OPEN shifts posted at a work site
+func (r *PostgresLocationRepo) GetOpenShifts(ctx context.Context, locationID int64) ([]entity.Shift, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+shiftColumns+` FROM shifts WHERE location_id = $1 AND status = 'OPEN'`, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query location shifts: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []entity.Shift
+	for rows.Next() {
+		var shift entity.Shift
+		if err := scanShift(rows, &shift); err != nil {
+			return nil, fmt.Errorf("failed to scan shift: %w", err)
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, rows.Err()
+}
+
+// GetTemplates returns the shift templates posted at a work site
+func (r *PostgresLocationRepo) GetTemplates(ctx context.Context, locationID int64) ([]entity.ShiftTemplate, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+templateColumns+` FROM shift_templates WHERE location_id = $1`, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query location templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []entity.ShiftTemplate
+	for rows.Next() {
+		var t entity.ShiftTemplate
+		if err := scanTemplate(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// UpdateLocation saves a work site and moves its events, templates and OPEN
+// shifts along
+func (r *PostgresLocationRepo) UpdateLocation(ctx context.Context, location *entity.Location) ([]entity.Shift, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE business_locations
+		SET name = $1, address = $2, lat = $3, lng = $4, instructions = $5, contact_name = $6, contact_phone = $7,
+			updated_at = now()
+		WHERE id = $8
+		RETURNING updated_at
+	`, location.Name, location.Address, location.Lat, location.Lng,
+		location.Instructions, location.ContactName, location.ContactPhone, location.ID).
+		Scan(&location.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("location not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE events SET lat = $1, lng = $2
+		WHERE location_id = $3 AND (lat <> $1 OR lng <> $2)
+	`, location.Lat, location.Lng, location.ID); err != nil {
+		return nil, fmt.Errorf("failed to move events: %w", err)
+	}
+
+	// Shifts the series create from now on are posted at the new coordinates
+	if _, err := tx.Exec(ctx, `
+		UPDATE shift_templates SET lat = $1, lng = $2
+		WHERE location_id = $3 AND (lat <> $1 OR lng <> $2)
+	`, location.Lat, location.Lng, location.ID); err != nil {
+		return nil, fmt.Errorf("failed to move templates: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE shifts SET lat = $1, lng = $2
+		WHERE location_id = $3 AND status = 'OPEN' AND (lat <> $1 OR lng <> $2)
+		RETURNING `+shiftColumns, location.Lat, location.Lng, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move shifts: %w", err)
+	}
+	var moved []entity.Shift
+	for rows.Next() {
+		var shift entity.Shift
+		if err := scanShift(rows, &shift); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan moved shift: %w", err)
+		}
+		moved = append(moved, shift)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to move shifts: %w", err)
+	}
+
+	return moved, tx.Commit(ctx)
+}
+
+// DeleteLocation deletes a work site nothing refers to
+func (r *PostgresLocationRepo) DeleteLocation(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.DB.Exec(ctx, `
+		DELETE FROM business_locations
+		WHERE id = $1
+			AND NOT EXISTS (SELECT 1 FROM shifts WHERE location_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM events WHERE location_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM shift_templates WHERE location_id = $1)
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresNegotiationRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresNegotiationRepo(db *pgxpool.Pool) *PostgresNegotiationRepo {
+	return &PostgresNegotiationRepo{DB: db}
+}
+
+// RecordOffer updates the rate on the table and adds the step to the thread
+// in one transaction
+func (r *PostgresNegotiationRepo) RecordOffer(ctx context.Context, offer *entity.RateOffer, turnWas string, rate *entity.Money, turn string) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	minor, currency := rateArgs(rate)
+	result, err := tx.Exec(ctx, `
+		UPDATE applications SET rate_minor = $2, rate_currency = $3, rate_turn = NULLIF($4, '')
+		WHERE id = $1 AND status IN ('PENDING', 'STANDBY') AND COALESCE(rate_turn, '') = $5
+	`, offer.ApplicationID, minor, currency, turn, turnWas)
+	if err != nil {
+		return false, fmt.Errorf("failed to update negotiated rate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := insertRateOffer(ctx, tx, offer); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// GetOffers returns the negotiation thread of each application
+func (r *PostgresNegotiationRepo) GetOffers(ctx context.Context, applicationIDs []int64) (map[int64][]entity.RateOffer, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT id, application_id, author_id, side, action, rate_minor, rate_currency, note, created_at
+		FROM rate_offers
+		WHERE application_id = ANY($1)
+		ORDER BY application_id, created_at, id
+	`, applicationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rate offers: %w", err)
+	}
+	defer rows.Close()
+
+	offers := map[int64][]entity.RateOffer{}
+	for rows.Next() {
+		var o entity.RateOffer
+		var minor *int64
+		var currency *string
+		if err := rows.Scan(&o.ID, &o.ApplicationID, &o.AuthorID, &o.Side, &o.Action, &minor, &currency, &o.Note, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rate offer: %w", err)
+		}
+		o.Rate = scanRate(minor, currency)
+		offers[o.ApplicationID] = append(offers[o.ApplicationID], o)
+	}
+	return offers, rows.Err()
+}
+
+// insertRateOffer adds a step to an application's negotiation thread
+func insertRateOffer(ctx context.Context, tx pgx.Tx, offer *entity.RateOffer) error {
+	minor, currency := rateArgs(offer.Rate)
+	err := tx.QueryRow(ctx, `
+		INSERT INTO rate_offers (application_id, author_id, side, action, rate_minor, rate_currency, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, offer.ApplicationID, offer.AuthorID, offer.Side, offer.Action, minor, currency, offer.Note).Scan(&offer.ID, &offer.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save rate offer: %w", err)
+	}
+	return nil
+}
+
+// rateArgs splits an optional rate into its nullable columns
+func rateArgs(rate *entity.Money) (*int64, *string) {
+	if rate == nil {
+		return nil, nil
+	}
+	return &rate.Minor, &rate.Currency
+}
+
+// scanRate joins nullable rate columns back into an optional rate
+func scanRate(minor *int64, currency *string) *entity.Money {
+	if minor == nil || currency == nil {
+		return nil
+	}
+	rate := entity.NewMoney(*minor, *currency)
+	return &rate
+}
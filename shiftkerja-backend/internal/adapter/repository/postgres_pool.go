@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresPoolRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresPoolRepo(db *pgxpool.Pool) *PostgresPoolRepo {
+	return &PostgresPoolRepo{DB: db}
+}
+
+// AddPoolMember adds a worker to a business's pool, updating the note if already there
+func (r *PostgresPoolRepo) AddPoolMember(ctx context.Context, m *entity.PoolMember) error {
+	query := `
+		INSERT INTO pool_members (business_id, worker_id, note)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (business_id, worker_id) DO UPDATE SET note = EXCLUDED.note
+		RETURNING created_at
+	`
+	if err := r.DB.QueryRow(ctx, query, m.BusinessID, m.WorkerID, m.Note).Scan(&m.CreatedAt); err != nil {
+		return fmt.Errorf("failed to add pool member: %w", err)
+	}
+	return nil
+}
+
+// RemovePoolMember removes a worker from a business's pool
+func (r *PostgresPoolRepo) RemovePoolMember(ctx context.Context, businessID, workerID int64) (bool, error) {
+	result, err := r.DB.Exec(ctx, `DELETE FROM pool_members WHERE business_id = $1 AND worker_id = $2`, businessID, workerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove pool member: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// GetPoolMembers lists a business's pool, newest first
+func (r *PostgresPoolRepo) GetPoolMembers(ctx context.Context, businessID int64) ([]entity.PoolMember, error) {
+	query := `
+		SELECT p.business_id, p.worker_id, p.note, p.created_at, u.full_name, u.email
+		FROM pool_members p
+		JOIN users u ON u.id = p.worker_id
+		WHERE p.business_id = $1
+		ORDER BY p.created_at DESC
+	`
+	rows, err := r.DB.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pool: %w", err)
+	}
+	defer rows.Close()
+
+	var members []entity.PoolMember
+	for rows.Next() {
+		var m entity.PoolMember
+		if err := rows.Scan(&m.BusinessID, &m.WorkerID, &m.Note, &m.CreatedAt, &m.WorkerName, &m.WorkerEmail); err != nil {
+			return nil, fmt.Errorf("failed to scan pool member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// InviteWorkers invites workers to a shift
+func (r *PostgresPoolRepo) InviteWorkers(ctx context.Context, shiftID int64, workerIDs []int64) error {
+	query := `
+		INSERT INTO shift_invites (shift_id, worker_id)
+		SELECT $1, unnest($2::bigint[])
+		ON CONFLICT (shift_id, worker_id) DO NOTHING
+	`
+	if _, err := r.DB.Exec(ctx, query, shiftID, workerIDs); err != nil {
+		return fmt.Errorf("failed to invite workers: %w", err)
+	}
+	return nil
+}
+
+// GetInvites lists the workers invited to a shift
+func (r *PostgresPoolRepo) GetInvites(ctx context.Context, shiftID int64) ([]entity.ShiftInvite, error) {
+	query := `
+		SELECT i.shift_id, i.worker_id, i.created_at, u.full_name
+		FROM shift_invites i
+		JOIN users u ON u.id = i.worker_id
+		WHERE i.shift_id = $1
+		ORDER BY i.created_at ASC
+	`
+	rows, err := r.DB.Query(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []entity.ShiftInvite
+	for rows.Next() {
+		var inv entity.ShiftInvite
+		if err := rows.Scan(&inv.ShiftID, &inv.WorkerID, &inv.CreatedAt, &inv.WorkerName); err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		invites = append(invites, inv)
+	}
+	return invites, rows.Err()
+}
+
+// GetAdmittedShifts returns which of the shifts the worker may see regardless of visibility
+func (r *PostgresPoolRepo) GetAdmittedShifts(ctx context.Context, workerID int64, shiftIDs []int64) (map[int64]bool, error) {
+	query := `
+		SELECT s.id
+		FROM shifts s
+		WHERE s.id = ANY($2)
+			AND (
+				EXISTS (SELECT 1 FROM shift_invites i WHERE i.shift_id = s.id AND i.worker_id = $1)
+				OR (s.visibility = 'POOL' AND EXISTS (
+					SELECT 1 FROM pool_members p WHERE p.business_id = s.owner_id AND p.worker_id = $1))
+				OR EXISTS (SELECT 1 FROM applications a WHERE a.shift_id = s.id AND a.worker_id = $1)
+			)
+	`
+	rows, err := r.DB.Query(ctx, query, workerID, shiftIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check shift access: %w", err)
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check shift access: %w", err)
+	}
+
+	admitted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		admitted[id] = true
+	}
+	return admitted, nil
+}
+
+// SetVisibility changes who may see a shift and when it is released to everyone
+func (r *PostgresPoolRepo) SetVisibility(ctx context.Context, shiftID int64, visibility entity.ShiftVisibility, releaseAt *time.Time) error {
+	result, err := r.DB.Exec(ctx, `UPDATE shifts SET visibility = $1, release_at = $2 WHERE id = $3`, visibility, releaseAt, shiftID)
+	if err != nil {
+		return fmt.Errorf("failed to update visibility: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("shift not found")
+	}
+	return nil
+}
+
+// ReleaseDue opens restricted OPEN shifts past their release time to everyone
+func (r *PostgresPoolRepo) ReleaseDue(ctx context.Context, now time.Time) ([]int64, error) {
+	query := `
+		UPDATE shifts SET visibility = 'PUBLIC', release_at = NULL
+		WHERE visibility <> 'PUBLIC' AND release_at <= $1 AND status = 'OPEN'
+		RETURNING id
+	`
+	rows, err := r.DB.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release shifts: %w", err)
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release shifts: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRatingRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresRatingRepo(db *pgxpool.Pool) *PostgresRatingRepo {
+	return &PostgresRatingRepo{DB: db}
+}
+
+const ratingColumns = `r.id, r.application_id, r.shift_id, r.rater_id, r.ratee_id, r.direction, r.stars, r.tags,
+	r.comment, r.deadline_at, r.published_at, r.created_at, u.full_name, s.title`
+
+const ratingFrom = `
+	FROM ratings r
+	JOIN users u ON r.rater_id = u.id
+	JOIN shifts s ON r.shift_id = s.id`
+
+func scanRating(row pgx.Row, r *entity.Rating) error {
+	return row.Scan(
+		&r.ID,
+		&r.ApplicationID,
+		&r.ShiftID,
+		&r.RaterID,
+		&r.RateeID,
+		&r.Direction,
+		&r.Stars,
+		&r.Tags,
+		&r.Comment,
+		&r.DeadlineAt,
+		&r.PublishedAt,
+		&r.CreatedAt,
+		&r.RaterName,
+		&r.ShiftTitle,
+	)
+}
+
+// GetCompletedAt prefers the recorded end of work over the scheduled end
+func (r *PostgresRatingRepo) GetCompletedAt(ctx context.Context, applicationID int64) (*time.Time, error) {
+	query := `
+		SELECT COALESCE(t.ended_at, s.ends_at)
+		FROM applications a
+		JOIN shifts s ON a.shift_id = s.id
+		LEFT JOIN timesheets t ON t.application_id = a.id
+		WHERE a.id = $1
+	`
+	var completedAt *time.Time
+	err := r.DB.QueryRow(ctx, query, applicationID).Scan(&completedAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("application not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completion time: %w", err)
+	}
+	return completedAt, nil
+}
+
+// CreateRating inserts the rating and reveals both sides once the second one is in.
+// The application row is locked so two simultaneous ratings can't both miss each other.
+func (r *PostgresRatingRepo) CreateRating(ctx context.Context, rating *entity.Rating) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM applications WHERE id = $1 FOR UPDATE`, rating.ApplicationID); err != nil {
+		return false, fmt.Errorf("failed to lock application: %w", err)
+	}
+
+	query := `
+		INSERT INTO ratings (application_id, shift_id, rater_id, ratee_id, direction, stars, tags, comment, deadline_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (application_id, direction) DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query,
+		rating.ApplicationID,
+		rating.ShiftID,
+		rating.RaterID,
+		rating.RateeID,
+		rating.Direction,
+		rating.Stars,
+		rating.Tags,
+		rating.Comment,
+		rating.DeadlineAt,
+	).Scan(&rating.ID, &rating.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert rating: %w", err)
+	}
+
+	reveal := `
+		UPDATE ratings
+		SET published_at = now()
+		WHERE application_id = $1 AND published_at IS NULL
+			AND (SELECT COUNT(*) FROM ratings WHERE application_id = $1) = 2
+		RETURNING id, ratee_id, published_at
+	`
+	rows, err := tx.Query(ctx, reveal, rating.ApplicationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to publish ratings: %w", err)
+	}
+	var ratees []int64
+	for rows.Next() {
+		var id, rateeID int64
+		var publishedAt time.Time
+		if err := rows.Scan(&id, &rateeID, &publishedAt); err != nil {
+			rows.Close()
+			return false, fmt.Errorf("failed to scan published rating: %w", err)
+		}
+		if id == rating.ID {
+			rating.PublishedAt = &publishedAt
+		}
+		ratees = append(ratees, rateeID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to publish ratings: %w", err)
+	}
+
+	if err := refreshRatingSummaries(ctx, tx, ratees); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// GetRatingsByApplication returns both sides' ratings of an application, hidden or not
+func (r *PostgresRatingRepo) GetRatingsByApplication(ctx context.Context, applicationID int64) ([]entity.Rating, error) {
+	query := `SELECT ` + ratingColumns + ratingFrom + `
+		WHERE r.application_id = $1
+		ORDER BY r.created_at ASC`
+	return r.queryRatings(ctx, query, applicationID)
+}
+
+// GetPublishedRatings returns the newest published ratings a user received
+func (r *PostgresRatingRepo) GetPublishedRatings(ctx context.Context, userID int64, limit int) ([]entity.Rating, error) {
+	query := `SELECT ` + ratingColumns + ratingFrom + `
+		WHERE r.ratee_id = $1 AND r.published_at IS NOT NULL
+		ORDER BY r.published_at DESC
+		LIMIT $2`
+	return r.queryRatings(ctx, query, userID, limit)
+}
+
+func (r *PostgresRatingRepo) queryRatings(ctx context.Context, query string, args ...interface{}) ([]entity.Rating, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []entity.Rating
+	for rows.Next() {
+		var rating entity.Rating
+		if err := scanRating(rows, &rating); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings = append(ratings, rating)
+	}
+	return ratings, nil
+}
+
+// GetRatingSummaries reads the aggregates stored on the users
+func (r *PostgresRatingRepo) GetRatingSummaries(ctx context.Context, userIDs []int64) (map[int64]entity.RatingSummary, error) {
+	summaries := make(map[int64]entity.RatingSummary, len(userIDs))
+	for _, id := range userIDs {
+		summaries[id] = entity.RatingSummary{UserID: id}
+	}
+	if len(userIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := `SELECT id, rating_avg::float8, rating_count FROM users WHERE id = ANY($1)`
+	rows, err := r.DB.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rating summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s entity.RatingSummary
+		if err := rows.Scan(&s.UserID, &s.Avg, &s.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating summary: %w", err)
+		}
+		summaries[s.UserID] = s
+	}
+	return summaries, nil
+}
+
+// PublishDueRatings reveals ratings whose counterpart never came
+func (r *PostgresRatingRepo) PublishDueRatings(ctx context.Context, now time.Time) (int, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE ratings
+		SET published_at = $1
+		WHERE published_at IS NULL AND deadline_at <= $1
+		RETURNING ratee_id
+	`
+	rows, err := tx.Query(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish due ratings: %w", err)
+	}
+	var ratees []int64
+	for rows.Next() {
+		var rateeID int64
+		if err := rows.Scan(&rateeID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan published rating: %w", err)
+		}
+		ratees = append(ratees, rateeID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to publish due ratings: %w", err)
+	}
+
+	if err := refreshRatingSummaries(ctx, tx, ratees); err != nil {
+		return 0, err
+	}
+	return len(ratees), tx.Commit(ctx)
+}
+
+// refreshRatingSummaries recomputes the stored aggregates from the published ratings
+func refreshRatingSummaries(ctx context.Context, tx pgx.Tx, userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	query := `
+		UPDATE users u
+		SET rating_avg = agg.avg, rating_count = agg.count
+		FROM (
+			SELECT ratee_id, ROUND(AVG(stars), 2) AS avg, COUNT(*) AS count
+			FROM ratings
+			WHERE ratee_id = ANY($1) AND published_at IS NOT NULL
+			GROUP BY ratee_id
+		) agg
+		WHERE u.id = agg.ratee_id
+	`
+	if _, err := tx.Exec(ctx, query, userIDs); err != nil {
+		return fmt.Errorf("failed to refresh rating summaries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReliabilityRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresReliabilityRepo(db *pgxpool.Pool) *PostgresReliabilityRepo {
+	return &PostgresReliabilityRepo{DB: db}
+}
+
+const reliabilityColumns = `e.id, e.worker_id, e.application_id, e.shift_id, e.kind, e.strikes, e.occurred_at, e.detail,
+	e.appeal_status, e.appeal_reason, e.appeal_note, e.appeal_decided_by, e.appeal_decided_at, e.created_at,
+	COALESCE(s.title, ''), u.full_name`
+
+const reliabilityFrom = `
+	FROM reliability_events e
+	JOIN users u ON e.worker_id = u.id
+	LEFT JOIN shifts s ON e.shift_id = s.id`
+
+func scanReliabilityEvent(row pgx.Row, e *entity.ReliabilityEvent) error {
+	return row.Scan(
+		&e.ID,
+		&e.WorkerID,
+		&e.ApplicationID,
+		&e.ShiftID,
+		&e.Kind,
+		&e.Strikes,
+		&e.OccurredAt,
+		&e.Detail,
+		&e.AppealStatus,
+		&e.AppealReason,
+		&e.AppealNote,
+		&e.AppealDecidedBy,
+		&e.AppealDecidedAt,
+		&e.CreatedAt,
+		&e.ShiftTitle,
+		&e.WorkerName,
+	)
+}
+
+// RecordNoShows inserts the missing NO_SHOW events in one statement.
+// Cancelled shifts are skipped.
+func (r *PostgresReliabilityRepo) RecordNoShows(ctx context.Context, startedAfter, startedBefore time.Time, strikes int) ([]entity.ReliabilityEvent, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO reliability_events (worker_id, application_id, shift_id, kind, strikes, occurred_at, detail)
+			SELECT a.worker_id, a.id, s.id, 'NO_SHOW', $3, s.starts_at, 'No clock-in for the shift'
+			FROM applications a
+			JOIN shifts s ON a.shift_id = s.id
+			LEFT JOIN attendance at ON at.application_id = a.id
+			WHERE a.status = 'ACCEPTED' AND s.status <> 'CANCELLED'
+				AND s.starts_at > $1 AND s.starts_at <= $2
+				AND at.id IS NULL
+			ON CONFLICT (application_id, kind) DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + reliabilityColumns + `
+		FROM inserted e
+		JOIN users u ON e.worker_id = u.id
+		LEFT JOIN shifts s ON e.shift_id = s.id
+	`
+	return r.queryEvents(ctx, query, startedAfter, startedBefore, strikes)
+}
+
+// CreateEvent inserts an event unless the application already has one of this kind
+func (r *PostgresReliabilityRepo) CreateEvent(ctx context.Context, event *entity.ReliabilityEvent) (bool, error) {
+	query := `
+		INSERT INTO reliability_events (worker_id, application_id, shift_id, kind, strikes, occurred_at, detail)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (application_id, kind) DO NOTHING
+		RETURNING id, appeal_status, created_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		event.WorkerID,
+		event.ApplicationID,
+		event.ShiftID,
+		event.Kind,
+		event.Strikes,
+		event.OccurredAt,
+		event.Detail,
+	).Scan(&event.ID, &event.AppealStatus, &event.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert reliability event: %w", err)
+	}
+	return true, nil
+}
+
+// GetEventByID retrieves a single event
+func (r *PostgresReliabilityRepo) GetEventByID(ctx context.Context, id int64) (*entity.ReliabilityEvent, error) {
+	query := `SELECT ` + reliabilityColumns + reliabilityFrom + ` WHERE e.id = $1`
+	var event entity.ReliabilityEvent
+	err := scanReliabilityEvent(r.DB.QueryRow(ctx, query, id), &event)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("reliability event not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reliability event: %w", err)
+	}
+	return &event, nil
+}
+
+// GetEventsByWorker returns the worker's recent events, newest first
+func (r *PostgresReliabilityRepo) GetEventsByWorker(ctx context.Context, workerID int64, since time.Time) ([]entity.ReliabilityEvent, error) {
+	query := `SELECT ` + reliabilityColumns + reliabilityFrom + `
+		WHERE e.worker_id = $1 AND e.created_at >= $2
+		ORDER BY e.created_at DESC`
+	return r.queryEvents(ctx, query, workerID, since)
+}
+
+// GetEventsByAppealStatus lists events by appeal status, oldest first (the admin queue)
+func (r *PostgresReliabilityRepo) GetEventsByAppealStatus(ctx context.Context, status string) ([]entity.ReliabilityEvent, error) {
+	query := `SELECT ` + reliabilityColumns + reliabilityFrom + `
+		WHERE e.appeal_status = $1
+		ORDER BY e.created_at ASC`
+	return r.queryEvents(ctx, query, status)
+}
+
+func (r *PostgresReliabilityRepo) queryEvents(ctx context.Context, query string, args ...interface{}) ([]entity.ReliabilityEvent, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reliability events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []entity.ReliabilityEvent
+	for rows.Next() {
+		var event entity.ReliabilityEvent
+		if err := scanReliabilityEvent(rows, &event); err != nil {
+			return nil, fmt.Errorf("failed to scan reliability event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// UpdateAppeal saves an appeal or its decision, guarded by the expected appeal status
+func (r *PostgresReliabilityRepo) UpdateAppeal(ctx context.Context, event *entity.ReliabilityEvent, fromStatus string) (bool, error) {
+	query := `
+		UPDATE reliability_events
+		SET appeal_status = $1, appeal_reason = $2, appeal_note = $3, appeal_decided_by = $4, appeal_decided_at = $5
+		WHERE id = $6 AND appeal_status = $7
+	`
+	result, err := r.DB.Exec(ctx, query,
+		event.AppealStatus,
+		event.AppealReason,
+		event.AppealNote,
+		event.AppealDecidedBy,
+		event.AppealDecidedAt,
+		event.ID,
+		fromStatus,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update appeal: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// CountAttended counts clock-ins, i.e. shifts the worker turned up for
+func (r *PostgresReliabilityRepo) CountAttended(ctx context.Context, workerID int64, since time.Time) (int, error) {
+	var count int
+	err := r.DB.QueryRow(ctx, `SELECT COUNT(*) FROM attendance WHERE worker_id = $1 AND clock_in_at >= $2`, workerID, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attendance: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresScreeningRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresScreeningRepo(db *pgxpool.Pool) *PostgresScreeningRepo {
+	return &PostgresScreeningRepo{DB: db}
+}
+
+// SetQuestions replaces a shift's screening questions in one transaction,
+// numbering them in the given order. Nothing changes once the shift has
+// applications, since their answers refer to the questions.
+func (r *PostgresScreeningRepo) SetQuestions(ctx context.Context, shiftID int64, questions []entity.ScreeningQuestion) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the shift so nobody applies while the questions change
+	var applied bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM applications WHERE shift_id = s.id)
+		FROM shifts s
+		WHERE s.id = $1
+		FOR UPDATE
+	`, shiftID).Scan(&applied)
+	if err != nil {
+		return false, fmt.Errorf("failed to check applications: %w", err)
+	}
+	if applied {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM screening_questions WHERE shift_id = $1`, shiftID); err != nil {
+		return false, fmt.Errorf("failed to clear screening questions: %w", err)
+	}
+	if err := insertScreeningQuestions(ctx, tx, shiftID, questions); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// insertScreeningQuestions adds a shift's questions, in order, as part of a transaction
+func insertScreeningQuestions(ctx context.Context, tx pgx.Tx, shiftID int64, questions []entity.ScreeningQuestion) error {
+	for i := range questions {
+		q := &questions[i]
+		q.ShiftID, q.Position = shiftID, i+1
+		err := tx.QueryRow(ctx, `
+			INSERT INTO screening_questions (shift_id, position, kind, prompt, options, knockout_answers, required)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, q.ShiftID, q.Position, q.Kind, q.Prompt, nonNilStrings(q.Options), nonNilStrings(q.KnockoutAnswers), q.Required).Scan(&q.ID)
+		if err != nil {
+			return fmt.Errorf("failed to insert screening question: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetQuestions lists a shift's screening questions in form order
+func (r *PostgresScreeningRepo) GetQuestions(ctx context.Context, shiftID int64) ([]entity.ScreeningQuestion, error) {
+	query := `
+		SELECT id, shift_id, position, kind, prompt, options, knockout_answers, required
+		FROM screening_questions
+		WHERE shift_id = $1
+		ORDER BY position
+	`
+	rows, err := r.DB.Query(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screening questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []entity.ScreeningQuestion
+	for rows.Next() {
+		var q entity.ScreeningQuestion
+		err := rows.Scan(&q.ID, &q.ShiftID, &q.Position, &q.Kind, &q.Prompt, &q.Options, &q.KnockoutAnswers, &q.Required)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan screening question: %w", err)
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+// GetAnswers loads the screening answers of the given applications
+func (r *PostgresScreeningRepo) GetAnswers(ctx context.Context, applicationIDs []int64) (map[int64][]entity.ScreeningAnswer, error) {
+	answers := make(map[int64][]entity.ScreeningAnswer)
+	if len(applicationIDs) == 0 {
+		return answers, nil
+	}
+
+	query := `
+		SELECT a.application_id, a.question_id, a.answer, a.knockout, q.prompt
+		FROM application_answers a
+		JOIN screening_questions q ON q.id = a.question_id
+		WHERE a.application_id = ANY($1)
+		ORDER BY a.application_id, q.position
+	`
+	rows, err := r.DB.Query(ctx, query, applicationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screening answers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var applicationID int64
+		var a entity.ScreeningAnswer
+		if err := rows.Scan(&applicationID, &a.QuestionID, &a.Answer, &a.Knockout, &a.Prompt); err != nil {
+			return nil, fmt.Errorf("failed to scan screening answer: %w", err)
+		}
+		answers[applicationID] = append(answers[applicationID], a)
+	}
+	return answers, rows.Err()
+}
+
+// nonNilStrings stores a missing list as an empty array (the columns are NOT NULL)
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -20,19 +20,50 @@ func NewPostgresShiftRepo(db *pgxpool.Pool) *PostgresShiftRepo {
 
 // CreateShift inserts a new shift into the database
 func (r *PostgresShiftRepo) CreateShift(ctx context.Context, shift *entity.Shift) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertShift(ctx, tx, shift); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// insertShift inserts an OPEN shift as part of a transaction
+func insertShift(ctx context.Context, tx pgx.Tx, shift *entity.Shift) error {
 	query := `
-		INSERT INTO shifts (owner_id, title, description, pay_rate, lat, lng, status)
-		VALUES ($1, $2, $3, $4, $5, $6, 'OPEN')
-		RETURNING id, created_at
+		INSERT INTO shifts (owner_id, title, description, pay_rate_minor, pay_currency, pay_unit, lat, lng, status,
+			category_id, starts_at, ends_at, visibility, release_at, slots, event_id, location_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'OPEN', $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, version, created_at
 	`
-	err := r.DB.QueryRow(ctx, query,
+	if shift.Visibility == "" {
+		shift.Visibility = entity.VisibilityPublic
+	}
+	if shift.Slots < 1 {
+		shift.Slots = 1
+	}
+	err := tx.QueryRow(ctx, query,
 		shift.OwnerID,
 		shift.Title,
 		shift.Description,
-		shift.PayRate,
+		shift.PayRate.Minor,
+		shift.PayRate.Currency,
+		shift.PayUnit,
 		shift.Lat,
 		shift.Lng,
-	).Scan(&shift.ID, &shift.CreatedAt)
+		shift.CategoryID,
+		shift.StartsAt,
+		shift.EndsAt,
+		shift.Visibility,
+		shift.ReleaseAt,
+		shift.Slots,
+		shift.EventID,
+		shift.LocationID,
+	).Scan(&shift.ID, &shift.Version, &shift.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to insert shift: %w", err)
@@ -40,25 +71,43 @@ func (r *PostgresShiftRepo) CreateShift(ctx context.Context, shift *entity.Shift
 	return nil
 }
 
-// GetShiftByID retrieves a shift by its ID
-func (r *PostgresShiftRepo) GetShiftByID(ctx context.Context, id int64) (*entity.Shift, error) {
-	query := `
-		SELECT id, owner_id, title, description, pay_rate, lat, lng, status, created_at
-		FROM shifts
-		WHERE id = $1
-	`
-	var shift entity.Shift
-	err := r.DB.QueryRow(ctx, query, id).Scan(
+// shiftColumns is the column list read by scanShift
+const shiftColumns = `id, owner_id, title, description, pay_rate_minor, pay_currency, pay_unit, lat, lng, status, category_id,
+	starts_at, ends_at, series_id, is_exception, version, created_at, visibility, release_at, slots, event_id, location_id`
+
+// scanShift reads one row selected with shiftColumns
+func scanShift(row pgx.Row, shift *entity.Shift) error {
+	return row.Scan(
 		&shift.ID,
 		&shift.OwnerID,
 		&shift.Title,
 		&shift.Description,
-		&shift.PayRate,
+		&shift.PayRate.Minor,
+		&shift.PayRate.Currency,
+		&shift.PayUnit,
 		&shift.Lat,
 		&shift.Lng,
 		&shift.Status,
+		&shift.CategoryID,
+		&shift.StartsAt,
+		&shift.EndsAt,
+		&shift.SeriesID,
+		&shift.IsException,
+		&shift.Version,
 		&shift.CreatedAt,
+		&shift.Visibility,
+		&shift.ReleaseAt,
+		&shift.Slots,
+		&shift.EventID,
+		&shift.LocationID,
 	)
+}
+
+// GetShiftByID retrieves a shift by its ID
+func (r *PostgresShiftRepo) GetShiftByID(ctx context.Context, id int64) (*entity.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts WHERE id = $1`
+	var shift entity.Shift
+	err := scanShift(r.DB.QueryRow(ctx, query, id), &shift)
 	
 	if err == pgx.ErrNoRows {
 		return nil, fmt.Errorf("shift not found")
@@ -73,12 +122,17 @@ func (r *PostgresShiftRepo) GetShiftByID(ctx context.Context, id int64) (*entity
 // GetShiftsByOwner retrieves all shifts posted by a business owner
 func (r *PostgresShiftRepo) GetShiftsByOwner(ctx context.Context, ownerID int64) ([]entity.Shift, error) {
 	query := `
-		SELECT id, owner_id, title, description, pay_rate, lat, lng, status, created_at
+		SELECT ` + shiftColumns + `
 		FROM shifts
 		WHERE owner_id = $1
 		ORDER BY created_at DESC
 	`
-	rows, err := r.DB.Query(ctx, query, ownerID)
+	return r.queryShifts(ctx, query, ownerID)
+}
+
+// queryShifts runs a query selecting shiftColumns and scans every row
+func (r *PostgresShiftRepo) queryShifts(ctx context.Context, query string, args ...interface{}) ([]entity.Shift, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query shifts: %w", err)
 	}
@@ -87,18 +141,7 @@ func (r *PostgresShiftRepo) GetShiftsByOwner(ctx context.Context, ownerID int64)
 	var shifts []entity.Shift
 	for rows.Next() {
 		var shift entity.Shift
-		err := rows.Scan(
-			&shift.ID,
-			&shift.OwnerID,
-			&shift.Title,
-			&shift.Description,
-			&shift.PayRate,
-			&shift.Lat,
-			&shift.Lng,
-			&shift.Status,
-			&shift.CreatedAt,
-		)
-		if err != nil {
+		if err := scanShift(rows, &shift); err != nil {
 			return nil, fmt.Errorf("failed to scan shift: %w", err)
 		}
 		shifts = append(shifts, shift)
@@ -107,46 +150,82 @@ func (r *PostgresShiftRepo) GetShiftsByOwner(ctx context.Context, ownerID int64)
 	return shifts, nil
 }
 
-// UpdateShiftStatus updates the status of a shift
-func (r *PostgresShiftRepo) UpdateShiftStatus(ctx context.Context, id int64, status string) error {
-	query := `UPDATE shifts SET status = $1 WHERE id = $2`
-	_, err := r.DB.Exec(ctx, query, status, id)
+// TransitionShiftStatus moves a shift from one status to another, unless
+// its status was changed in the meantime
+func (r *PostgresShiftRepo) TransitionShiftStatus(ctx context.Context, id int64, from, to entity.ShiftStatus) (bool, error) {
+	query := `UPDATE shifts SET status = $1 WHERE id = $2 AND status = $3`
+	result, err := r.DB.Exec(ctx, query, to, id, from)
 	if err != nil {
-		return fmt.Errorf("failed to update shift status: %w", err)
+		return false, fmt.Errorf("failed to update shift status: %w", err)
 	}
-	return nil
+	return result.RowsAffected() > 0, nil
 }
 
-// ApplyForShift creates a new application
-func (r *PostgresShiftRepo) ApplyForShift(ctx context.Context, shiftID, workerID int64) error {
-	// Check if already applied
-	checkQuery := `SELECT COUNT(*) FROM applications WHERE shift_id = $1 AND worker_id = $2`
-	var count int
-	err := r.DB.QueryRow(ctx, checkQuery, shiftID, workerID).Scan(&count)
+// ApplyForShift creates a new application with its screening answers and the
+// rate the worker asks for, if any. A worker who withdrew from the shift may
+// apply again, which reuses their withdrawn application.
+func (r *PostgresShiftRepo) ApplyForShift(ctx context.Context, app *entity.Application) error {
+	tx, err := r.DB.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to check existing application: %w", err)
-	}
-	if count > 0 {
-		return errors.New("you have already applied to this shift")
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
 	query := `
-		INSERT INTO applications (shift_id, worker_id, status)
-		VALUES ($1, $2, 'PENDING')
+		INSERT INTO applications (shift_id, worker_id, status, cover_note, reason_code, decided_at,
+			rate_minor, rate_currency, rate_turn)
+		VALUES ($1, $2, $3, $4, $5, CASE WHEN $3 = 'REJECTED' THEN now() END, $6, $7, NULLIF($8, ''))
+		ON CONFLICT (shift_id, worker_id) DO UPDATE
+		SET status = EXCLUDED.status, cover_note = EXCLUDED.cover_note, reason_code = EXCLUDED.reason_code,
+			reason_note = '', decided_at = EXCLUDED.decided_at, created_at = now(),
+			rate_minor = EXCLUDED.rate_minor, rate_currency = EXCLUDED.rate_currency, rate_turn = EXCLUDED.rate_turn
+		WHERE applications.status = 'WITHDRAWN'
+		RETURNING id, created_at, decided_at
 	`
-	_, err = r.DB.Exec(ctx, query, shiftID, workerID)
+	rateMinor, rateCurrency := rateArgs(app.NegotiatedRate)
+	err = tx.QueryRow(ctx, query, app.ShiftID, app.WorkerID, app.Status, app.CoverNote, app.ReasonCode,
+		rateMinor, rateCurrency, app.RateTurn).
+		Scan(&app.ID, &app.CreatedAt, &app.DecidedAt)
+	if err == pgx.ErrNoRows {
+		return errors.New("you have already applied to this shift")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to submit application: %w", err)
 	}
-	return nil
+
+	if _, err := tx.Exec(ctx, `DELETE FROM application_answers WHERE application_id = $1`, app.ID); err != nil {
+		return fmt.Errorf("failed to clear screening answers: %w", err)
+	}
+	for _, a := range app.Answers {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO application_answers (application_id, question_id, answer, knockout)
+			VALUES ($1, $2, $3, $4)
+		`, app.ID, a.QuestionID, a.Answer, a.Knockout)
+		if err != nil {
+			return fmt.Errorf("failed to save screening answer: %w", err)
+		}
+	}
+
+	// A reused application starts a new negotiation
+	if _, err := tx.Exec(ctx, `DELETE FROM rate_offers WHERE application_id = $1`, app.ID); err != nil {
+		return fmt.Errorf("failed to clear rate offers: %w", err)
+	}
+	for i := range app.RateOffers {
+		app.RateOffers[i].ApplicationID = app.ID
+		if err := insertRateOffer(ctx, tx, &app.RateOffers[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
 }
 
 // GetApplicationsByWorker retrieves all applications for a worker with shift details
 func (r *PostgresShiftRepo) GetApplicationsByWorker(ctx context.Context, workerID int64) ([]entity.Application, error) {
 	query := `
 		SELECT 
-			a.id, a.shift_id, a.worker_id, a.status, a.created_at,
-			s.title, s.pay_rate
+			a.id, a.shift_id, a.worker_id, a.status, a.created_at, a.reconfirm_by, a.standby_rank, a.offer_expires_at, a.cover_note,
+			a.reason_code, a.reason_note, a.decided_at, a.rate_minor, a.rate_currency, COALESCE(a.rate_turn, ''),
+			s.title, s.pay_rate_minor, s.pay_currency, s.pay_unit, s.owner_id, s.category_id, s.starts_at, s.ends_at
 		FROM applications a
 		JOIN shifts s ON a.shift_id = s.id
 		WHERE a.worker_id = $1
@@ -161,18 +240,39 @@ func (r *PostgresShiftRepo) GetApplicationsByWorker(ctx context.Context, workerI
 	var applications []entity.Application
 	for rows.Next() {
 		var app entity.Application
+		var pay entity.Money
+		var rateMinor *int64
+		var rateCurrency *string
 		err := rows.Scan(
 			&app.ID,
 			&app.ShiftID,
 			&app.WorkerID,
 			&app.Status,
 			&app.CreatedAt,
+			&app.ReconfirmBy,
+			&app.StandbyRank,
+			&app.OfferExpiresAt,
+			&app.CoverNote,
+			&app.ReasonCode,
+			&app.ReasonNote,
+			&app.DecidedAt,
+			&rateMinor,
+			&rateCurrency,
+			&app.RateTurn,
 			&app.ShiftTitle,
-			&app.ShiftPayRate,
+			&pay.Minor,
+			&pay.Currency,
+			&app.ShiftPayUnit,
+			&app.ShiftOwnerID,
+			&app.ShiftCategoryID,
+			&app.ShiftStartsAt,
+			&app.ShiftEndsAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan application: %w", err)
 		}
+		app.ShiftPayRate = &pay
+		app.NegotiatedRate = scanRate(rateMinor, rateCurrency)
 		applications = append(applications, app)
 	}
 	
@@ -183,8 +283,9 @@ func (r *PostgresShiftRepo) GetApplicationsByWorker(ctx context.Context, workerI
 func (r *PostgresShiftRepo) GetApplicationsByShift(ctx context.Context, shiftID int64) ([]entity.Application, error) {
 	query := `
 		SELECT 
-			a.id, a.shift_id, a.worker_id, a.status, a.created_at,
-			u.full_name, u.email
+			a.id, a.shift_id, a.worker_id, a.status, a.created_at, a.reconfirm_by, a.standby_rank, a.offer_expires_at, a.cover_note,
+			a.reason_code, a.reason_note, a.decided_at, a.rate_minor, a.rate_currency, COALESCE(a.rate_turn, ''),
+			u.full_name, u.email, u.rating_avg::float8, u.rating_count
 		FROM applications a
 		JOIN users u ON a.worker_id = u.id
 		WHERE a.shift_id = $1
@@ -199,48 +300,208 @@ func (r *PostgresShiftRepo) GetApplicationsByShift(ctx context.Context, shiftID
 	var applications []entity.Application
 	for rows.Next() {
 		var app entity.Application
+		var rateMinor *int64
+		var rateCurrency *string
 		err := rows.Scan(
 			&app.ID,
 			&app.ShiftID,
 			&app.WorkerID,
 			&app.Status,
 			&app.CreatedAt,
+			&app.ReconfirmBy,
+			&app.StandbyRank,
+			&app.OfferExpiresAt,
+			&app.CoverNote,
+			&app.ReasonCode,
+			&app.ReasonNote,
+			&app.DecidedAt,
+			&rateMinor,
+			&rateCurrency,
+			&app.RateTurn,
 			&app.WorkerName,
 			&app.WorkerEmail,
+			&app.WorkerRatingAvg,
+			&app.WorkerRatingCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan application: %w", err)
 		}
+		app.NegotiatedRate = scanRate(rateMinor, rateCurrency)
 		applications = append(applications, app)
 	}
 	
 	return applications, nil
 }
 
-// UpdateApplicationStatus updates the status of an application
-func (r *PostgresShiftRepo) UpdateApplicationStatus(ctx context.Context, applicationID int64, status string) error {
-	query := `UPDATE applications SET status = $1 WHERE id = $2`
-	_, err := r.DB.Exec(ctx, query, status, applicationID)
+// TransitionApplicationStatus moves an application from one status to
+// another with the reason given for it, unless its status was changed in the
+// meantime. Rejections and withdrawals are timestamped.
+func (r *PostgresShiftRepo) TransitionApplicationStatus(ctx context.Context, applicationID int64, from, to entity.ApplicationStatus, reasonCode, reasonNote string) (bool, error) {
+	query := `
+		UPDATE applications
+		SET status = $1, standby_rank = NULL, offer_expires_at = NULL, reason_code = $4, reason_note = $5,
+			decided_at = CASE WHEN $1 IN ('REJECTED', 'WITHDRAWN') THEN now() END
+		WHERE id = $2 AND status = $3
+	`
+	result, err := r.DB.Exec(ctx, query, to, applicationID, from, reasonCode, reasonNote)
 	if err != nil {
-		return fmt.Errorf("failed to update application status: %w", err)
+		return false, fmt.Errorf("failed to update application status: %w", err)
 	}
-	return nil
+	return result.RowsAffected() > 0, nil
+}
+
+// AcceptApplication accepts an application (PENDING, STANDBY or OFFERED) into
+// a free slot of its OPEN shift in one transaction, and fills the shift when
+// that was the last slot. Nothing changes unless the application is still in
+// that status with no rate offer waiting for an answer, and the shift still
+// OPEN with a slot left.
+func (r *PostgresShiftRepo) AcceptApplication(ctx context.Context, applicationID, shiftID int64, from entity.ApplicationStatus) (accepted, filled bool, err error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	free, err := lockFreeSlots(ctx, tx, []int64{shiftID})
+	if err != nil {
+		return false, false, err
+	}
+	if free[shiftID] < 1 {
+		return false, false, nil
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE applications SET status = 'ACCEPTED', standby_rank = NULL, offer_expires_at = NULL
+		WHERE id = $1 AND shift_id = $2 AND status = $3 AND rate_turn IS NULL
+	`, applicationID, shiftID, from)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to accept application: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, false, nil
+	}
+
+	if free[shiftID] == 1 {
+		if _, err := tx.Exec(ctx, `UPDATE shifts SET status = 'FILLED' WHERE id = $1`, shiftID); err != nil {
+			return false, false, fmt.Errorf("failed to fill shift: %w", err)
+		}
+		filled = true
+	}
+
+	return true, filled, tx.Commit(ctx)
+}
+
+// BatchTransitionApplications moves every listed application to the same
+// status in one transaction. Accepting takes free slots of the OPEN shifts and
+// fills the ones left without any. Nothing changes (false) if an application
+// left its expected status (or got a rate offer, for accepts) or a shift ran
+// out of slots in the meantime.
+// Returns the shifts that were filled.
+func (r *PostgresShiftRepo) BatchTransitionApplications(ctx context.Context, items []entity.ApplicationTransition, to entity.ApplicationStatus, reasonCode, reasonNote string) ([]int64, bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var filled []int64
+	if to == entity.ApplicationAccepted {
+		taken := map[int64]int{}
+		shiftIDs := []int64{}
+		for _, it := range items {
+			if taken[it.ShiftID] == 0 {
+				shiftIDs = append(shiftIDs, it.ShiftID)
+			}
+			taken[it.ShiftID]++
+		}
+		free, err := lockFreeSlots(ctx, tx, shiftIDs)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, id := range shiftIDs {
+			if taken[id] > free[id] {
+				return nil, false, nil
+			}
+			if taken[id] == free[id] {
+				filled = append(filled, id)
+			}
+		}
+	}
+
+	for _, it := range items {
+		result, err := tx.Exec(ctx, `
+			UPDATE applications
+			SET status = $4, reason_code = $5, reason_note = $6, standby_rank = NULL, offer_expires_at = NULL,
+				decided_at = CASE WHEN $4 IN ('REJECTED', 'WITHDRAWN') THEN now() END
+			WHERE id = $1 AND shift_id = $2 AND status = $3 AND ($4 <> 'ACCEPTED' OR rate_turn IS NULL)
+		`, it.ApplicationID, it.ShiftID, it.From, to, reasonCode, reasonNote)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to update application %d: %w", it.ApplicationID, err)
+		}
+		if result.RowsAffected() == 0 {
+			return nil, false, nil
+		}
+	}
+
+	if len(filled) > 0 {
+		if _, err := tx.Exec(ctx, `UPDATE shifts SET status = 'FILLED' WHERE id = ANY($1)`, filled); err != nil {
+			return nil, false, fmt.Errorf("failed to fill shifts: %w", err)
+		}
+	}
+
+	return filled, true, tx.Commit(ctx)
+}
+
+// lockFreeSlots locks the shifts (in id order, so concurrent batches can't
+// deadlock) and counts the slots still free on the OPEN ones
+func lockFreeSlots(ctx context.Context, tx pgx.Tx, shiftIDs []int64) (map[int64]int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT s.id, s.slots - (SELECT COUNT(*) FROM applications a WHERE a.shift_id = s.id AND a.status = 'ACCEPTED')
+		FROM (SELECT id, slots FROM shifts WHERE id = ANY($1) AND status = 'OPEN' ORDER BY id FOR UPDATE) s
+	`, shiftIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock shifts: %w", err)
+	}
+	defer rows.Close()
+
+	free := map[int64]int{}
+	for rows.Next() {
+		var id int64
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan free slots: %w", err)
+		}
+		free[id] = n
+	}
+	return free, rows.Err()
 }
 
 // GetApplicationByID retrieves an application by its ID
 func (r *PostgresShiftRepo) GetApplicationByID(ctx context.Context, id int64) (*entity.Application, error) {
 	query := `
-		SELECT id, shift_id, worker_id, status, created_at
+		SELECT id, shift_id, worker_id, status, created_at, reconfirm_by, standby_rank, offer_expires_at,
+			reason_code, reason_note, decided_at, rate_minor, rate_currency, COALESCE(rate_turn, '')
 		FROM applications
 		WHERE id = $1
 	`
 	var app entity.Application
+	var rateMinor *int64
+	var rateCurrency *string
 	err := r.DB.QueryRow(ctx, query, id).Scan(
 		&app.ID,
 		&app.ShiftID,
 		&app.WorkerID,
 		&app.Status,
 		&app.CreatedAt,
+		&app.ReconfirmBy,
+		&app.StandbyRank,
+		&app.OfferExpiresAt,
+		&app.ReasonCode,
+		&app.ReasonNote,
+		&app.DecidedAt,
+		&rateMinor,
+		&rateCurrency,
+		&app.RateTurn,
 	)
 	
 	if err == pgx.ErrNoRows {
@@ -249,27 +510,36 @@ func (r *PostgresShiftRepo) GetApplicationByID(ctx context.Context, id int64) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get application: %w", err)
 	}
+	app.NegotiatedRate = scanRate(rateMinor, rateCurrency)
 	
 	return &app, nil
 }
 
-// UpdateShift updates shift details
+// UpdateShift updates shift details (the status is changed through TransitionShiftStatus)
 func (r *PostgresShiftRepo) UpdateShift(ctx context.Context, shift *entity.Shift) error {
 	query := `
 		UPDATE shifts
-		SET title = $1, description = $2, pay_rate = $3, lat = $4, lng = $5, status = $6
-		WHERE id = $7
+		SET title = $1, description = $2, pay_rate_minor = $3, pay_currency = $4, pay_unit = $5, lat = $6, lng = $7,
+			category_id = $8, starts_at = $9, ends_at = $10, slots = $12, location_id = $13,
+			is_exception = (series_id IS NOT NULL) -- Edited occurrences are no longer managed by their series
+		WHERE id = $11
 		RETURNING id
 	`
 	var id int64
 	err := r.DB.QueryRow(ctx, query,
 		shift.Title,
 		shift.Description,
-		shift.PayRate,
+		shift.PayRate.Minor,
+		shift.PayRate.Currency,
+		shift.PayUnit,
 		shift.Lat,
 		shift.Lng,
-		shift.Status,
+		shift.CategoryID,
+		shift.StartsAt,
+		shift.EndsAt,
 		shift.ID,
+		shift.Slots,
+		shift.LocationID,
 	).Scan(&id)
 
 	if err == pgx.ErrNoRows {
@@ -302,16 +572,68 @@ func (r *PostgresShiftRepo) DeleteShift(ctx context.Context, id int64) error {
 	return nil
 }
 
-// DeleteApplication deletes an application by ID
-func (r *PostgresShiftRepo) DeleteApplication(ctx context.Context, id int64) error {
-	result, err := r.DB.Exec(ctx, "DELETE FROM applications WHERE id = $1", id)
+// HasLedgerHistory reports whether money was posted against the shift's
+// cancellations (late cancellation compensation)
+func (r *PostgresShiftRepo) HasLedgerHistory(ctx context.Context, shiftID int64) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM ledger_transactions t
+			JOIN shift_cancellations c ON c.id = t.cancellation_id
+			WHERE c.shift_id = $1
+		)`, shiftID).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("failed to delete application: %w", err)
+		return false, fmt.Errorf("failed to check ledger history: %w", err)
 	}
+	return exists, nil
+}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("application not found")
+// CreateShiftOccurrence inserts one occurrence of a recurring series.
+// Returns false (and no error) if that date was already materialised.
+func (r *PostgresShiftRepo) CreateShiftOccurrence(ctx context.Context, shift *entity.Shift, occurrenceDate string) (bool, error) {
+	query := `
+		INSERT INTO shifts (owner_id, title, description, pay_rate_minor, pay_currency, pay_unit, lat, lng, status,
+			category_id, starts_at, ends_at, series_id, occurrence_date, visibility, release_at, slots, location_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'OPEN', $9, $10, $11, $12, $13::date, $14, $15, $16, $17)
+		ON CONFLICT (series_id, occurrence_date) DO NOTHING
+		RETURNING id, version, created_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		shift.OwnerID,
+		shift.Title,
+		shift.Description,
+		shift.PayRate.Minor,
+		shift.PayRate.Currency,
+		shift.PayUnit,
+		shift.Lat,
+		shift.Lng,
+		shift.CategoryID,
+		shift.StartsAt,
+		shift.EndsAt,
+		shift.SeriesID,
+		occurrenceDate,
+		shift.Visibility,
+		shift.ReleaseAt,
+		shift.Slots,
+		shift.LocationID,
+	).Scan(&shift.ID, &shift.Version, &shift.CreatedAt)
+
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert occurrence: %w", err)
 	}
+	return true, nil
+}
 
-	return nil
-}
\ No newline at end of file
+// GetUpcomingSeriesShifts retrieves the occurrences of a series that haven't started yet
+func (r *PostgresShiftRepo) GetUpcomingSeriesShifts(ctx context.Context, seriesID int64) ([]entity.Shift, error) {
+	query := `
+		SELECT ` + shiftColumns + `
+		FROM shifts
+		WHERE series_id = $1 AND starts_at > now()
+		ORDER BY starts_at
+	`
+	return r.queryShifts(ctx, query, seriesID)
+}
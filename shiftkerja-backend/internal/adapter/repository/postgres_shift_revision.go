@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresShiftRevisionRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresShiftRevisionRepo(db *pgxpool.Pool) *PostgresShiftRevisionRepo {
+	return &PostgresShiftRevisionRepo{DB: db}
+}
+
+// SaveRevision stores an edit as the next version and flags the applicants
+// who have to re-confirm it
+func (r *PostgresShiftRevisionRepo) SaveRevision(ctx context.Context, before *entity.Shift, rev *entity.ShiftRevision) ([]int64, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Shifts edited for the first time get their original version recorded
+	original := entity.NewShiftRevision(before, before.OwnerID)
+	original.Version = 1
+	original.CreatedAt = before.CreatedAt
+	if err := insertRevision(ctx, tx, original, `ON CONFLICT (shift_id, version) DO NOTHING`); err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to record original version: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `UPDATE shifts SET version = version + 1 WHERE id = $1 RETURNING version`, rev.ShiftID).Scan(&rev.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bump shift version: %w", err)
+	}
+	rev.CreatedAt = time.Now()
+	if err := insertRevision(ctx, tx, rev, ``); err != nil {
+		return nil, fmt.Errorf("failed to record revision: %w", err)
+	}
+
+	var workerIDs []int64
+	if rev.ReconfirmBy != nil {
+		rows, err := tx.Query(ctx, `
+			UPDATE applications SET reconfirm_by = $1
+			WHERE shift_id = $2 AND status IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED')
+			RETURNING worker_id
+		`, rev.ReconfirmBy, rev.ShiftID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request re-confirmation: %w", err)
+		}
+		if workerIDs, err = scanIDs(rows); err != nil {
+			return nil, fmt.Errorf("failed to request re-confirmation: %w", err)
+		}
+	}
+
+	return workerIDs, tx.Commit(ctx)
+}
+
+// insertRevision writes one revision; conflict is an optional ON CONFLICT clause
+func insertRevision(ctx context.Context, tx pgx.Tx, rev *entity.ShiftRevision, conflict string) error {
+	query := `
+		INSERT INTO shift_revisions (shift_id, version, edited_by, title, description, pay_rate_minor, pay_currency,
+			pay_unit, lat, lng, category_id, starts_at, ends_at, material_changes, reconfirm_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		` + conflict + `
+		RETURNING id
+	`
+	return tx.QueryRow(ctx, query,
+		rev.ShiftID,
+		rev.Version,
+		rev.EditedBy,
+		rev.Title,
+		rev.Description,
+		rev.PayRate.Minor,
+		rev.PayRate.Currency,
+		rev.PayUnit,
+		rev.Lat,
+		rev.Lng,
+		rev.CategoryID,
+		rev.StartsAt,
+		rev.EndsAt,
+		rev.MaterialChanges,
+		rev.ReconfirmBy,
+		rev.CreatedAt,
+	).Scan(&rev.ID)
+}
+
+// GetRevisions returns a shift's versions, oldest first
+func (r *PostgresShiftRevisionRepo) GetRevisions(ctx context.Context, shiftID int64) ([]entity.ShiftRevision, error) {
+	query := `
+		SELECT id, shift_id, version, edited_by, title, description, pay_rate_minor, pay_currency, pay_unit,
+			lat, lng, category_id, starts_at, ends_at, material_changes, reconfirm_by, created_at
+		FROM shift_revisions
+		WHERE shift_id = $1
+		ORDER BY version ASC
+	`
+	rows, err := r.DB.Query(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []entity.ShiftRevision
+	for rows.Next() {
+		var rev entity.ShiftRevision
+		err := rows.Scan(
+			&rev.ID,
+			&rev.ShiftID,
+			&rev.Version,
+			&rev.EditedBy,
+			&rev.Title,
+			&rev.Description,
+			&rev.PayRate.Minor,
+			&rev.PayRate.Currency,
+			&rev.PayUnit,
+			&rev.Lat,
+			&rev.Lng,
+			&rev.CategoryID,
+			&rev.StartsAt,
+			&rev.EndsAt,
+			&rev.MaterialChanges,
+			&rev.ReconfirmBy,
+			&rev.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+// ConfirmApplication clears the re-confirmation deadline of a live application
+func (r *PostgresShiftRevisionRepo) ConfirmApplication(ctx context.Context, applicationID int64) (bool, error) {
+	query := `
+		UPDATE applications SET reconfirm_by = NULL
+		WHERE id = $1 AND reconfirm_by IS NOT NULL AND status IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED')
+	`
+	result, err := r.DB.Exec(ctx, query, applicationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm application: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ReleaseUnconfirmed cancels applications that declined or missed their
+// re-confirmation and reopens the FILLED shifts they leave with a free slot
+func (r *PostgresShiftRevisionRepo) ReleaseUnconfirmed(ctx context.Context, applicationID int64, deadlineBefore *time.Time) ([]entity.ReleasedApplication, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The joined row still holds the status from before the update
+	rows, err := tx.Query(ctx, `
+		UPDATE applications a SET status = 'CANCELLED', reconfirm_by = NULL
+		FROM applications old, shifts s
+		WHERE old.id = a.id AND s.id = a.shift_id
+			AND a.reconfirm_by IS NOT NULL AND a.status IN ('PENDING', 'STANDBY', 'OFFERED', 'ACCEPTED')
+			AND ($1 = 0 OR a.id = $1)
+			AND ($2::timestamptz IS NULL OR a.reconfirm_by <= $2)
+		RETURNING a.id, a.shift_id, a.worker_id, s.owner_id, old.status
+	`, applicationID, deadlineBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release applications: %w", err)
+	}
+	var released []entity.ReleasedApplication
+	for rows.Next() {
+		var rel entity.ReleasedApplication
+		if err := rows.Scan(&rel.ApplicationID, &rel.ShiftID, &rel.WorkerID, &rel.BusinessID, &rel.WasStatus); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan released application: %w", err)
+		}
+		released = append(released, rel)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to release applications: %w", err)
+	}
+
+	var vacated []int64
+	for _, rel := range released {
+		if rel.WasStatus == entity.ApplicationAccepted {
+			vacated = append(vacated, rel.ShiftID)
+		}
+	}
+	if len(vacated) > 0 {
+		rows, err := tx.Query(ctx, `
+			UPDATE shifts s SET status = 'OPEN'
+			WHERE s.id = ANY($1) AND s.status = 'FILLED'
+				AND (s.starts_at IS NULL OR s.starts_at > now())
+				AND (SELECT COUNT(*) FROM applications x WHERE x.shift_id = s.id AND x.status = 'ACCEPTED') < s.slots
+			RETURNING s.id
+		`, vacated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reopen shifts: %w", err)
+		}
+		reopened, err := scanIDs(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reopen shifts: %w", err)
+		}
+		for i := range released {
+			for _, id := range reopened {
+				if released[i].ShiftID == id && released[i].WasStatus == entity.ApplicationAccepted {
+					released[i].ShiftReopened = true
+				}
+			}
+		}
+	}
+
+	return released, tx.Commit(ctx)
+}
+
+// scanIDs reads a single bigint column and closes the rows
+func scanIDs(rows pgx.Rows) ([]int64, error) {
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTaxonomyRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresTaxonomyRepo(db *pgxpool.Pool) *PostgresTaxonomyRepo {
+	return &PostgresTaxonomyRepo{DB: db}
+}
+
+// CreateCategory inserts a new job category
+func (r *PostgresTaxonomyRepo) CreateCategory(ctx context.Context, category *entity.Category) error {
+	query := `
+		INSERT INTO categories (name, description)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRow(ctx, query, category.Name, category.Description).Scan(&category.ID, &category.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert category: %w", err)
+	}
+	return nil
+}
+
+// ListCategories retrieves all categories ordered by name
+func (r *PostgresTaxonomyRepo) ListCategories(ctx context.Context) ([]entity.Category, error) {
+	query := `SELECT id, name, COALESCE(description, ''), created_at FROM categories ORDER BY name`
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []entity.Category
+	for rows.Next() {
+		var c entity.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, c)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryByID retrieves a category by its ID
+func (r *PostgresTaxonomyRepo) GetCategoryByID(ctx context.Context, id int64) (*entity.Category, error) {
+	query := `SELECT id, name, COALESCE(description, ''), created_at FROM categories WHERE id = $1`
+	var c entity.Category
+	err := r.DB.QueryRow(ctx, query, id).Scan(&c.ID, &c.Name, &c.Description, &c.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("category not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateSkill inserts a new skill
+func (r *PostgresTaxonomyRepo) CreateSkill(ctx context.Context, skill *entity.Skill) error {
+	query := `
+		INSERT INTO skills (category_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRow(ctx, query, skill.CategoryID, skill.Name).Scan(&skill.ID, &skill.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert skill: %w", err)
+	}
+	return nil
+}
+
+// ListSkills retrieves all skills, optionally restricted to one category (0 = all)
+func (r *PostgresTaxonomyRepo) ListSkills(ctx context.Context, categoryID int64) ([]entity.Skill, error) {
+	query := `
+		SELECT id, category_id, name, created_at
+		FROM skills
+		WHERE $1 = 0 OR category_id = $1
+		ORDER BY name
+	`
+	rows, err := r.DB.Query(ctx, query, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skills: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSkills(rows)
+}
+
+// GetSkillsByIDs retrieves the skills matching the given IDs (unknown IDs are skipped)
+func (r *PostgresTaxonomyRepo) GetSkillsByIDs(ctx context.Context, ids []int64) ([]entity.Skill, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `
+		SELECT id, category_id, name, created_at
+		FROM skills
+		WHERE id = ANY($1)
+		ORDER BY name
+	`
+	rows, err := r.DB.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skills: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSkills(rows)
+}
+
+// SetShiftSkills replaces the required skills of a shift
+func (r *PostgresTaxonomyRepo) SetShiftSkills(ctx context.Context, shiftID int64, skillIDs []int64) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM shift_skills WHERE shift_id = $1", shiftID); err != nil {
+		return fmt.Errorf("failed to clear shift skills: %w", err)
+	}
+	if err := insertShiftSkills(ctx, tx, shiftID, skillIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertShiftSkills adds required skills to a shift as part of a transaction
+func insertShiftSkills(ctx context.Context, tx pgx.Tx, shiftID int64, skillIDs []int64) error {
+	for _, skillID := range skillIDs {
+		_, err := tx.Exec(ctx,
+			"INSERT INTO shift_skills (shift_id, skill_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			shiftID, skillID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert shift skill: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetShiftSkills retrieves the required skills for several shifts at once, keyed by shift ID
+func (r *PostgresTaxonomyRepo) GetShiftSkills(ctx context.Context, shiftIDs []int64) (map[int64][]entity.Skill, error) {
+	result := make(map[int64][]entity.Skill)
+	if len(shiftIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT ss.shift_id, s.id, s.category_id, s.name, s.created_at
+		FROM shift_skills ss
+		JOIN skills s ON ss.skill_id = s.id
+		WHERE ss.shift_id = ANY($1)
+		ORDER BY s.name
+	`
+	rows, err := r.DB.Query(ctx, query, shiftIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shift skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shiftID int64
+		var s entity.Skill
+		if err := rows.Scan(&shiftID, &s.ID, &s.CategoryID, &s.Name, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shift skill: %w", err)
+		}
+		result[shiftID] = append(result[shiftID], s)
+	}
+
+	return result, nil
+}
+
+// SetWorkerSkills replaces the skill profile of a worker
+func (r *PostgresTaxonomyRepo) SetWorkerSkills(ctx context.Context, workerID int64, skills []entity.WorkerSkill) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM worker_skills WHERE worker_id = $1", workerID); err != nil {
+		return fmt.Errorf("failed to clear worker skills: %w", err)
+	}
+	for _, ws := range skills {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO worker_skills (worker_id, skill_id, level, years_experience)
+			VALUES ($1, $2, $3, $4)
+		`, workerID, ws.SkillID, ws.Level, ws.YearsExperience)
+		if err != nil {
+			return fmt.Errorf("failed to insert worker skill: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetWorkerSkills retrieves the skill profile of a worker with skill names
+func (r *PostgresTaxonomyRepo) GetWorkerSkills(ctx context.Context, workerID int64) ([]entity.WorkerSkill, error) {
+	query := `
+		SELECT ws.worker_id, ws.skill_id, ws.level, ws.years_experience, s.name
+		FROM worker_skills ws
+		JOIN skills s ON ws.skill_id = s.id
+		WHERE ws.worker_id = $1
+		ORDER BY s.name
+	`
+	rows, err := r.DB.Query(ctx, query, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query worker skills: %w", err)
+	}
+	defer rows.Close()
+
+	var skills []entity.WorkerSkill
+	for rows.Next() {
+		var ws entity.WorkerSkill
+		if err := rows.Scan(&ws.WorkerID, &ws.SkillID, &ws.Level, &ws.YearsExperience, &ws.SkillName); err != nil {
+			return nil, fmt.Errorf("failed to scan worker skill: %w", err)
+		}
+		skills = append(skills, ws)
+	}
+
+	return skills, nil
+}
+
+// GetWorkerSkillsByWorkers retrieves the skill profiles of several workers, keyed by worker ID
+func (r *PostgresTaxonomyRepo) GetWorkerSkillsByWorkers(ctx context.Context, workerIDs []int64) (map[int64][]entity.WorkerSkill, error) {
+	result := make(map[int64][]entity.WorkerSkill)
+	if len(workerIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT ws.worker_id, ws.skill_id, ws.level, ws.years_experience, s.name
+		FROM worker_skills ws
+		JOIN skills s ON ws.skill_id = s.id
+		WHERE ws.worker_id = ANY($1)
+		ORDER BY s.name
+	`
+	rows, err := r.DB.Query(ctx, query, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query worker skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ws entity.WorkerSkill
+		if err := rows.Scan(&ws.WorkerID, &ws.SkillID, &ws.Level, &ws.YearsExperience, &ws.SkillName); err != nil {
+			return nil, fmt.Errorf("failed to scan worker skill: %w", err)
+		}
+		result[ws.WorkerID] = append(result[ws.WorkerID], ws)
+	}
+
+	return result, nil
+}
+
+func scanSkills(rows pgx.Rows) ([]entity.Skill, error) {
+	var skills []entity.Skill
+	for rows.Next() {
+		var s entity.Skill
+		if err := rows.Scan(&s.ID, &s.CategoryID, &s.Name, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan skill: %w", err)
+		}
+		skills = append(skills, s)
+	}
+	return skills, nil
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTemplateRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresTemplateRepo(db *pgxpool.Pool) *PostgresTemplateRepo {
+	return &PostgresTemplateRepo{DB: db}
+}
+
+const templateColumns = `id, owner_id, name, title, description, pay_rate_minor, pay_currency, pay_unit, lat, lng, location_id,
+	category_id, skill_ids, start_minute, duration_minutes, timezone, visibility, release_after_hours, slots, created_at`
+
+func scanTemplate(row pgx.Row, t *entity.ShiftTemplate) error {
+	return row.Scan(
+		&t.ID,
+		&t.OwnerID,
+		&t.Name,
+		&t.Title,
+		&t.Description,
+		&t.PayRate.Minor,
+		&t.PayRate.Currency,
+		&t.PayUnit,
+		&t.Lat,
+		&t.Lng,
+		&t.LocationID,
+		&t.CategoryID,
+		&t.SkillIDs,
+		&t.StartMinute,
+		&t.DurationMinutes,
+		&t.Timezone,
+		&t.Visibility,
+		&t.ReleaseAfterHours,
+		&t.Slots,
+		&t.CreatedAt,
+	)
+}
+
+// CreateTemplate inserts a new shift template
+func (r *PostgresTemplateRepo) CreateTemplate(ctx context.Context, t *entity.ShiftTemplate) error {
+	query := `
+		INSERT INTO shift_templates (owner_id, name, title, description, pay_rate_minor, pay_currency, pay_unit,
+			lat, lng, location_id, category_id, skill_ids, start_minute, duration_minutes, timezone, visibility,
+			release_after_hours, slots)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		t.OwnerID,
+		t.Name,
+		t.Title,
+		t.Description,
+		t.PayRate.Minor,
+		t.PayRate.Currency,
+		t.PayUnit,
+		t.Lat,
+		t.Lng,
+		t.LocationID,
+		t.CategoryID,
+		t.SkillIDs,
+		t.StartMinute,
+		t.DurationMinutes,
+		t.Timezone,
+		t.Visibility,
+		t.ReleaseAfterHours,
+		t.Slots,
+	).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert template: %w", err)
+	}
+	return nil
+}
+
+// GetTemplateByID retrieves a template by its ID
+func (r *PostgresTemplateRepo) GetTemplateByID(ctx context.Context, id int64) (*entity.ShiftTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM shift_templates WHERE id = $1`
+	var t entity.ShiftTemplate
+	err := scanTemplate(r.DB.QueryRow(ctx, query, id), &t)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("template not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	return &t, nil
+}
+
+// GetTemplatesByOwner retrieves all templates of a business
+func (r *PostgresTemplateRepo) GetTemplatesByOwner(ctx context.Context, ownerID int64) ([]entity.ShiftTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM shift_templates WHERE owner_id = $1 ORDER BY name`
+	rows, err := r.DB.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []entity.ShiftTemplate
+	for rows.Next() {
+		var t entity.ShiftTemplate
+		if err := scanTemplate(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// UpdateTemplate overwrites a template (already materialised shifts are not touched)
+func (r *PostgresTemplateRepo) UpdateTemplate(ctx context.Context, t *entity.ShiftTemplate) error {
+	query := `
+		UPDATE shift_templates
+		SET name = $1, title = $2, description = $3, pay_rate_minor = $4, pay_currency = $5, pay_unit = $6,
+			lat = $7, lng = $8, location_id = $9, category_id = $10, skill_ids = $11, start_minute = $12,
+			duration_minutes = $13, timezone = $14, visibility = $15, release_after_hours = $16, slots = $17
+		WHERE id = $18
+	`
+	result, err := r.DB.Exec(ctx, query,
+		t.Name,
+		t.Title,
+		t.Description,
+		t.PayRate.Minor,
+		t.PayRate.Currency,
+		t.PayUnit,
+		t.Lat,
+		t.Lng,
+		t.LocationID,
+		t.CategoryID,
+		t.SkillIDs,
+		t.StartMinute,
+		t.DurationMinutes,
+		t.Timezone,
+		t.Visibility,
+		t.ReleaseAfterHours,
+		t.Slots,
+		t.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("template not found")
+	}
+	return nil
+}
+
+// DeleteTemplate deletes a template by ID
+func (r *PostgresTemplateRepo) DeleteTemplate(ctx context.Context, id int64) error {
+	result, err := r.DB.Exec(ctx, "DELETE FROM shift_templates WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("template not found")
+	}
+	return nil
+}
+
+const seriesColumns = `id, template_id, owner_id, rrule, to_char(starts_on, 'YYYY-MM-DD'),
+	COALESCE(to_char(materialised_until, 'YYYY-MM-DD'), ''), status, created_at`
+
+func scanSeries(row pgx.Row, s *entity.ShiftSeries) error {
+	return row.Scan(
+		&s.ID,
+		&s.TemplateID,
+		&s.OwnerID,
+		&s.RRule,
+		&s.StartsOn,
+		&s.MaterialisedUntil,
+		&s.Status,
+		&s.CreatedAt,
+	)
+}
+
+// CreateSeries inserts a new recurring series
+func (r *PostgresTemplateRepo) CreateSeries(ctx context.Context, series *entity.ShiftSeries) error {
+	query := `
+		INSERT INTO shift_series (template_id, owner_id, rrule, starts_on, status)
+		VALUES ($1, $2, $3, $4::date, 'ACTIVE')
+		RETURNING id, status, created_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		series.TemplateID,
+		series.OwnerID,
+		series.RRule,
+		series.StartsOn,
+	).Scan(&series.ID, &series.Status, &series.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert series: %w", err)
+	}
+	return nil
+}
+
+// GetSeriesByID retrieves a series by its ID
+func (r *PostgresTemplateRepo) GetSeriesByID(ctx context.Context, id int64) (*entity.ShiftSeries, error) {
+	query := `SELECT ` + seriesColumns + ` FROM shift_series WHERE id = $1`
+	var s entity.ShiftSeries
+	err := scanSeries(r.DB.QueryRow(ctx, query, id), &s)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("series not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	return &s, nil
+}
+
+// GetSeriesByOwner retrieves all series of a business, newest first
+func (r *PostgresTemplateRepo) GetSeriesByOwner(ctx context.Context, ownerID int64) ([]entity.ShiftSeries, error) {
+	query := `SELECT ` + seriesColumns + ` FROM shift_series WHERE owner_id = $1 ORDER BY created_at DESC`
+	return r.querySeries(ctx, query, ownerID)
+}
+
+// GetActiveSeries retrieves every series the scheduler still has to materialise
+func (r *PostgresTemplateRepo) GetActiveSeries(ctx context.Context) ([]entity.ShiftSeries, error) {
+	query := `SELECT ` + seriesColumns + ` FROM shift_series WHERE status = 'ACTIVE' ORDER BY id`
+	return r.querySeries(ctx, query)
+}
+
+// UpdateSeriesProgress records how far a series has been materialised and its status
+func (r *PostgresTemplateRepo) UpdateSeriesProgress(ctx context.Context, id int64, materialisedUntil, status string) error {
+	query := `
+		UPDATE shift_series
+		SET materialised_until = NULLIF($1, '')::date, status = $2
+		WHERE id = $3
+	`
+	if _, err := r.DB.Exec(ctx, query, materialisedUntil, status, id); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTemplateRepo) querySeries(ctx context.Context, query string, args ...interface{}) ([]entity.ShiftSeries, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series: %w", err)
+	}
+	defer rows.Close()
+
+	var series []entity.ShiftSeries
+	for rows.Next() {
+		var s entity.ShiftSeries
+		if err := scanSeries(rows, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
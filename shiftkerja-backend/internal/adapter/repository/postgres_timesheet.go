@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTimesheetRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresTimesheetRepo(db *pgxpool.Pool) *PostgresTimesheetRepo {
+	return &PostgresTimesheetRepo{DB: db}
+}
+
+const timesheetColumns = `t.id, t.application_id, t.attendance_id, t.shift_id, t.worker_id, t.business_id,
+	t.started_at, t.ended_at, t.break_minutes, t.worked_minutes, t.pay_rate_minor, t.pay_currency, t.pay_unit, t.pay_amount_minor,
+	t.region_code, t.holiday_minutes, t.minimum_wage_applied, t.status, t.note, t.created_at, t.updated_at, s.title, u.full_name`
+
+const timesheetFrom = `
+	FROM timesheets t
+	JOIN shifts s ON t.shift_id = s.id
+	JOIN users u ON t.worker_id = u.id`
+
+func scanTimesheet(row pgx.Row, t *entity.Timesheet) error {
+	err := row.Scan(
+		&t.ID,
+		&t.ApplicationID,
+		&t.AttendanceID,
+		&t.ShiftID,
+		&t.WorkerID,
+		&t.BusinessID,
+		&t.StartedAt,
+		&t.EndedAt,
+		&t.BreakMinutes,
+		&t.WorkedMinutes,
+		&t.PayRate.Minor,
+		&t.PayRate.Currency,
+		&t.PayUnit,
+		&t.PayAmount.Minor,
+		&t.RegionCode,
+		&t.HolidayMinutes,
+		&t.MinimumWageApplied,
+		&t.Status,
+		&t.Note,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.ShiftTitle,
+		&t.WorkerName,
+	)
+	t.PayAmount.Currency = t.PayRate.Currency
+	return err
+}
+
+// CreateTimesheet inserts a timesheet together with its first history entry
+func (r *PostgresTimesheetRepo) CreateTimesheet(ctx context.Context, ts *entity.Timesheet, entry *entity.TimesheetHistoryEntry) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO timesheets (application_id, attendance_id, shift_id, worker_id, business_id,
+			started_at, ended_at, break_minutes, worked_minutes, pay_rate_minor, pay_currency, pay_unit,
+			pay_amount_minor, region_code, holiday_minutes, minimum_wage_applied, status, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(ctx, query,
+		ts.ApplicationID,
+		ts.AttendanceID,
+		ts.ShiftID,
+		ts.WorkerID,
+		ts.BusinessID,
+		ts.StartedAt,
+		ts.EndedAt,
+		ts.BreakMinutes,
+		ts.WorkedMinutes,
+		ts.PayRate.Minor,
+		ts.PayRate.Currency,
+		ts.PayUnit,
+		ts.PayAmount.Minor,
+		ts.RegionCode,
+		ts.HolidayMinutes,
+		ts.MinimumWageApplied,
+		ts.Status,
+		ts.Note,
+	).Scan(&ts.ID, &ts.CreatedAt, &ts.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert timesheet: %w", err)
+	}
+
+	entry.TimesheetID = ts.ID
+	if err := insertTimesheetHistory(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UpdateTimesheet saves a change and its history entry, guarded by the expected status
+func (r *PostgresTimesheetRepo) UpdateTimesheet(ctx context.Context, ts *entity.Timesheet, fromStatus string, entry *entity.TimesheetHistoryEntry) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE timesheets
+		SET started_at = $1, ended_at = $2, break_minutes = $3, worked_minutes = $4,
+			pay_amount_minor = $5, holiday_minutes = $6, minimum_wage_applied = $7, status = $8, note = $9,
+			updated_at = now()
+		WHERE id = $10 AND status = $11
+		RETURNING updated_at
+	`
+	err = tx.QueryRow(ctx, query,
+		ts.StartedAt,
+		ts.EndedAt,
+		ts.BreakMinutes,
+		ts.WorkedMinutes,
+		ts.PayAmount.Minor,
+		ts.HolidayMinutes,
+		ts.MinimumWageApplied,
+		ts.Status,
+		ts.Note,
+		ts.ID,
+		fromStatus,
+	).Scan(&ts.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update timesheet: %w", err)
+	}
+
+	if err := insertTimesheetHistory(ctx, tx, entry); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit timesheet update: %w", err)
+	}
+	return true, nil
+}
+
+func insertTimesheetHistory(ctx context.Context, tx pgx.Tx, e *entity.TimesheetHistoryEntry) error {
+	query := `
+		INSERT INTO timesheet_history (timesheet_id, actor_id, action, from_status, to_status,
+			started_at, ended_at, break_minutes, worked_minutes, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, query,
+		e.TimesheetID,
+		e.ActorID,
+		e.Action,
+		e.FromStatus,
+		e.ToStatus,
+		e.StartedAt,
+		e.EndedAt,
+		e.BreakMinutes,
+		e.WorkedMinutes,
+		e.Reason,
+	).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert timesheet history: %w", err)
+	}
+	return nil
+}
+
+// GetTimesheetByID retrieves a timesheet by its ID
+func (r *PostgresTimesheetRepo) GetTimesheetByID(ctx context.Context, id int64) (*entity.Timesheet, error) {
+	query := `SELECT ` + timesheetColumns + timesheetFrom + ` WHERE t.id = $1`
+	var t entity.Timesheet
+	err := scanTimesheet(r.DB.QueryRow(ctx, query, id), &t)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("timesheet not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timesheet: %w", err)
+	}
+	return &t, nil
+}
+
+// GetTimesheetsByWorker retrieves a worker's timesheets, optionally filtered by status
+func (r *PostgresTimesheetRepo) GetTimesheetsByWorker(ctx context.Context, workerID int64, status string) ([]entity.Timesheet, error) {
+	query := `SELECT ` + timesheetColumns + timesheetFrom + `
+		WHERE t.worker_id = $1 AND ($2::varchar = '' OR t.status = $2)
+		ORDER BY t.started_at DESC`
+	return r.queryTimesheets(ctx, query, workerID, status)
+}
+
+// GetTimesheetsByBusiness retrieves the timesheets of a business's shifts, optionally filtered by status
+func (r *PostgresTimesheetRepo) GetTimesheetsByBusiness(ctx context.Context, businessID int64, status string) ([]entity.Timesheet, error) {
+	query := `SELECT ` + timesheetColumns + timesheetFrom + `
+		WHERE t.business_id = $1 AND ($2::varchar = '' OR t.status = $2)
+		ORDER BY t.started_at DESC`
+	return r.queryTimesheets(ctx, query, businessID, status)
+}
+
+func (r *PostgresTimesheetRepo) queryTimesheets(ctx context.Context, query string, args ...interface{}) ([]entity.Timesheet, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timesheets: %w", err)
+	}
+	defer rows.Close()
+
+	var timesheets []entity.Timesheet
+	for rows.Next() {
+		var t entity.Timesheet
+		if err := scanTimesheet(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan timesheet: %w", err)
+		}
+		timesheets = append(timesheets, t)
+	}
+	return timesheets, nil
+}
+
+// GetTimesheetHistory retrieves the audit trail of a timesheet, oldest first
+func (r *PostgresTimesheetRepo) GetTimesheetHistory(ctx context.Context, timesheetID int64) ([]entity.TimesheetHistoryEntry, error) {
+	query := `
+		SELECT id, timesheet_id, actor_id, action, from_status, to_status,
+			started_at, ended_at, break_minutes, worked_minutes, reason, created_at
+		FROM timesheet_history
+		WHERE timesheet_id = $1
+		ORDER BY created_at, id
+	`
+	rows, err := r.DB.Query(ctx, query, timesheetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timesheet history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []entity.TimesheetHistoryEntry
+	for rows.Next() {
+		var e entity.TimesheetHistoryEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.TimesheetID,
+			&e.ActorID,
+			&e.Action,
+			&e.FromStatus,
+			&e.ToStatus,
+			&e.StartedAt,
+			&e.EndedAt,
+			&e.BreakMinutes,
+			&e.WorkedMinutes,
+			&e.Reason,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan timesheet history: %w", err)
+		}
+		history = append(history, e)
+	}
+	return history, nil
+}
@@ -61,4 +61,13 @@ func (r *PostgresUserRepo) GetUserByID(ctx context.Context, id int64) (*entity.U
 		return nil, err
 	}
 	return &user, nil
+}
+
+// SetUserRole changes a user's role (used to promote admins from the CLI)
+func (r *PostgresUserRepo) SetUserRole(ctx context.Context, id int64, role string) error {
+	_, err := r.DB.Exec(ctx, `UPDATE users SET role = $1 WHERE id = $2`, role, id)
+	if err != nil {
+		return fmt.Errorf("failed to set role: %w", err)
+	}
+	return nil
 }
\ No newline at end of file
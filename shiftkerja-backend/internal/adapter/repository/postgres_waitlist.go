@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWaitlistRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresWaitlistRepo(db *pgxpool.Pool) *PostgresWaitlistRepo {
+	return &PostgresWaitlistRepo{DB: db}
+}
+
+// SetStandby replaces the shift's standby list with the given applications, in rank order
+func (r *PostgresWaitlistRepo) SetStandby(ctx context.Context, shiftID int64, applicationIDs []int64) (bool, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE applications SET status = 'PENDING', standby_rank = NULL
+		WHERE shift_id = $1 AND status = 'STANDBY' AND NOT (id = ANY($2))
+	`, shiftID, applicationIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to clear standby list: %w", err)
+	}
+
+	for i, id := range applicationIDs {
+		result, err := tx.Exec(ctx, `
+			UPDATE applications SET status = 'STANDBY', standby_rank = $1
+			WHERE id = $2 AND shift_id = $3 AND status IN ('PENDING', 'STANDBY')
+		`, i+1, id, shiftID)
+		if err != nil {
+			return false, fmt.Errorf("failed to rank application %d: %w", id, err)
+		}
+		if result.RowsAffected() == 0 {
+			return false, nil
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// ExpireOffers cancels lapsed offers and puts offers on shifts that were
+// filled or closed in the meantime back on standby
+func (r *PostgresWaitlistRepo) ExpireOffers(ctx context.Context, now time.Time) ([]entity.WaitlistOffer, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE applications a SET status = 'STANDBY', offer_expires_at = NULL
+		FROM shifts s
+		WHERE s.id = a.shift_id AND a.status = 'OFFERED' AND s.status <> 'OPEN'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw offers: %w", err)
+	}
+
+	// offer_expires_at is kept on the cancelled application as a record
+	rows, err := tx.Query(ctx, `
+		UPDATE applications a SET status = 'CANCELLED', standby_rank = NULL
+		FROM shifts s
+		WHERE s.id = a.shift_id AND a.status = 'OFFERED' AND a.offer_expires_at <= $1
+		RETURNING a.id, a.shift_id, a.worker_id, s.title, a.offer_expires_at
+	`, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire offers: %w", err)
+	}
+	expired, err := scanOffers(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return expired, tx.Commit(ctx)
+}
+
+// OfferNext offers each free slot (not accepted or already on offer) to the
+// next best ranked standby applicant of its shift
+func (r *PostgresWaitlistRepo) OfferNext(ctx context.Context, now, deadline time.Time) ([]entity.WaitlistOffer, error) {
+	// Applicants still re-confirming a material change or negotiating their
+	// rate are skipped until they're done, and applicants with a block with the
+	// business are skipped altogether
+	query := `
+		WITH free AS (
+			SELECT s.id, s.slots - (
+				SELECT COUNT(*) FROM applications x
+				WHERE x.shift_id = s.id AND x.status IN ('OFFERED', 'ACCEPTED')
+			) AS n
+			FROM shifts s
+			WHERE s.status = 'OPEN' AND (s.starts_at IS NULL OR s.starts_at > $1)
+		), ranked AS (
+			SELECT a.id, a.shift_id, ROW_NUMBER() OVER (PARTITION BY a.shift_id ORDER BY a.standby_rank, a.id) AS pos
+			FROM applications a
+			JOIN shifts s ON s.id = a.shift_id
+			WHERE a.status = 'STANDBY' AND a.reconfirm_by IS NULL AND a.rate_turn IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM blocks b
+					WHERE (b.blocker_id = a.worker_id AND b.blocked_id = s.owner_id)
+						OR (b.blocker_id = s.owner_id AND b.blocked_id = a.worker_id)
+				)
+		), next AS (
+			SELECT ranked.id FROM ranked JOIN free ON free.id = ranked.shift_id
+			WHERE ranked.pos <= free.n
+		)
+		UPDATE applications a
+		SET status = 'OFFERED', offer_expires_at = LEAST($2::timestamptz, COALESCE(s.starts_at, $2::timestamptz))
+		FROM next, shifts s
+		WHERE a.id = next.id AND s.id = a.shift_id AND a.status = 'STANDBY'
+		RETURNING a.id, a.shift_id, a.worker_id, s.title, a.offer_expires_at
+	`
+	rows, err := r.DB.Query(ctx, query, now, deadline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make offers: %w", err)
+	}
+	return scanOffers(rows)
+}
+
+func scanOffers(rows pgx.Rows) ([]entity.WaitlistOffer, error) {
+	defer rows.Close()
+
+	var offers []entity.WaitlistOffer
+	for rows.Next() {
+		var o entity.WaitlistOffer
+		if err := rows.Scan(&o.ApplicationID, &o.ShiftID, &o.WorkerID, &o.ShiftTitle, &o.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan offer: %w", err)
+		}
+		offers = append(offers, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read offers: %w", err)
+	}
+	return offers, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWorkerProfileRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresWorkerProfileRepo(db *pgxpool.Pool) *PostgresWorkerProfileRepo {
+	return &PostgresWorkerProfileRepo{DB: db}
+}
+
+// GetPreferences retrieves the preferences of a worker (nil if none saved yet)
+func (r *PostgresWorkerProfileRepo) GetPreferences(ctx context.Context, workerID int64) (*entity.WorkerPreferences, error) {
+	query := `
+		SELECT worker_id, min_pay_rate_minor, min_pay_currency, home_lat, home_lng, max_distance_km, timezone, updated_at
+		FROM worker_preferences
+		WHERE worker_id = $1
+	`
+	var p entity.WorkerPreferences
+	err := r.DB.QueryRow(ctx, query, workerID).Scan(
+		&p.WorkerID,
+		&p.MinPayRate.Minor,
+		&p.MinPayRate.Currency,
+		&p.HomeLat,
+		&p.HomeLng,
+		&p.MaxDistanceKm,
+		&p.Timezone,
+		&p.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worker preferences: %w", err)
+	}
+	return &p, nil
+}
+
+// UpsertPreferences creates or replaces the preferences of a worker
+func (r *PostgresWorkerProfileRepo) UpsertPreferences(ctx context.Context, prefs *entity.WorkerPreferences) error {
+	query := `
+		INSERT INTO worker_preferences (worker_id, min_pay_rate_minor, min_pay_currency, home_lat, home_lng,
+			max_distance_km, timezone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+		ON CONFLICT (worker_id) DO UPDATE
+		SET min_pay_rate_minor = EXCLUDED.min_pay_rate_minor,
+			min_pay_currency = EXCLUDED.min_pay_currency,
+			home_lat = EXCLUDED.home_lat,
+			home_lng = EXCLUDED.home_lng,
+			max_distance_km = EXCLUDED.max_distance_km,
+			timezone = EXCLUDED.timezone,
+			updated_at = now()
+		RETURNING updated_at
+	`
+	err := r.DB.QueryRow(ctx, query,
+		prefs.WorkerID,
+		prefs.MinPayRate.Minor,
+		prefs.MinPayRate.Currency,
+		prefs.HomeLat,
+		prefs.HomeLng,
+		prefs.MaxDistanceKm,
+		prefs.Timezone,
+	).Scan(&prefs.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save worker preferences: %w", err)
+	}
+	return nil
+}
+
+// GetPreferencesByWorkers retrieves the preferences of several workers, keyed by worker ID
+func (r *PostgresWorkerProfileRepo) GetPreferencesByWorkers(ctx context.Context, workerIDs []int64) (map[int64]entity.WorkerPreferences, error) {
+	result := make(map[int64]entity.WorkerPreferences)
+	if len(workerIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT worker_id, min_pay_rate_minor, min_pay_currency, home_lat, home_lng, max_distance_km, timezone, updated_at
+		FROM worker_preferences
+		WHERE worker_id = ANY($1)
+	`
+	rows, err := r.DB.Query(ctx, query, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query worker preferences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p entity.WorkerPreferences
+		if err := rows.Scan(&p.WorkerID, &p.MinPayRate.Minor, &p.MinPayRate.Currency, &p.HomeLat, &p.HomeLng, &p.MaxDistanceKm, &p.Timezone, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan worker preferences: %w", err)
+		}
+		result[p.WorkerID] = p
+	}
+
+	return result, nil
+}
+
+// GetAvailability retrieves the weekly windows and blackout dates of a worker
+func (r *PostgresWorkerProfileRepo) GetAvailability(ctx context.Context, workerID int64) (*entity.WorkerAvailability, error) {
+	av := &entity.WorkerAvailability{
+		WorkerID:  workerID,
+		Windows:   []entity.AvailabilityWindow{},
+		Blackouts: []entity.BlackoutDate{},
+	}
+
+	err := r.DB.QueryRow(ctx,
+		"SELECT COALESCE((SELECT timezone FROM worker_preferences WHERE worker_id = $1), 'Asia/Jakarta')",
+		workerID,
+	).Scan(&av.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worker timezone: %w", err)
+	}
+
+	rows, err := r.DB.Query(ctx, `
+		SELECT weekday, start_minute, end_minute
+		FROM worker_availability_windows
+		WHERE worker_id = $1
+		ORDER BY weekday, start_minute
+	`, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query availability windows: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var w entity.AvailabilityWindow
+		if err := rows.Scan(&w.Weekday, &w.StartMinute, &w.EndMinute); err != nil {
+			return nil, fmt.Errorf("failed to scan availability window: %w", err)
+		}
+		av.Windows = append(av.Windows, w)
+	}
+	rows.Close()
+
+	rows, err = r.DB.Query(ctx, `
+		SELECT to_char(date, 'YYYY-MM-DD'), reason
+		FROM worker_blackout_dates
+		WHERE worker_id = $1 AND date >= CURRENT_DATE - 1
+		ORDER BY date
+	`, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blackout dates: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var b entity.BlackoutDate
+		if err := rows.Scan(&b.Date, &b.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan blackout date: %w", err)
+		}
+		av.Blackouts = append(av.Blackouts, b)
+	}
+
+	return av, nil
+}
+
+// SetAvailability replaces the weekly windows and blackout dates of a worker
+func (r *PostgresWorkerProfileRepo) SetAvailability(ctx context.Context, av *entity.WorkerAvailability) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM worker_availability_windows WHERE worker_id = $1", av.WorkerID); err != nil {
+		return fmt.Errorf("failed to clear availability windows: %w", err)
+	}
+	for _, w := range av.Windows {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO worker_availability_windows (worker_id, weekday, start_minute, end_minute)
+			VALUES ($1, $2, $3, $4)
+		`, av.WorkerID, w.Weekday, w.StartMinute, w.EndMinute)
+		if err != nil {
+			return fmt.Errorf("failed to insert availability window: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM worker_blackout_dates WHERE worker_id = $1", av.WorkerID); err != nil {
+		return fmt.Errorf("failed to clear blackout dates: %w", err)
+	}
+	for _, b := range av.Blackouts {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO worker_blackout_dates (worker_id, date, reason)
+			VALUES ($1, $2::date, $3)
+			ON CONFLICT (worker_id, date) DO UPDATE SET reason = EXCLUDED.reason
+		`, av.WorkerID, b.Date, b.Reason)
+		if err != nil {
+			return fmt.Errorf("failed to insert blackout date: %w", err)
+		}
+	}
+
+	// The timezone lives with the other preferences
+	_, err = tx.Exec(ctx, `
+		INSERT INTO worker_preferences (worker_id, timezone)
+		VALUES ($1, $2)
+		ON CONFLICT (worker_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = now()
+	`, av.WorkerID, av.Timezone)
+	if err != nil {
+		return fmt.Errorf("failed to save timezone: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"shiftkerja-backend/internal/core/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWorkerStatsRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresWorkerStatsRepo(db *pgxpool.Pool) *PostgresWorkerStatsRepo {
+	return &PostgresWorkerStatsRepo{DB: db}
+}
+
+// GetTrackRecords aggregates the history of several workers at once.
+// A shift counts as completed once an ACCEPTED worker's shift has ended and
+// it wasn't a no-show. No-shows and late withdrawals overturned on appeal
+// don't count. Ratings come from the published aggregate on the user.
+func (r *PostgresWorkerStatsRepo) GetTrackRecords(ctx context.Context, workerIDs []int64) (map[int64]entity.WorkerTrackRecord, error) {
+	records := make(map[int64]entity.WorkerTrackRecord, len(workerIDs))
+	for _, id := range workerIDs {
+		records[id] = entity.WorkerTrackRecord{WorkerID: id}
+	}
+	if len(workerIDs) == 0 {
+		return records, nil
+	}
+
+	query := `
+		SELECT a.worker_id, COUNT(*)
+		FROM applications a
+		JOIN shifts s ON a.shift_id = s.id
+		WHERE a.worker_id = ANY($1)
+			AND a.status = 'ACCEPTED'
+			AND s.ends_at IS NOT NULL AND s.ends_at < now()
+			AND NOT EXISTS (
+				SELECT 1 FROM reliability_events e
+				WHERE e.application_id = a.id AND e.kind = 'NO_SHOW' AND e.appeal_status <> 'OVERTURNED'
+			)
+		GROUP BY a.worker_id
+	`
+	rows, err := r.DB.Query(ctx, query, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query track records: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workerID int64
+		var completed int
+		if err := rows.Scan(&workerID, &completed); err != nil {
+			return nil, fmt.Errorf("failed to scan track record: %w", err)
+		}
+		rec := records[workerID]
+		rec.CompletedShifts = completed
+		records[workerID] = rec
+	}
+	rows.Close()
+
+	eventQuery := `
+		SELECT worker_id,
+			COUNT(*) FILTER (WHERE kind = 'NO_SHOW'),
+			COUNT(*) FILTER (WHERE kind = 'LATE_WITHDRAWAL')
+		FROM reliability_events
+		WHERE worker_id = ANY($1) AND appeal_status <> 'OVERTURNED'
+		GROUP BY worker_id
+	`
+	eventRows, err := r.DB.Query(ctx, eventQuery, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reliability events: %w", err)
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var workerID int64
+		var noShows, late int
+		if err := eventRows.Scan(&workerID, &noShows, &late); err != nil {
+			return nil, fmt.Errorf("failed to scan reliability events: %w", err)
+		}
+		rec := records[workerID]
+		rec.NoShows = noShows
+		rec.LateWithdrawals = late
+		records[workerID] = rec
+	}
+	eventRows.Close()
+
+	ratingRows, err := r.DB.Query(ctx, `SELECT id, rating_avg::float8, rating_count FROM users WHERE id = ANY($1)`, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer ratingRows.Close()
+
+	for ratingRows.Next() {
+		var workerID int64
+		var avg *float64
+		var count int
+		if err := ratingRows.Scan(&workerID, &avg, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		rec := records[workerID]
+		rec.RatingAvg = avg
+		rec.RatingCount = count
+		records[workerID] = rec
+	}
+
+	return records, nil
+}
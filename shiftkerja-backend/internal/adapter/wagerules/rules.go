@@ -0,0 +1,304 @@
+// Package wagerules loads minimum wage regions and public holidays from a
+// local dataset: a GeoJSON file of region polygons and a JSON holiday
+// calendar. The bundled files (see data/) can be replaced with a directory of
+// the same two files, e.g. when the new year's UMP is announced.
+//
+// The holiday calendar lists dates, not rules, so it has to be refreshed every
+// year once the government publishes the next year's holidays (SKB). Dates in
+// a year it doesn't cover get no holiday multiplier; see MissingYears.
+package wagerules
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+//go:embed data/regions.geojson data/holidays.json
+var bundled embed.FS
+
+const (
+	regionsFile  = "regions.geojson"
+	holidaysFile = "holidays.json"
+)
+
+// hoursPerMonth turns a monthly minimum wage into an hourly one, as in
+// PP 36/2021: hourly wage = monthly wage / 126
+const hoursPerMonth = 126
+
+type region struct {
+	entity.WageRegion
+	polygons [][][2]float64 // Outer rings as [lng, lat]; holes are ignored
+	area     float64
+}
+
+// Rules is an in-memory port.WageRules
+type Rules struct {
+	regions  []region
+	byCode   map[string]*region
+	holidays map[string][]entity.PublicHoliday
+	years    map[int]bool // Years the holiday calendar covers
+}
+
+// Load reads regions.geojson and holidays.json from dir, or the bundled
+// dataset if dir is empty. A multiplier > 0 overrides the calendar's default.
+func Load(dir string, defaultMultiplier float64) (*Rules, error) {
+	var files fs.FS
+	if dir == "" {
+		sub, err := fs.Sub(bundled, "data")
+		if err != nil {
+			return nil, err
+		}
+		files = sub
+	} else {
+		files = os.DirFS(dir)
+	}
+
+	r := &Rules{byCode: map[string]*region{}, holidays: map[string][]entity.PublicHoliday{}, years: map[int]bool{}}
+	if err := r.loadRegions(files); err != nil {
+		return nil, fmt.Errorf("%s: %w", regionsFile, err)
+	}
+	if err := r.loadHolidays(files, defaultMultiplier); err != nil {
+		return nil, fmt.Errorf("%s: %w", holidaysFile, err)
+	}
+	return r, nil
+}
+
+type featureCollection struct {
+	Features []struct {
+		Properties struct {
+			Code           string `json:"code"`
+			Name           string `json:"name"`
+			MinMonthlyWage string `json:"min_monthly_wage"`
+			MinHourlyWage  string `json:"min_hourly_wage"` // Takes precedence over the monthly wage
+			Currency       string `json:"currency"`
+			Timezone       string `json:"timezone"`
+		} `json:"properties"`
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+func (r *Rules) loadRegions(files fs.FS) error {
+	raw, err := fs.ReadFile(files, regionsFile)
+	if err != nil {
+		return err
+	}
+	var fc featureCollection
+	if err := json.Unmarshal(raw, &fc); err != nil {
+		return err
+	}
+
+	r.regions = make([]region, 0, len(fc.Features))
+	for _, f := range fc.Features {
+		p := f.Properties
+		if p.Code == "" {
+			return fmt.Errorf("region without a code")
+		}
+		if p.Timezone == "" {
+			p.Timezone = "Asia/Jakarta"
+		}
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("region %s: %w", p.Code, err)
+		}
+
+		var hourly entity.Money
+		if p.MinHourlyWage != "" {
+			hourly, err = entity.ParseMoney(p.MinHourlyWage, p.Currency)
+		} else {
+			var monthly entity.Money
+			monthly, err = entity.ParseMoney(p.MinMonthlyWage, p.Currency)
+			hourly = monthly.MulDiv(1, hoursPerMonth)
+		}
+		if err != nil {
+			return fmt.Errorf("region %s: %w", p.Code, err)
+		}
+
+		polygons, err := outerRings(f.Geometry.Type, f.Geometry.Coordinates)
+		if err != nil {
+			return fmt.Errorf("region %s: %w", p.Code, err)
+		}
+		reg := region{
+			WageRegion: entity.WageRegion{Code: p.Code, Name: p.Name, MinHourlyRate: hourly, Timezone: p.Timezone},
+			polygons:   polygons,
+		}
+		for _, ring := range polygons {
+			reg.area += ringArea(ring)
+		}
+		r.regions = append(r.regions, reg)
+	}
+	for i := range r.regions {
+		r.byCode[r.regions[i].Code] = &r.regions[i]
+	}
+	return nil
+}
+
+// outerRings extracts the outer ring of each polygon of a Polygon or MultiPolygon
+func outerRings(kind string, coordinates json.RawMessage) ([][][2]float64, error) {
+	switch kind {
+	case "Polygon":
+		var rings [][][2]float64
+		if err := json.Unmarshal(coordinates, &rings); err != nil || len(rings) == 0 {
+			return nil, fmt.Errorf("invalid Polygon coordinates")
+		}
+		return rings[:1], nil
+	case "MultiPolygon":
+		var polygons [][][][2]float64
+		if err := json.Unmarshal(coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates")
+		}
+		var outer [][][2]float64
+		for _, rings := range polygons {
+			if len(rings) > 0 {
+				outer = append(outer, rings[0])
+			}
+		}
+		return outer, nil
+	}
+	return nil, fmt.Errorf("unsupported geometry %q", kind)
+}
+
+type holidayCalendar struct {
+	DefaultMultiplier float64                `json:"default_multiplier"`
+	Holidays          []entity.PublicHoliday `json:"holidays"`
+}
+
+func (r *Rules) loadHolidays(files fs.FS, defaultMultiplier float64) error {
+	raw, err := fs.ReadFile(files, holidaysFile)
+	if err != nil {
+		return err
+	}
+	var cal holidayCalendar
+	if err := json.Unmarshal(raw, &cal); err != nil {
+		return err
+	}
+	if defaultMultiplier > 0 {
+		cal.DefaultMultiplier = defaultMultiplier
+	}
+	if cal.DefaultMultiplier < 1 {
+		return fmt.Errorf("default_multiplier must be at least 1")
+	}
+
+	for _, h := range cal.Holidays {
+		date, err := time.Parse("2006-01-02", h.Date)
+		if err != nil {
+			return fmt.Errorf("holiday %q: date must be YYYY-MM-DD", h.Name)
+		}
+		r.years[date.Year()] = true
+		if h.Multiplier == 0 {
+			h.Multiplier = cal.DefaultMultiplier
+		}
+		if h.Multiplier < 1 {
+			return fmt.Errorf("holiday %q: multiplier must be at least 1", h.Name)
+		}
+		r.holidays[h.Date] = append(r.holidays[h.Date], h)
+	}
+	return nil
+}
+
+// MissingYears returns the years the holiday calendar has no holidays for
+func (r *Rules) MissingYears(years ...int) []int {
+	var missing []int
+	for _, year := range years {
+		if !r.years[year] {
+			missing = append(missing, year)
+		}
+	}
+	return missing
+}
+
+// RegionAt returns the smallest region containing the point, so a city with
+// its own UMK wins over the province around it
+func (r *Rules) RegionAt(lat, lng float64) *entity.WageRegion {
+	var best *region
+	for i := range r.regions {
+		reg := &r.regions[i]
+		if (best == nil || reg.area < best.area) && reg.contains(lat, lng) {
+			best = reg
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	found := best.WageRegion
+	return &found
+}
+
+// RegionByCode returns a region by its code (nil if unknown)
+func (r *Rules) RegionByCode(code string) *entity.WageRegion {
+	reg, ok := r.byCode[code]
+	if !ok {
+		return nil
+	}
+	found := reg.WageRegion
+	return &found
+}
+
+// Holiday returns the holiday with the highest multiplier on a date in a region
+func (r *Rules) Holiday(date, regionCode string) *entity.PublicHoliday {
+	var best *entity.PublicHoliday
+	for i, h := range r.holidays[date] {
+		if !appliesTo(h, regionCode) {
+			continue
+		}
+		if best == nil || h.Multiplier > best.Multiplier {
+			best = &r.holidays[date][i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	found := *best
+	return &found
+}
+
+func appliesTo(h entity.PublicHoliday, regionCode string) bool {
+	if len(h.Regions) == 0 {
+		return true
+	}
+	for _, code := range h.Regions {
+		if code == regionCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (reg *region) contains(lat, lng float64) bool {
+	for _, ring := range reg.polygons {
+		if ringContains(ring, lat, lng) {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains is the even-odd ray casting test
+func ringContains(ring [][2]float64, lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ringArea is the planar area in square degrees, only used to compare regions
+func ringArea(ring [][2]float64) float64 {
+	var sum float64
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		sum += ring[j][0]*ring[i][1] - ring[i][0]*ring[j][1]
+	}
+	return math.Abs(sum) / 2
+}
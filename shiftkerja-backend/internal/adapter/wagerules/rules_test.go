@@ -0,0 +1,113 @@
+package wagerules
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+func TestRingContains(t *testing.T) {
+	// An L shape as [lng, lat]: the square (0,0)-(2,2) without its top right quarter
+	ring := [][2]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}, {0, 0}}
+	tests := []struct {
+		name     string
+		lat, lng float64
+		want     bool
+	}{
+		{"bottom left", 0.5, 0.5, true},
+		{"bottom right", 0.5, 1.5, true},
+		{"top left", 1.5, 0.5, true},
+		{"cut out corner", 1.5, 1.5, false},
+		{"left of the shape", 0.5, -0.5, false},
+		{"above the shape", 2.5, 0.5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ringContains(ring, tt.lat, tt.lng); got != tt.want {
+				t.Errorf("ringContains(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundledRegionAt(t *testing.T) {
+	rules, err := Load("", 0)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		lat, lng float64
+		want     string
+	}{
+		{"Jakarta wins over the province around it", -6.2, 106.85, "ID-JK"},
+		{"Bandung", -6.91, 107.61, "ID-JB"},
+		{"Denpasar", -8.65, 115.22, "ID-BA"},
+		{"Yogyakarta", -7.8, 110.37, "ID-YO"},
+		{"Java Sea", -5.0, 110.0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if region := rules.RegionAt(tt.lat, tt.lng); region != nil {
+				got = region.Code
+			}
+			if got != tt.want {
+				t.Errorf("RegionAt(%v, %v) = %q, want %q", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+
+	// Monthly UMP / 126, rounded to the sen
+	if got, want := rules.RegionByCode("ID-JK").MinHourlyRate, entity.NewMoney(4283144, "IDR"); got != want {
+		t.Errorf("Jakarta hourly minimum = %v, want %v", got, want)
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	dir := t.TempDir()
+	regions, err := bundled.ReadFile("data/" + regionsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendar := `{"default_multiplier": 2, "holidays": [
+		{"date": "2030-01-01", "name": "New Year"},
+		{"date": "2030-03-05", "name": "Nyepi", "multiplier": 3, "regions": ["ID-BA"]},
+		{"date": "2030-03-05", "name": "Regional day"}
+	]}`
+	for name, content := range map[string][]byte{regionsFile: regions, holidaysFile: []byte(calendar)} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules, err := Load(dir, 0)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		date, region   string
+		wantMultiplier float64 // 0 for no holiday
+	}{
+		{"2030-01-01", "ID-JK", 2},
+		{"2030-03-05", "ID-BA", 3},
+		{"2030-03-05", "ID-JK", 2},
+		{"2030-01-02", "ID-JK", 0},
+	}
+	for _, tt := range tests {
+		var got float64
+		if h := rules.Holiday(tt.date, tt.region); h != nil {
+			got = h.Multiplier
+		}
+		if got != tt.wantMultiplier {
+			t.Errorf("Holiday(%s, %s) multiplier = %v, want %v", tt.date, tt.region, got, tt.wantMultiplier)
+		}
+	}
+
+	if got := rules.MissingYears(2029, 2030, 2031); !slices.Equal(got, []int{2029, 2031}) {
+		t.Errorf("MissingYears() = %v, want [2029 2031]", got)
+	}
+}
@@ -0,0 +1,12 @@
+package dto
+
+// ClockRequest represents the request body for clocking in or out of a shift
+type ClockRequest struct {
+	ShiftID int64   `json:"shift_id" validate:"required,gt=0"`
+	Lat     float64 `json:"lat" validate:"required,min=-90,max=90"` // Worker's current position
+	Lng     float64 `json:"lng" validate:"required,min=-180,max=180"`
+	QRToken string  `json:"qr_token,omitempty"` // Scanned from the on-site QR code (clock-in only)
+
+	// Unpaid break taken during the shift (clock-out only)
+	BreakMinutes int `json:"break_minutes,omitempty" validate:"min=0"`
+}
@@ -4,7 +4,7 @@ package dto
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
-	Role     string `json:"role" validate:"required,oneof=worker business admin"`
+	Role     string `json:"role" validate:"required,oneof=worker business"`
 	FullName string `json:"full_name" validate:"required,min=2,max=100"`
 }
 
@@ -0,0 +1,7 @@
+package dto
+
+// BlockRequest blocks (or unblocks) a user of the other side
+type BlockRequest struct {
+	UserID int64  `json:"user_id" validate:"required,gt=0"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
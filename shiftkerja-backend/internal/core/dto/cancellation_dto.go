@@ -0,0 +1,13 @@
+package dto
+
+// CancelShiftRequest represents a business calling off one of its shifts
+type CancelShiftRequest struct {
+	ShiftID int64  `json:"shift_id" validate:"required,gt=0"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+// CancelApplicationRequest represents a worker giving up an accepted spot
+type CancelApplicationRequest struct {
+	ApplicationID int64  `json:"application_id" validate:"required,gt=0"`
+	Reason        string `json:"reason" validate:"required"`
+}
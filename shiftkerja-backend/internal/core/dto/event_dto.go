@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// EventPositionRequest is one position of an event. It is posted like a
+// shift, at the event's place and time.
+type EventPositionRequest struct {
+	Title       string       `json:"title" validate:"required,min=3,max=100"`
+	Description string       `json:"description" validate:"max=500"`
+	PayRate     entity.Money `json:"pay_rate" validate:"required"` // {"amount": "25000", "currency": "IDR"}
+	PayUnit     string       `json:"pay_unit,omitempty"`           // HOURLY (default) or PER_SHIFT
+	CategoryID  *int64       `json:"category_id,omitempty"`
+	SkillIDs    []int64      `json:"skill_ids,omitempty"`
+	Slots       int          `json:"slots,omitempty" validate:"omitempty,min=1,max=100"` // Workers needed, 1 by default
+
+	Visibility        entity.ShiftVisibility `json:"visibility,omitempty"`          // PUBLIC (default), POOL or INVITE
+	ReleaseAfterHours float64                `json:"release_after_hours,omitempty"` // Make a restricted position PUBLIC if still unfilled
+
+	ScreeningQuestions []entity.ScreeningQuestion `json:"screening_questions,omitempty"`
+}
+
+// CreateEventRequest represents the request body for creating an event with
+// its positions
+type CreateEventRequest struct {
+	Title       string                 `json:"title" validate:"required,min=3,max=100"`
+	Description string                 `json:"description" validate:"max=1000"`
+	Lat         float64                `json:"lat" validate:"required_without=LocationID,min=-90,max=90"`
+	Lng         float64                `json:"lng" validate:"required_without=LocationID,min=-180,max=180"`
+	LocationID  *int64                 `json:"location_id,omitempty"` // The saved work site; required for businesses, admins may give lat/lng
+	StartsAt    *time.Time             `json:"starts_at,omitempty"`
+	EndsAt      *time.Time             `json:"ends_at,omitempty"`
+	Positions   []EventPositionRequest `json:"positions" validate:"required,min=1,max=20"`
+}
+
+// AddEventPositionRequest represents the request body for adding a position
+// to an event
+type AddEventPositionRequest struct {
+	EventID int64 `json:"event_id" validate:"required"`
+	EventPositionRequest
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// CreateAdjustmentRequest represents an admin correction to a worker's balance
+type CreateAdjustmentRequest struct {
+	WorkerID    int64        `json:"worker_id" validate:"required,gt=0"`
+	Amount      entity.Money `json:"amount" validate:"required"` // Positive credits the worker, negative debits
+	Description string       `json:"description" validate:"required,max=200"`
+}
+
+// UpdateBankAccountRequest represents the request body for saving payout details
+type UpdateBankAccountRequest struct {
+	BankCode      string `json:"bank_code" validate:"required,len=3"` // e.g. 014 = BCA, 008 = Mandiri
+	AccountNumber string `json:"account_number" validate:"required"`
+	AccountHolder string `json:"account_holder" validate:"required"`
+}
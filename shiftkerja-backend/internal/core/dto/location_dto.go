@@ -0,0 +1,19 @@
+package dto
+
+// LocationRequest represents the request body for saving a work site
+type LocationRequest struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	Address      string  `json:"address" validate:"max=300"`
+	Lat          float64 `json:"lat" validate:"required,min=-90,max=90"`
+	Lng          float64 `json:"lng" validate:"required,min=-180,max=180"`
+	Instructions string  `json:"instructions,omitempty" validate:"max=1000"` // Shown to accepted workers
+	ContactName  string  `json:"contact_name,omitempty" validate:"max=100"`
+	ContactPhone string  `json:"contact_phone,omitempty" validate:"max=30"`
+}
+
+// UpdateLocationRequest represents the request body for editing or moving a
+// work site
+type UpdateLocationRequest struct {
+	ID int64 `json:"id" validate:"required"`
+	LocationRequest
+}
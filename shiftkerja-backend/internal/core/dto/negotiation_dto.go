@@ -0,0 +1,11 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// NegotiateRateRequest is one step of a rate negotiation on an application
+type NegotiateRateRequest struct {
+	ApplicationID int64         `json:"application_id" validate:"required,gt=0"`
+	Action        string        `json:"action" validate:"required,oneof=ACCEPT DECLINE COUNTER"`
+	Rate          *entity.Money `json:"rate,omitempty"` // COUNTER only
+	Note          string        `json:"note,omitempty" validate:"max=500"`
+}
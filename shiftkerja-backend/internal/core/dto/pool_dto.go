@@ -0,0 +1,22 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// PoolMemberRequest adds a worker to (or removes one from) the business's pool
+type PoolMemberRequest struct {
+	WorkerID int64  `json:"worker_id" validate:"required,gt=0"`
+	Note     string `json:"note,omitempty" validate:"max=200"`
+}
+
+// ShiftVisibilityRequest changes who may see a shift
+type ShiftVisibilityRequest struct {
+	ShiftID           int64                  `json:"shift_id" validate:"required,gt=0"`
+	Visibility        entity.ShiftVisibility `json:"visibility" validate:"required,oneof=PUBLIC POOL INVITE"`
+	ReleaseAfterHours float64                `json:"release_after_hours,omitempty"` // 0 = never released to the public
+}
+
+// InviteWorkersRequest invites workers to a shift
+type InviteWorkersRequest struct {
+	ShiftID   int64   `json:"shift_id" validate:"required,gt=0"`
+	WorkerIDs []int64 `json:"worker_ids" validate:"required,min=1"`
+}
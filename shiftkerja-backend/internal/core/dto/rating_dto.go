@@ -0,0 +1,9 @@
+package dto
+
+// CreateRatingRequest represents one side's rating of a completed shift
+type CreateRatingRequest struct {
+	ApplicationID int64    `json:"application_id" validate:"required,gt=0"`
+	Stars         int      `json:"stars" validate:"required,min=1,max=5"`
+	Tags          []string `json:"tags,omitempty"` // See GET /ratings/tags
+	Comment       string   `json:"comment,omitempty" validate:"max=1000"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// CreateAppealRequest represents a worker contesting a no-show or late withdrawal
+type CreateAppealRequest struct {
+	EventID int64  `json:"event_id" validate:"required,gt=0"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+// DecideAppealRequest represents an admin's decision on an appeal
+type DecideAppealRequest struct {
+	EventID  int64  `json:"event_id" validate:"required,gt=0"`
+	Decision string `json:"decision" validate:"required,oneof=UPHOLD OVERTURN"`
+	Note     string `json:"note,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// SetScreeningQuestionsRequest replaces a shift's screening questions
+type SetScreeningQuestionsRequest struct {
+	ShiftID   int64                      `json:"shift_id" validate:"required,gt=0"`
+	Questions []entity.ScreeningQuestion `json:"questions"` // Empty removes them
+}
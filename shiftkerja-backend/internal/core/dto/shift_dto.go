@@ -1,60 +1,105 @@
 package dto
 
+import (
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
 // CreateShiftRequest represents the request body for creating a shift
 type CreateShiftRequest struct {
-	Title       string  `json:"title" validate:"required,min=3,max=100"`
-	Description string  `json:"description" validate:"max=500"`
-	PayRate     float64 `json:"pay_rate" validate:"required,gt=0"`
-	Lat         float64 `json:"lat" validate:"required,min=-90,max=90"`
-	Lng         float64 `json:"lng" validate:"required,min=-180,max=180"`
+	Title       string       `json:"title" validate:"required,min=3,max=100"`
+	Description string       `json:"description" validate:"max=500"`
+	PayRate     entity.Money `json:"pay_rate" validate:"required"` // {"amount": "25000", "currency": "IDR"}
+	PayUnit     string       `json:"pay_unit,omitempty"`           // HOURLY (default) or PER_SHIFT
+	Lat         float64      `json:"lat" validate:"required_without=LocationID,min=-90,max=90"`
+	Lng         float64      `json:"lng" validate:"required_without=LocationID,min=-180,max=180"`
+	LocationID  *int64       `json:"location_id,omitempty"` // The saved work site; required for businesses, admins may give lat/lng
+	CategoryID  *int64       `json:"category_id,omitempty"`
+	SkillIDs    []int64      `json:"skill_ids,omitempty"`
+	StartsAt    *time.Time   `json:"starts_at,omitempty"`
+	EndsAt      *time.Time   `json:"ends_at,omitempty"`
+	Slots       int          `json:"slots,omitempty" validate:"omitempty,min=1,max=100"` // Workers needed, 1 by default
+
+	Visibility        entity.ShiftVisibility `json:"visibility,omitempty"`          // PUBLIC (default), POOL or INVITE
+	ReleaseAfterHours float64                `json:"release_after_hours,omitempty"` // Make a restricted shift PUBLIC if still unfilled
+
+	ScreeningQuestions []entity.ScreeningQuestion `json:"screening_questions,omitempty"` // {kind, prompt, options, knockout_answers, required}
 }
 
 // UpdateShiftRequest represents the request body for updating a shift
 type UpdateShiftRequest struct {
-	ID          int64   `json:"id" validate:"required"`
-	Title       string  `json:"title" validate:"required,min=3,max=100"`
-	Description string  `json:"description" validate:"max=500"`
-	PayRate     float64 `json:"pay_rate" validate:"required,gt=0"`
-	Lat         float64 `json:"lat" validate:"required,min=-90,max=90"`
-	Lng         float64 `json:"lng" validate:"required,min=-180,max=180"`
-	Status      string  `json:"status" validate:"required,oneof=OPEN FILLED"`
+	ID          int64              `json:"id" validate:"required"`
+	Title       string             `json:"title" validate:"required,min=3,max=100"`
+	Description string             `json:"description" validate:"max=500"`
+	PayRate     entity.Money       `json:"pay_rate" validate:"required"`                            // {"amount": "25000", "currency": "IDR"}
+	PayUnit     string             `json:"pay_unit,omitempty"`                                      // HOURLY (default) or PER_SHIFT
+	LocationID  *int64             `json:"location_id,omitempty"`                                   // Move to another saved work site; leave out to keep the current one
+	Status      entity.ShiftStatus `json:"status,omitempty" validate:"omitempty,oneof=OPEN FILLED"` // Leave out to keep the current status
+	CategoryID  *int64             `json:"category_id,omitempty"`
+	SkillIDs    []int64            `json:"skill_ids,omitempty"`
+	StartsAt    *time.Time         `json:"starts_at,omitempty"`
+	EndsAt      *time.Time         `json:"ends_at,omitempty"`
+	Slots       int                `json:"slots,omitempty" validate:"omitempty,min=1,max=100"` // Leave out to keep the current slots
 }
 
 // ApplyShiftRequest represents the request body for applying to a shift
 type ApplyShiftRequest struct {
-	ShiftID int64 `json:"shift_id" validate:"required,gt=0"`
+	ShiftID   int64                    `json:"shift_id" validate:"required,gt=0"`
+	CoverNote string                   `json:"cover_note,omitempty" validate:"max=1000"`
+	Answers   []entity.ScreeningAnswer `json:"answers,omitempty"` // {question_id, answer} per screening question
+
+	ProposedRate *entity.Money `json:"proposed_rate,omitempty"` // Ask for another rate than the shift's (same currency and pay unit)
 }
 
 // UpdateApplicationStatusRequest represents the request body for updating application status
 type UpdateApplicationStatusRequest struct {
-	ApplicationID int64  `json:"application_id" validate:"required,gt=0"`
-	Status        string `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+	ApplicationID int64                    `json:"application_id" validate:"required,gt=0"`
+	Status        entity.ApplicationStatus `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+	ReasonCode    string                   `json:"reason_code,omitempty"` // Rejections only, see entity.ApplicationReasons
+	ReasonNote    string                   `json:"reason_note,omitempty" validate:"max=500"`
+}
+
+// BatchApplicationStatusRequest represents the request body for accepting or
+// rejecting several applications at once
+type BatchApplicationStatusRequest struct {
+	ApplicationIDs []int64                  `json:"application_ids" validate:"required,min=1,max=100"`
+	Status         entity.ApplicationStatus `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+	ReasonCode     string                   `json:"reason_code,omitempty"` // Rejections only, applies to every application
+	ReasonNote     string                   `json:"reason_note,omitempty" validate:"max=500"`
+}
+
+// BatchErrorResponse is an error response that says what went wrong with each
+// item of a batch
+type BatchErrorResponse struct {
+	ErrorResponse
+	Results []entity.BatchItemResult `json:"results"`
 }
 
 // ShiftResponse represents a shift in API responses
 type ShiftResponse struct {
-	ID          int64   `json:"id"`
-	OwnerID     int64   `json:"owner_id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	PayRate     float64 `json:"pay_rate"`
-	Lat         float64 `json:"lat"`
-	Lng         float64 `json:"lng"`
-	Status      string  `json:"status"`
-	CreatedAt   string  `json:"created_at"`
+	ID          int64              `json:"id"`
+	OwnerID     int64              `json:"owner_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	PayRate     entity.Money       `json:"pay_rate"`
+	Lat         float64            `json:"lat"`
+	Lng         float64            `json:"lng"`
+	Status      entity.ShiftStatus `json:"status"`
+	CreatedAt   string             `json:"created_at"`
 }
 
 // ApplicationResponse represents an application in API responses
 type ApplicationResponse struct {
-	ID           int64   `json:"id"`
-	ShiftID      int64   `json:"shift_id"`
-	WorkerID     int64   `json:"worker_id"`
-	Status       string  `json:"status"`
-	CreatedAt    string  `json:"created_at"`
-	ShiftTitle   string  `json:"shift_title,omitempty"`
-	ShiftPayRate float64 `json:"shift_pay_rate,omitempty"`
-	WorkerName   string  `json:"worker_name,omitempty"`
-	WorkerEmail  string  `json:"worker_email,omitempty"`
+	ID           int64                    `json:"id"`
+	ShiftID      int64                    `json:"shift_id"`
+	WorkerID     int64                    `json:"worker_id"`
+	Status       entity.ApplicationStatus `json:"status"`
+	CreatedAt    string                   `json:"created_at"`
+	ShiftTitle   string                   `json:"shift_title,omitempty"`
+	ShiftPayRate *entity.Money            `json:"shift_pay_rate,omitempty"`
+	WorkerName   string                   `json:"worker_name,omitempty"`
+	WorkerEmail  string                   `json:"worker_email,omitempty"`
 }
 
 // ErrorResponse represents an error in API responses
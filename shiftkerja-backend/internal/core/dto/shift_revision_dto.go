@@ -0,0 +1,7 @@
+package dto
+
+// ReconfirmApplicationRequest represents a worker's answer to a material change of a shift
+type ReconfirmApplicationRequest struct {
+	ApplicationID int64 `json:"application_id" validate:"required,gt=0"`
+	Confirm       bool  `json:"confirm"` // false declines and withdraws the application
+}
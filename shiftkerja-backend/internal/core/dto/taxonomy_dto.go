@@ -0,0 +1,25 @@
+package dto
+
+// CreateCategoryRequest represents the request body for creating a job category
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+// CreateSkillRequest represents the request body for creating a skill
+type CreateSkillRequest struct {
+	Name       string `json:"name" validate:"required,min=2,max=100"`
+	CategoryID *int64 `json:"category_id,omitempty"`
+}
+
+// WorkerSkillInput is one entry of a worker's skill profile
+type WorkerSkillInput struct {
+	SkillID         int64  `json:"skill_id" validate:"required,gt=0"`
+	Level           string `json:"level" validate:"required,oneof=BEGINNER INTERMEDIATE EXPERT"`
+	YearsExperience int    `json:"years_experience" validate:"min=0"`
+}
+
+// UpdateWorkerSkillsRequest replaces the full skill profile of the calling worker
+type UpdateWorkerSkillsRequest struct {
+	Skills []WorkerSkillInput `json:"skills"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// ShiftTemplateRequest represents the request body for creating or updating a shift template
+type ShiftTemplateRequest struct {
+	ID              int64        `json:"id,omitempty"` // Required on update
+	Name            string       `json:"name" validate:"required,max=100"`
+	Title           string       `json:"title" validate:"required,min=3,max=100"`
+	Description     string       `json:"description" validate:"max=500"`
+	PayRate         entity.Money `json:"pay_rate" validate:"required"`
+	PayUnit         string       `json:"pay_unit,omitempty"`              // HOURLY (default) or PER_SHIFT
+	LocationID      *int64       `json:"location_id" validate:"required"` // The saved work site the shifts are posted at
+	CategoryID      *int64       `json:"category_id,omitempty"`
+	SkillIDs        []int64      `json:"skill_ids,omitempty"`
+	StartMinute     int          `json:"start_minute" validate:"min=0,max=1439"`
+	DurationMinutes int          `json:"duration_minutes" validate:"required,min=1,max=1440"`
+	Timezone        string       `json:"timezone,omitempty"`
+
+	Visibility        entity.ShiftVisibility `json:"visibility,omitempty"`          // PUBLIC (default) or POOL
+	ReleaseAfterHours float64                `json:"release_after_hours,omitempty"` // Make a POOL occurrence PUBLIC if still unfilled
+	Slots             int                    `json:"slots,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// CreateSeriesRequest represents the request body for repeating a template
+type CreateSeriesRequest struct {
+	TemplateID int64  `json:"template_id" validate:"required,gt=0"`
+	RRule      string `json:"rrule" validate:"required"`     // e.g. FREQ=WEEKLY;BYDAY=SA,SU;COUNT=8
+	StartsOn   string `json:"starts_on" validate:"required"` // YYYY-MM-DD
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// ReviewTimesheetRequest represents a business's decision on a timesheet
+type ReviewTimesheetRequest struct {
+	TimesheetID  int64      `json:"timesheet_id" validate:"required,gt=0"`
+	Action       string     `json:"action" validate:"required,oneof=APPROVE EDIT DISPUTE"`
+	StartedAt    *time.Time `json:"started_at,omitempty"` // EDIT only
+	EndedAt      *time.Time `json:"ended_at,omitempty"`   // EDIT only
+	BreakMinutes *int       `json:"break_minutes,omitempty"`
+	Reason       string     `json:"reason,omitempty"` // Required for EDIT and DISPUTE
+}
+
+// RespondTimesheetRequest represents a worker's answer to an edited timesheet
+type RespondTimesheetRequest struct {
+	TimesheetID int64  `json:"timesheet_id" validate:"required,gt=0"`
+	Action      string `json:"action" validate:"required,oneof=ACCEPT CONTEST"`
+	Reason      string `json:"reason,omitempty"` // Required for CONTEST
+}
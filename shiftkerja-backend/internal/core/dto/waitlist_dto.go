@@ -0,0 +1,14 @@
+package dto
+
+// StandbyRequest sets a shift's standby list, best ranked first
+type StandbyRequest struct {
+	ShiftID        int64   `json:"shift_id" validate:"required,gt=0"`
+	ApplicationIDs []int64 `json:"application_ids"`
+	AutoRank       bool    `json:"auto_rank"` // Rank all pending and standby applicants by score instead
+}
+
+// OfferResponseRequest represents a standby worker's answer to an offered spot
+type OfferResponseRequest struct {
+	ApplicationID int64 `json:"application_id" validate:"required,gt=0"`
+	Accept        bool  `json:"accept"` // false turns the spot down
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "shiftkerja-backend/internal/core/entity"
+
+// UpdatePreferencesRequest represents the request body for saving worker preferences
+type UpdatePreferencesRequest struct {
+	MinPayRate    entity.Money `json:"min_pay_rate"` // Per hour
+	HomeLat       *float64     `json:"home_lat,omitempty" validate:"omitempty,min=-90,max=90"`
+	HomeLng       *float64     `json:"home_lng,omitempty" validate:"omitempty,min=-180,max=180"`
+	MaxDistanceKm float64      `json:"max_distance_km" validate:"omitempty,gt=0,max=100"`
+	Timezone      string       `json:"timezone,omitempty"`
+}
+
+// AvailabilityWindowInput is one recurring weekly window
+type AvailabilityWindowInput struct {
+	Weekday     int `json:"weekday" validate:"min=0,max=6"`
+	StartMinute int `json:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int `json:"end_minute" validate:"min=1,max=1440"`
+}
+
+// BlackoutDateInput is a day on which the worker is unavailable
+type BlackoutDateInput struct {
+	Date   string `json:"date" validate:"required"` // YYYY-MM-DD
+	Reason string `json:"reason" validate:"max=200"`
+}
+
+// UpdateAvailabilityRequest replaces the full availability calendar of the calling worker
+type UpdateAvailabilityRequest struct {
+	Timezone  string                    `json:"timezone"`
+	Windows   []AvailabilityWindowInput `json:"windows"`
+	Blackouts []BlackoutDateInput       `json:"blackouts"`
+}
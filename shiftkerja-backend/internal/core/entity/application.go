@@ -2,16 +2,105 @@ package entity
 
 import "time"
 
+// ApplicationStatus is where an application is in its lifecycle (see LifecycleService)
+type ApplicationStatus string
+
+const (
+	ApplicationPending   ApplicationStatus = "PENDING"   // Waiting for the business
+	ApplicationStandby   ApplicationStatus = "STANDBY"   // On the shift's ranked waitlist
+	ApplicationOffered   ApplicationStatus = "OFFERED"   // A freed-up spot is offered until OfferExpiresAt
+	ApplicationAccepted  ApplicationStatus = "ACCEPTED"  // The worker has the spot
+	ApplicationRejected  ApplicationStatus = "REJECTED"  // Turned down by the business, final
+	ApplicationCancelled ApplicationStatus = "CANCELLED" // Spot or shift cancelled, final
+	ApplicationWithdrawn ApplicationStatus = "WITHDRAWN" // Withdrawn by the worker before being accepted, final
+)
+
+// ReasonScreeningKnockout is recorded on applications rejected by a knockout
+// answer to a screening question
+const ReasonScreeningKnockout = "SCREENING_KNOCKOUT"
+
+// ApplicationReasons lists the reason codes a rejection (given by the
+// business) or a withdrawal (given by the worker) may carry
+var ApplicationReasons = map[ApplicationStatus][]string{
+	ApplicationRejected: {
+		"SKILLS_MISMATCH", "NOT_ENOUGH_EXPERIENCE", "SCHEDULE_MISMATCH", "POSITION_FILLED",
+		"LOW_RELIABILITY", "TOO_FAR", ReasonScreeningKnockout, "OTHER",
+	},
+	ApplicationWithdrawn: {
+		"FOUND_OTHER_WORK", "SCHEDULE_CHANGED", "PAY_TOO_LOW", "TOO_FAR",
+		"JOB_NOT_AS_DESCRIBED", "BUSINESS_UNRESPONSIVE", "PERSONAL", "OTHER",
+	},
+}
+
+// ReasonCount is how often a reason code was given, for business analytics
+type ReasonCount struct {
+	Status     ApplicationStatus `json:"status"`      // REJECTED or WITHDRAWN
+	ReasonCode string            `json:"reason_code"` // UNSPECIFIED when none was given
+	Count      int               `json:"count"`
+	Share      float64           `json:"share"` // Of all rejections or withdrawals in the period
+}
+
+// ReasonBreakdown sums up why a business's applications were rejected or
+// withdrawn since a given time
+type ReasonBreakdown struct {
+	BusinessID  int64         `json:"business_id"`
+	Since       time.Time     `json:"since"`
+	Rejected    int           `json:"rejected"`
+	Withdrawn   int           `json:"withdrawn"`
+	Rejections  []ReasonCount `json:"rejections"`
+	Withdrawals []ReasonCount `json:"withdrawals"`
+}
+
 type Application struct {
-	ID        int64     `json:"id"`
-	ShiftID   int64     `json:"shift_id"`
-	WorkerID  int64     `json:"worker_id"`
-	Status    string    `json:"status"` // PENDING, ACCEPTED, REJECTED
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64             `json:"id"`
+	ShiftID   int64             `json:"shift_id"`
+	WorkerID  int64             `json:"worker_id"`
+	Status    ApplicationStatus `json:"status"`
+	CreatedAt time.Time         `json:"created_at"`
+
+	// Set while the worker has to re-confirm a material change of the shift
+	ReconfirmBy *time.Time `json:"reconfirm_by,omitempty"`
+
+	// Standby waitlist position (1 = offered first) and open offer deadline
+	StandbyRank    *int       `json:"standby_rank,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+
+	// The applicant's note and screening answers (answers are loaded for the shift owner)
+	CoverNote string            `json:"cover_note,omitempty"`
+	Answers   []ScreeningAnswer `json:"answers,omitempty"`
+
+	// Why it was REJECTED or WITHDRAWN, see ApplicationReasons
+	ReasonCode string     `json:"reason_code,omitempty"`
+	ReasonNote string     `json:"reason_note,omitempty"`
+	DecidedAt  *time.Time `json:"decided_at,omitempty"`
+
+	// A rate asked for by the worker or countered by the business, in the
+	// shift's currency and pay unit (nil = the shift's rate). RateTurn is the
+	// side that has to answer it; once nobody has to it is agreed, and it is
+	// locked when the application is accepted. The thread is in RateOffers.
+	NegotiatedRate *Money      `json:"negotiated_rate,omitempty"`
+	RateTurn       string      `json:"rate_turn,omitempty"`
+	RateOffers     []RateOffer `json:"rate_offers,omitempty"`
 	
 	// Populated via JOIN queries
-	ShiftTitle   string  `json:"shift_title,omitempty"`
-	ShiftPayRate float64 `json:"shift_pay_rate,omitempty"`
-	WorkerName   string  `json:"worker_name,omitempty"`
-	WorkerEmail  string  `json:"worker_email,omitempty"`
+	ShiftTitle        string     `json:"shift_title,omitempty"`
+	ShiftPayRate      *Money     `json:"shift_pay_rate,omitempty"`
+	ShiftPayUnit      string     `json:"shift_pay_unit,omitempty"`
+	ShiftOwnerID      int64      `json:"shift_owner_id,omitempty"`
+	ShiftCategoryID   *int64     `json:"shift_category_id,omitempty"`
+	ShiftStartsAt     *time.Time `json:"shift_starts_at,omitempty"`
+	ShiftEndsAt       *time.Time `json:"shift_ends_at,omitempty"`
+	WorkerName        string     `json:"worker_name,omitempty"`
+	WorkerEmail       string     `json:"worker_email,omitempty"`
+	WorkerRatingAvg   *float64   `json:"worker_rating_avg,omitempty"` // Published ratings only
+	WorkerRatingCount int        `json:"worker_rating_count,omitempty"`
+}
+
+// PayRate is the rate the worker is paid for the shift: the agreed rate if
+// one was negotiated, otherwise the shift's
+func (a Application) PayRate(shift Shift) Money {
+	if a.NegotiatedRate != nil && a.RateTurn == "" {
+		return *a.NegotiatedRate
+	}
+	return shift.PayRate
 }
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Attendance flags raised to the business
+const (
+	FlagOutOfFence    = "OUT_OF_FENCE"
+	FlagLate          = "LATE"
+	FlagEarlyClockOut = "EARLY_CLOCK_OUT"
+)
+
+// Attendance records when and where an accepted worker clocked in and out
+type Attendance struct {
+	ID            int64 `json:"id"`
+	ApplicationID int64 `json:"application_id"`
+	ShiftID       int64 `json:"shift_id"`
+	WorkerID      int64 `json:"worker_id"`
+
+	ClockInAt          time.Time `json:"clock_in_at"`
+	ClockInLat         float64   `json:"clock_in_lat"`
+	ClockInLng         float64   `json:"clock_in_lng"`
+	ClockInDistanceM   float64   `json:"clock_in_distance_m"`
+	ClockInWithinFence bool      `json:"clock_in_within_fence"`
+	LateMinutes        int       `json:"late_minutes"`
+	ClockInQRVerified  bool      `json:"clock_in_qr_verified"` // Scanned the rotating on-site QR code
+
+	// Empty until the worker clocks out
+	ClockOutAt          *time.Time `json:"clock_out_at,omitempty"`
+	ClockOutLat         *float64   `json:"clock_out_lat,omitempty"`
+	ClockOutLng         *float64   `json:"clock_out_lng,omitempty"`
+	ClockOutDistanceM   *float64   `json:"clock_out_distance_m,omitempty"`
+	ClockOutWithinFence *bool      `json:"clock_out_within_fence,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	WorkerName string `json:"worker_name,omitempty"`
+}
+
+// AttendanceEvent is the outcome of a single clock-in or clock-out
+type AttendanceEvent struct {
+	Attendance *Attendance `json:"attendance"`
+	Action     string      `json:"action"` // CLOCK_IN, CLOCK_OUT
+	BusinessID int64       `json:"business_id"`
+	Flags      []string    `json:"flags"`
+	Timesheet  *Timesheet  `json:"timesheet,omitempty"` // Opened on clock-out
+}
+
+// CheckInToken is the rotating code a business displays as a QR on site
+type CheckInToken struct {
+	ShiftID       int64     `json:"shift_id"`
+	Token         string    `json:"token"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	PeriodSeconds int       `json:"period_seconds"`
+}
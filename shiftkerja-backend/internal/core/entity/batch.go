@@ -0,0 +1,19 @@
+package entity
+
+// ApplicationTransition is one application of a batch status change, guarded
+// by the status it was read in
+type ApplicationTransition struct {
+	ApplicationID int64
+	ShiftID       int64
+	From          ApplicationStatus
+}
+
+// BatchItemResult is the outcome of one application in a batch accept/reject
+type BatchItemResult struct {
+	ApplicationID int64             `json:"application_id"`
+	ShiftID       int64             `json:"shift_id,omitempty"`
+	WorkerID      int64             `json:"worker_id,omitempty"`
+	OK            bool              `json:"ok"`
+	Status        ApplicationStatus `json:"status,omitempty"` // The status it has now
+	Error         string            `json:"error,omitempty"`  // Why it would fail (then nothing in the batch is applied)
+}
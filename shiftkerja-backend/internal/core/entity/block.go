@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Block is one user (worker or business) blocking the other side. It applies
+// in both directions.
+type Block struct {
+	ID        int64     `json:"id"`
+	BlockerID int64     `json:"blocker_id"`
+	BlockedID int64     `json:"blocked_id"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	BlockerName string `json:"blocker_name,omitempty"`
+	BlockedName string `json:"blocked_name,omitempty"`
+}
+
+// BlockPattern sums up a user's blocks over a period, for spotting abuse:
+// users blocked by many others, or users blocking many others
+type BlockPattern struct {
+	UserID        int64      `json:"user_id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	TimesBlocked  int        `json:"times_blocked"`
+	BlocksMade    int        `json:"blocks_made"`
+	LastBlockedAt *time.Time `json:"last_blocked_at,omitempty"`
+	LastBlockAt   *time.Time `json:"last_block_at,omitempty"`
+}
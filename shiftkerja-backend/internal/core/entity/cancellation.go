@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// Who cancelled
+const (
+	CancelledByBusiness = "BUSINESS"
+	CancelledByWorker   = "WORKER"
+)
+
+// Cancellation records accepted work being called off by either side
+type Cancellation struct {
+	ID            int64     `json:"id"`
+	ShiftID       int64     `json:"shift_id"`
+	ApplicationID *int64    `json:"application_id,omitempty"`
+	WorkerID      *int64    `json:"worker_id,omitempty"` // Whose spot was cancelled
+	CancelledBy   int64     `json:"cancelled_by"`
+	Side          string    `json:"side"`
+	Reason        string    `json:"reason"`
+	NoticeMinutes *int      `json:"notice_minutes,omitempty"` // nil for unscheduled shifts
+	WithinCutoff  bool      `json:"within_cutoff"`
+	Compensation  Money     `json:"compensation"` // Owed by the business to the worker
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	BusinessID int64  `json:"business_id,omitempty"`
+	ShiftTitle string `json:"shift_title,omitempty"`
+}
+
+// CancellationResult is what a cancellation changed
+type CancellationResult struct {
+	Shift         *Shift         `json:"shift"`
+	Cancellations []Cancellation `json:"cancellations"`
+	Reopened      bool           `json:"reopened"`                    // The shift is OPEN again
+	Waitlist      []int64        `json:"waitlist_notified,omitempty"` // Pending applicants told about the reopened spot
+	Affected      []int64        `json:"affected_workers,omitempty"`  // Workers whose application was cancelled
+}
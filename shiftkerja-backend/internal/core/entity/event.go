@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Event is one posting staffing several positions at the same place and time.
+// Each position is a Shift with EventID set, so it has its own headcount
+// (Slots), pay, required skills and applications.
+type Event struct {
+	ID          int64      `json:"id"`
+	OwnerID     int64      `json:"owner_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Lat         float64    `json:"lat"`
+	Lng         float64    `json:"lng"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	LocationID  *int64     `json:"location_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	Positions []PositionAvailability `json:"positions"`
+}
+
+// PositionAvailability is one position of an event and how many of its slots
+// are still open
+type PositionAvailability struct {
+	ShiftID        int64       `json:"shift_id"`
+	Title          string      `json:"title"`
+	PayRate        Money       `json:"pay_rate"`
+	PayUnit        string      `json:"pay_unit"`
+	Status         ShiftStatus `json:"status"`
+	Slots          int         `json:"slots"`
+	Accepted       int         `json:"accepted"`
+	Open           int         `json:"open"` // Slots left while the position is OPEN
+	RequiredSkills []Skill     `json:"required_skills,omitempty"`
+}
+
+// NewPositionAvailability sums up a position given its accepted workers
+func NewPositionAvailability(shift Shift, accepted int) PositionAvailability {
+	p := PositionAvailability{
+		ShiftID:        shift.ID,
+		Title:          shift.Title,
+		PayRate:        shift.PayRate,
+		PayUnit:        shift.PayUnit,
+		Status:         shift.Status,
+		Slots:          shift.Slots,
+		Accepted:       accepted,
+		RequiredSkills: shift.RequiredSkills,
+	}
+	if shift.Status == ShiftOpen && shift.Slots > accepted {
+		p.Open = shift.Slots - accepted
+	}
+	return p
+}
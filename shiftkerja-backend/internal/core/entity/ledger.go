@@ -0,0 +1,96 @@
+package entity
+
+import "time"
+
+// Ledger account kinds
+const (
+	AccountWorkerPayable       = "WORKER_PAYABLE"       // What the platform owes a worker
+	AccountBusinessReceivable  = "BUSINESS_RECEIVABLE"  // What a business owes the platform
+	AccountPlatformRevenue     = "PLATFORM_REVENUE"     // Fees earned
+	AccountPlatformAdjustments = "PLATFORM_ADJUSTMENTS" // Manual corrections
+	AccountPayoutClearing      = "PAYOUT_CLEARING"      // Money sent to banks
+)
+
+// Ledger transaction kinds
+const (
+	LedgerEarning      = "EARNING"
+	LedgerFee          = "FEE"
+	LedgerAdjustment   = "ADJUSTMENT"
+	LedgerPayout       = "PAYOUT"
+	LedgerCompensation = "COMPENSATION" // Late cancellation by a business
+)
+
+// LedgerTransaction groups balanced entries (debits are positive, credits negative)
+type LedgerTransaction struct {
+	ID             int64         `json:"id"`
+	Kind           string        `json:"kind"`
+	TimesheetID    *int64        `json:"timesheet_id,omitempty"`
+	CancellationID *int64        `json:"cancellation_id,omitempty"`
+	Description    string        `json:"description"`
+	CreatedBy      *int64        `json:"created_by,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Entries        []LedgerEntry `json:"entries,omitempty"`
+}
+
+// LedgerEntry is one side of a transaction on one account
+type LedgerEntry struct {
+	ID            int64     `json:"id"`
+	TransactionID int64     `json:"transaction_id"`
+	AccountKind   string    `json:"account_kind"`
+	OwnerID       int64     `json:"owner_id"` // 0 for platform accounts
+	Amount        Money     `json:"amount"`
+	PayoutBatchID *int64    `json:"payout_batch_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	TransactionKind string `json:"transaction_kind,omitempty"`
+	Description     string `json:"description,omitempty"`
+}
+
+// EarningsSummary answers "how much have I earned this month" for a worker.
+// Amounts are from the worker's point of view (positive = money for them).
+type EarningsSummary struct {
+	WorkerID      int64         `json:"worker_id"`
+	Month         string        `json:"month"` // YYYY-MM
+	Currency      string        `json:"currency"`
+	Gross         Money         `json:"gross"`
+	Fees          Money         `json:"fees"`
+	Adjustments   Money         `json:"adjustments"`
+	Compensation  Money         `json:"compensation"` // For shifts the business cancelled late
+	Net           Money         `json:"net"`
+	PaidOut       Money         `json:"paid_out"`
+	UnpaidBalance Money         `json:"unpaid_balance"` // All time, not just this month
+	Entries       []LedgerEntry `json:"entries"`
+}
+
+// WorkerBankAccount is where a worker's payouts are sent
+type WorkerBankAccount struct {
+	WorkerID      int64     `json:"worker_id"`
+	BankCode      string    `json:"bank_code"`
+	AccountNumber string    `json:"account_number"`
+	AccountHolder string    `json:"account_holder"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PayoutBatch is one bulk-transfer file
+type PayoutBatch struct {
+	ID          int64     `json:"id"`
+	BankFormat  string    `json:"bank_format"`
+	Status      string    `json:"status"`
+	TotalAmount Money     `json:"total_amount"`
+	LineCount   int       `json:"line_count"`
+	FileName    string    `json:"file_name"`
+	FileContent string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PayoutLine is one transfer within a batch
+type PayoutLine struct {
+	WorkerID      int64   `json:"worker_id"`
+	WorkerName    string  `json:"worker_name"`
+	Amount        Money   `json:"amount"`
+	BankCode      string  `json:"bank_code"`
+	AccountNumber string  `json:"account_number"`
+	AccountHolder string  `json:"account_holder"`
+	EntryIDs      []int64 `json:"-"` // Unpaid payable entries settled by this line
+}
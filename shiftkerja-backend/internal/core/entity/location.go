@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Location is a work site saved by a business. Shifts and events posted at it
+// reference it and take its coordinates.
+type Location struct {
+	ID      int64   `json:"id"`
+	OwnerID int64   `json:"owner_id"`
+	Name    string  `json:"name"`
+	Address string  `json:"address"`
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+
+	// On-site details, shown to the business and the workers it accepted
+	Instructions string `json:"instructions,omitempty"` // e.g. "Staff entrance at the back, ask for Wayan"
+	ContactName  string `json:"contact_name,omitempty"`
+	ContactPhone string `json:"contact_phone,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Public returns the location without its on-site details
+func (l Location) Public() Location {
+	l.Instructions, l.ContactName, l.ContactPhone = "", "", ""
+	return l
+}
@@ -0,0 +1,36 @@
+package entity
+
+// MatchSignal explains how one factor contributed to a match score
+type MatchSignal struct {
+	Name         string  `json:"name"`
+	Value        float64 `json:"value"`        // Normalised 0..1
+	Weight       float64 `json:"weight"`       // Configured weight
+	Contribution float64 `json:"contribution"` // Points added to the 0..100 score
+	Explanation  string  `json:"explanation"`
+}
+
+// ShiftRecommendation is an OPEN shift scored for a particular worker
+type ShiftRecommendation struct {
+	Shift      Shift         `json:"shift"`
+	Score      float64       `json:"score"` // 0..100
+	DistanceKm float64       `json:"distance_km"`
+	Signals    []MatchSignal `json:"signals"`
+}
+
+// WorkerTrackRecord summarises a worker's past work for ranking
+type WorkerTrackRecord struct {
+	WorkerID        int64    `json:"worker_id"`
+	CompletedShifts int      `json:"completed_shifts"`
+	NoShows         int      `json:"no_shows"`
+	LateWithdrawals int      `json:"late_withdrawals"`
+	RatingAvg       *float64 `json:"rating_avg,omitempty"`
+	RatingCount     int      `json:"rating_count"`
+}
+
+// RankedApplicant is an application scored for the business that posted the shift
+type RankedApplicant struct {
+	Application
+	Rank    int           `json:"rank"`
+	Score   float64       `json:"score"` // 0..100
+	Signals []MatchSignal `json:"signals"`
+}
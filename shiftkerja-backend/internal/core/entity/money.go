@@ -0,0 +1,219 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used when a request doesn't name one
+const DefaultCurrency = "IDR"
+
+// Pay units
+const (
+	PayHourly   = "HOURLY"
+	PayPerShift = "PER_SHIFT"
+)
+
+// currencyExponents holds the ISO 4217 minor unit exponent of each supported currency
+var currencyExponents = map[string]int{
+	"IDR": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"USD": 2,
+	"JPY": 0,
+}
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Money is an exact amount in the currency's minor units (sen for IDR).
+// In JSON it is {"amount": "150000.00", "minor_units": 15000000, "currency": "IDR"}.
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// NewMoney builds an amount from minor units
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "150000" or "12.50" exactly
+func ParseMoney(amount, currency string) (Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+	exp, ok := currencyExponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" || len(frac) > exp || strings.Trim(whole+frac, "0123456789") != "" {
+		return Money{}, fmt.Errorf("%w: %q (at most %d decimals for %s)", ErrInvalidAmount, amount, exp, currency)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// ValidCurrency reports whether the currency is supported
+func ValidCurrency(currency string) bool {
+	_, ok := currencyExponents[currency]
+	return ok
+}
+
+// Decimal formats the amount in major units, e.g. "150000.00"
+func (m Money) Decimal() string {
+	exp := currencyExponents[m.Currency]
+	if exp == 0 {
+		return strconv.FormatInt(m.Minor, 10)
+	}
+
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	digits := fmt.Sprintf("%0*d", exp+1, minor)
+	cut := len(digits) - exp
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// String formats the amount with its currency, e.g. "IDR 150000.00"
+func (m Money) String() string {
+	return m.Currency + " " + m.Decimal()
+}
+
+// Float returns the amount in major units, for scoring and display only
+func (m Money) Float() float64 {
+	f, _ := new(big.Rat).SetFrac64(m.Minor, pow10(currencyExponents[m.Currency])).Float64()
+	return f
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+func (m Money) Neg() Money       { return Money{Minor: -m.Minor, Currency: m.Currency} }
+
+// Add sums two amounts of the same currency
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Minor: m.Minor + o.Minor, Currency: m.Currency}, nil
+}
+
+// MulDiv returns m × num / den, rounded half away from zero to a whole minor
+// unit. A zero den yields zero in m's currency.
+func (m Money) MulDiv(num, den int64) Money {
+	if den == 0 {
+		return Money{Currency: m.Currency}
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(m.Minor), big.NewInt(1))
+	r.Mul(r, new(big.Rat).SetFrac64(num, den))
+
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Money{Minor: q.Int64(), Currency: m.Currency}
+}
+
+type moneyJSON struct {
+	Amount     string `json:"amount"`
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), MinorUnits: m.Minor, Currency: m.Currency})
+}
+
+// UnmarshalJSON accepts {"amount": "150000", "currency": "IDR"},
+// {"minor_units": 15000000, "currency": "IDR"} or, for older clients and
+// cached payloads, a bare number of major units in the default currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "null" {
+		return nil
+	}
+	if !strings.HasPrefix(trimmed, "{") {
+		parsed, err := ParseMoney(roundLegacy(trimmed), DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	var raw struct {
+		Amount     json.RawMessage `json:"amount"`
+		MinorUnits *int64          `json:"minor_units"`
+		Currency   string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	currency := strings.ToUpper(raw.Currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if !ValidCurrency(currency) {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, raw.Currency)
+	}
+
+	if raw.MinorUnits != nil {
+		*m = Money{Minor: *raw.MinorUnits, Currency: currency}
+		return nil
+	}
+	if len(raw.Amount) == 0 {
+		return fmt.Errorf("%w: amount or minor_units is required", ErrInvalidAmount)
+	}
+	parsed, err := ParseMoney(strings.Trim(string(raw.Amount), `"`), currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// roundLegacy trims float noise such as "150000.00000001" from legacy numbers
+func roundLegacy(number string) string {
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return number
+	}
+	return strconv.FormatFloat(f, 'f', currencyExponents[DefaultCurrency], 64)
+}
+
+func pow10(exp int) int64 {
+	p := int64(1)
+	for i := 0; i < exp; i++ {
+		p *= 10
+	}
+	return p
+}
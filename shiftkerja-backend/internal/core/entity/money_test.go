@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoneyMulDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        Money
+		num, den int64
+		want     Money
+	}{
+		{"exact", NewMoney(1000, "IDR"), 3, 2, NewMoney(1500, "IDR")},
+		{"rounds down below half", NewMoney(1000, "IDR"), 1, 3, NewMoney(333, "IDR")},
+		{"rounds up above half", NewMoney(1000, "IDR"), 2, 3, NewMoney(667, "IDR")},
+		{"half rounds away from zero", NewMoney(5, "IDR"), 1, 2, NewMoney(3, "IDR")},
+		{"negative half rounds away from zero", NewMoney(-5, "IDR"), 1, 2, NewMoney(-3, "IDR")},
+		{"negative denominator", NewMoney(5, "IDR"), 1, -2, NewMoney(-3, "IDR")},
+		{"negative below half", NewMoney(-14, "IDR"), 1, 10, NewMoney(-1, "IDR")},
+		{"basis points", NewMoney(123456, "IDR"), 250, 10000, NewMoney(3086, "IDR")},
+		{"keeps currency", NewMoney(1000, "JPY"), 1, 3, NewMoney(333, "JPY")},
+		{"zero denominator", NewMoney(1000, "SGD"), 1, 0, NewMoney(0, "SGD")},
+		{"zero amount", NewMoney(0, "IDR"), 7, 3, NewMoney(0, "IDR")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MulDiv(tt.num, tt.den); got != tt.want {
+				t.Errorf("%v.MulDiv(%d, %d) = %v, want %v", tt.m, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		want             Money
+		err              error
+	}{
+		{"150000", "", NewMoney(15000000, "IDR"), nil},
+		{"12.5", "IDR", NewMoney(1250, "IDR"), nil},
+		{"12.50", "usd", NewMoney(1250, "USD"), nil},
+		{"0.01", "SGD", NewMoney(1, "SGD"), nil},
+		{"-3.25", "IDR", NewMoney(-325, "IDR"), nil},
+		{" 7.00 ", "IDR", NewMoney(700, "IDR"), nil},
+		{"1000", "JPY", NewMoney(1000, "JPY"), nil},
+		{"10.5", "JPY", Money{}, ErrInvalidAmount},
+		{"1.234", "IDR", Money{}, ErrInvalidAmount},
+		{"", "IDR", Money{}, ErrInvalidAmount},
+		{".5", "IDR", Money{}, ErrInvalidAmount},
+		{"1e3", "IDR", Money{}, ErrInvalidAmount},
+		{"abc", "IDR", Money{}, ErrInvalidAmount},
+		{"99999999999999999999", "IDR", Money{}, ErrInvalidAmount},
+		{"10", "XYZ", Money{}, ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.amount, tt.currency)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseMoney(%q, %q) error = %v, want %v", tt.amount, tt.currency, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q, %q) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
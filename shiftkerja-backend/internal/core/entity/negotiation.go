@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// The two sides of a rate negotiation
+const (
+	SideWorker   = "WORKER"
+	SideBusiness = "BUSINESS"
+)
+
+// What a step of a rate negotiation does
+const (
+	RatePropose = "PROPOSE" // The worker applies asking for a rate
+	RateCounter = "COUNTER" // Puts a new rate on the table for the other side
+	RateAccept  = "ACCEPT"  // Agrees to the rate on the table
+	RateDecline = "DECLINE" // Turns it down; the shift's posted rate stands
+)
+
+// RateOffer is one step of the negotiation thread of an application
+type RateOffer struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	AuthorID      int64     `json:"author_id"`
+	Side          string    `json:"side"` // WORKER or BUSINESS
+	Action        string    `json:"action"`
+	Rate          *Money    `json:"rate,omitempty"` // The rate put on the table or accepted
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// OtherSide returns the side that answers the given one
+func OtherSide(side string) string {
+	if side == SideWorker {
+		return SideBusiness
+	}
+	return SideWorker
+}
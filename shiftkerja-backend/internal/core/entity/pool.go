@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// ShiftVisibility decides who may see and apply to a shift
+type ShiftVisibility string
+
+const (
+	VisibilityPublic ShiftVisibility = "PUBLIC" // Everyone
+	VisibilityPool   ShiftVisibility = "POOL"   // The business's worker pool and invited workers
+	VisibilityInvite ShiftVisibility = "INVITE" // Invited workers only
+)
+
+// PoolMember is a worker a business trusts and offers shifts to first
+type PoolMember struct {
+	BusinessID int64     `json:"business_id"`
+	WorkerID   int64     `json:"worker_id"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	WorkerName  string `json:"worker_name,omitempty"`
+	WorkerEmail string `json:"worker_email,omitempty"`
+}
+
+// ShiftInvite lets a worker see and apply to a restricted shift
+type ShiftInvite struct {
+	ShiftID   int64     `json:"shift_id"`
+	WorkerID  int64     `json:"worker_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Populated via JOIN queries
+	WorkerName string `json:"worker_name,omitempty"`
+}
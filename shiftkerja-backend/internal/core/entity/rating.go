@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Rating directions
+const (
+	RateBusiness = "WORKER_TO_BUSINESS"
+	RateWorker   = "BUSINESS_TO_WORKER"
+)
+
+// RatingTags lists the tags a rater may pick, per direction
+var RatingTags = map[string][]string{
+	RateWorker: {
+		"PUNCTUAL", "HARD_WORKING", "FRIENDLY", "SKILLED", "WOULD_REHIRE",
+		"LATE", "UNPREPARED", "LEFT_EARLY",
+	},
+	RateBusiness: {
+		"CLEAR_INSTRUCTIONS", "RESPECTFUL", "SAFE_WORKPLACE", "FAIR_PAY", "WOULD_RETURN",
+		"DISORGANISED", "JOB_NOT_AS_DESCRIBED", "UNSAFE",
+	},
+}
+
+// Rating is one side's feedback on a completed accepted application
+type Rating struct {
+	ID            int64      `json:"id"`
+	ApplicationID int64      `json:"application_id"`
+	ShiftID       int64      `json:"shift_id"`
+	RaterID       int64      `json:"rater_id"`
+	RateeID       int64      `json:"ratee_id"`
+	Direction     string     `json:"direction"`
+	Stars         int        `json:"stars"` // 1..5
+	Tags          []string   `json:"tags"`
+	Comment       string     `json:"comment,omitempty"`
+	DeadlineAt    time.Time  `json:"deadline_at"`            // Published on its own after this
+	PublishedAt   *time.Time `json:"published_at,omitempty"` // nil while hidden
+	CreatedAt     time.Time  `json:"created_at"`
+
+	// Populated via JOIN queries
+	RaterName  string `json:"rater_name,omitempty"`
+	ShiftTitle string `json:"shift_title,omitempty"`
+}
+
+// RatingSummary is the aggregate of a user's published ratings
+type RatingSummary struct {
+	UserID int64    `json:"user_id"`
+	Avg    *float64 `json:"rating_avg,omitempty"` // nil until rated
+	Count  int      `json:"rating_count"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// Reliability event kinds
+const (
+	ReliabilityNoShow         = "NO_SHOW"         // Accepted but never clocked in
+	ReliabilityLateWithdrawal = "LATE_WITHDRAWAL" // Pulled out shortly before the start
+)
+
+// Appeal statuses of a reliability event
+const (
+	AppealNone       = "NONE"
+	AppealPending    = "PENDING"
+	AppealUpheld     = "UPHELD"
+	AppealOverturned = "OVERTURNED" // The event no longer counts
+)
+
+// ReliabilityEvent is a no-show or late withdrawal recorded against a worker
+type ReliabilityEvent struct {
+	ID              int64      `json:"id"`
+	WorkerID        int64      `json:"worker_id"`
+	ApplicationID   *int64     `json:"application_id,omitempty"` // nil once the application is deleted
+	ShiftID         *int64     `json:"shift_id,omitempty"`
+	Kind            string     `json:"kind"`
+	Strikes         int        `json:"strikes"`
+	OccurredAt      time.Time  `json:"occurred_at"`
+	Detail          string     `json:"detail,omitempty"`
+	AppealStatus    string     `json:"appeal_status"`
+	AppealReason    string     `json:"appeal_reason,omitempty"`
+	AppealNote      string     `json:"appeal_note,omitempty"`
+	AppealDecidedBy *int64     `json:"appeal_decided_by,omitempty"`
+	AppealDecidedAt *time.Time `json:"appeal_decided_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Populated via JOIN queries
+	ShiftTitle string `json:"shift_title,omitempty"`
+	WorkerName string `json:"worker_name,omitempty"`
+}
+
+// Counts reports whether the event still counts towards score and strikes
+func (e ReliabilityEvent) Counts() bool {
+	return e.AppealStatus != AppealOverturned
+}
+
+// WorkerReliability is a worker's rolling reliability and suspension state
+type WorkerReliability struct {
+	WorkerID        int64              `json:"worker_id"`
+	WindowDays      int                `json:"window_days"`
+	Score           *float64           `json:"score,omitempty"` // 0..100, nil without history in the window
+	Attended        int                `json:"attended"`
+	NoShows         int                `json:"no_shows"`
+	LateWithdrawals int                `json:"late_withdrawals"`
+	Strikes         int                `json:"strikes"`
+	SuspendedUntil  *time.Time         `json:"suspended_until,omitempty"`
+	Events          []ReliabilityEvent `json:"events,omitempty"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+// QuestionKind is the kind of answer a screening question takes
+type QuestionKind string
+
+const (
+	QuestionYesNo          QuestionKind = "YES_NO"          // Answered YES or NO
+	QuestionMultipleChoice QuestionKind = "MULTIPLE_CHOICE" // Answered with one of Options
+	QuestionFreeText       QuestionKind = "FREE_TEXT"       // Answered in the applicant's own words
+)
+
+// Yes/no answers
+const (
+	AnswerYes = "YES"
+	AnswerNo  = "NO"
+)
+
+// ScreeningQuestion is asked of everyone applying to a shift
+type ScreeningQuestion struct {
+	ID       int64        `json:"id"`
+	ShiftID  int64        `json:"shift_id"`
+	Position int          `json:"position"` // 1-based order on the form
+	Kind     QuestionKind `json:"kind"`
+	Prompt   string       `json:"prompt"`
+	Options  []string     `json:"options,omitempty"` // MULTIPLE_CHOICE only
+	Required bool         `json:"required"`
+
+	// Answers that reject the application straight away. Only shown to the
+	// shift's owner.
+	KnockoutAnswers []string `json:"knockout_answers,omitempty"`
+}
+
+// ScreeningAnswer is an applicant's answer to one screening question
+type ScreeningAnswer struct {
+	QuestionID int64  `json:"question_id"`
+	Answer     string `json:"answer"`
+	Knockout   bool   `json:"knockout,omitempty"` // The answer rejected the application
+
+	// Populated via JOIN queries
+	Prompt string `json:"prompt,omitempty"`
+}
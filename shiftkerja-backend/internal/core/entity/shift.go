@@ -2,14 +2,76 @@ package entity
 
 import "time"
 
+// ShiftStatus is where a shift is in its lifecycle (see LifecycleService)
+type ShiftStatus string
+
+const (
+	ShiftOpen      ShiftStatus = "OPEN"      // On the map, taking applications
+	ShiftFilled    ShiftStatus = "FILLED"    // Its slots are taken (or closed early)
+	ShiftCancelled ShiftStatus = "CANCELLED" // Called off, final
+)
+
 type Shift struct {
-	ID          int64     `json:"id"`       
-	OwnerID     int64     `json:"owner_id"` 
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	PayRate     float64   `json:"pay_rate"`
-	Lat         float64   `json:"lat"`
-	Lng         float64   `json:"lng"`
-	Status      string    `json:"status"`    
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64       `json:"id"`
+	OwnerID     int64       `json:"owner_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	PayRate     Money       `json:"pay_rate"`
+	PayUnit     string      `json:"pay_unit"` // HOURLY, PER_SHIFT
+	Lat         float64     `json:"lat"`
+	Lng         float64     `json:"lng"`
+	Status      ShiftStatus `json:"status"`
+	CategoryID  *int64      `json:"category_id,omitempty"`
+	StartsAt    *time.Time  `json:"starts_at,omitempty"`
+	EndsAt      *time.Time  `json:"ends_at,omitempty"`
+	SeriesID    *int64      `json:"series_id,omitempty"`    // Set when materialised from a recurring series
+	IsException bool        `json:"is_exception,omitempty"` // Occurrence edited on its own
+	Version     int         `json:"version"`                // Bumped on every edit, see ShiftRevision
+	Slots       int         `json:"slots"`                  // Workers needed; FILLED once that many are accepted
+	EventID     *int64      `json:"event_id,omitempty"`     // Set when the shift is a position of an Event
+	LocationID  *int64      `json:"location_id,omitempty"`  // Saved work site the coordinates come from
+	CreatedAt   time.Time   `json:"created_at"`
+
+	// Who may see and apply to the shift, see PoolService
+	Visibility ShiftVisibility `json:"visibility"`
+	ReleaseAt  *time.Time      `json:"release_at,omitempty"` // A restricted shift unfilled by then becomes PUBLIC
+
+	// Loaded from shift_skills, cached in Redis alongside the shift
+	RequiredSkills []Skill `json:"required_skills,omitempty"`
+
+	// Asked of every applicant, see ScreeningService
+	ScreeningQuestions []ScreeningQuestion `json:"screening_questions,omitempty"`
+
+	// The saved work site, filled in on the shift's detail (not cached)
+	Location *Location `json:"location,omitempty"`
+
+	// On nearby searches, the event this shift stands for on the map with the
+	// availability of its positions (not cached)
+	Event *Event `json:"event,omitempty"`
+
+	// The business's rating, filled in on nearby searches (not cached)
+	OwnerRatingAvg   *float64 `json:"owner_rating_avg,omitempty"`
+	OwnerRatingCount int      `json:"owner_rating_count,omitempty"`
+}
+
+// HourlyRate is the pay per hour, spreading a per-shift rate over the
+// scheduled duration. It returns false if that can't be worked out.
+func (s Shift) HourlyRate() (Money, bool) {
+	if s.PayUnit != PayPerShift {
+		return s.PayRate, true
+	}
+	if s.StartsAt == nil || s.EndsAt == nil {
+		return Money{}, false
+	}
+	minutes := int64(s.EndsAt.Sub(*s.StartsAt) / time.Minute)
+	if minutes <= 0 {
+		return Money{}, false
+	}
+	return s.PayRate.MulDiv(60, minutes), true
+}
+
+// IsPublic reports whether everyone may see the shift at the given time:
+// it is PUBLIC or its release time has passed
+func (s Shift) IsPublic(at time.Time) bool {
+	return s.Visibility == "" || s.Visibility == VisibilityPublic || (s.ReleaseAt != nil && !at.Before(*s.ReleaseAt))
 }
\ No newline at end of file
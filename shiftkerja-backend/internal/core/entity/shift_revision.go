@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// Material changes of a shift edit; any of them makes applicants re-confirm
+const (
+	ChangePayDown     = "PAY_DOWN"    // Pay (per hour) went down or changed currency
+	ChangeMoved       = "MOVED"       // Location moved further than the threshold
+	ChangeRescheduled = "RESCHEDULED" // Start or end time changed
+)
+
+// ShiftRevision is a snapshot of a shift after one edit (version 1 is the
+// shift as posted)
+type ShiftRevision struct {
+	ID              int64      `json:"id"`
+	ShiftID         int64      `json:"shift_id"`
+	Version         int        `json:"version"`
+	EditedBy        int64      `json:"edited_by"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	PayRate         Money      `json:"pay_rate"`
+	PayUnit         string     `json:"pay_unit"`
+	Lat             float64    `json:"lat"`
+	Lng             float64    `json:"lng"`
+	CategoryID      *int64     `json:"category_id,omitempty"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	MaterialChanges []string   `json:"material_changes"`
+	ReconfirmBy     *time.Time `json:"reconfirm_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Workers asked to re-confirm by this edit (not stored)
+	ReconfirmWorkers []int64 `json:"reconfirm_workers,omitempty"`
+}
+
+// NewShiftRevision snapshots the shift's editable fields
+func NewShiftRevision(shift *Shift, editedBy int64) *ShiftRevision {
+	return &ShiftRevision{
+		ShiftID:         shift.ID,
+		EditedBy:        editedBy,
+		Title:           shift.Title,
+		Description:     shift.Description,
+		PayRate:         shift.PayRate,
+		PayUnit:         shift.PayUnit,
+		Lat:             shift.Lat,
+		Lng:             shift.Lng,
+		CategoryID:      shift.CategoryID,
+		StartsAt:        shift.StartsAt,
+		EndsAt:          shift.EndsAt,
+		MaterialChanges: []string{},
+	}
+}
+
+// ReleasedApplication is an application cancelled because its worker
+// declined, or didn't re-confirm, a material change
+type ReleasedApplication struct {
+	ApplicationID int64             `json:"application_id"`
+	ShiftID       int64             `json:"shift_id"`
+	WorkerID      int64             `json:"worker_id"`
+	BusinessID    int64             `json:"business_id"` // The shift's owner
+	WasStatus     ApplicationStatus `json:"was_status"`
+	ShiftReopened bool              `json:"shift_reopened"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// Skill experience levels a worker can declare
+const (
+	SkillLevelBeginner     = "BEGINNER"
+	SkillLevelIntermediate = "INTERMEDIATE"
+	SkillLevelExpert       = "EXPERT"
+)
+
+type Category struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Skill struct {
+	ID         int64     `json:"id"`
+	CategoryID *int64    `json:"category_id,omitempty"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WorkerSkill struct {
+	WorkerID        int64  `json:"worker_id"`
+	SkillID         int64  `json:"skill_id"`
+	Level           string `json:"level"` // BEGINNER, INTERMEDIATE, EXPERT
+	YearsExperience int    `json:"years_experience"`
+
+	// Populated via JOIN queries
+	SkillName string `json:"skill_name,omitempty"`
+}
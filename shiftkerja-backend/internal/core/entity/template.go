@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// Shift series statuses
+const (
+	SeriesActive    = "ACTIVE"
+	SeriesEnded     = "ENDED"
+	SeriesCancelled = "CANCELLED"
+)
+
+// ShiftTemplate is a reusable shift definition a business can post again and again
+type ShiftTemplate struct {
+	ID                int64           `json:"id"`
+	OwnerID           int64           `json:"owner_id"`
+	Name              string          `json:"name"`
+	Title             string          `json:"title"`
+	Description       string          `json:"description"`
+	PayRate           Money           `json:"pay_rate"`
+	PayUnit           string          `json:"pay_unit"` // HOURLY, PER_SHIFT
+	Lat               float64         `json:"lat"`      // From the location
+	Lng               float64         `json:"lng"`
+	LocationID        *int64          `json:"location_id,omitempty"` // Empty on templates saved before locations
+	CategoryID        *int64          `json:"category_id,omitempty"`
+	SkillIDs          []int64         `json:"skill_ids"`
+	StartMinute       int             `json:"start_minute"` // Local time, minutes after midnight
+	DurationMinutes   int             `json:"duration_minutes"`
+	Timezone          string          `json:"timezone"`
+	Visibility        ShiftVisibility `json:"visibility"`                    // PUBLIC or POOL, invites are made per shift
+	ReleaseAfterHours float64         `json:"release_after_hours,omitempty"` // After an occurrence is posted
+	Slots             int             `json:"slots"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+// ShiftSeries repeats a template according to an RRULE-style recurrence rule
+type ShiftSeries struct {
+	ID                int64     `json:"id"`
+	TemplateID        int64     `json:"template_id"`
+	OwnerID           int64     `json:"owner_id"`
+	RRule             string    `json:"rrule"`
+	StartsOn          string    `json:"starts_on"`                    // YYYY-MM-DD
+	MaterialisedUntil string    `json:"materialised_until,omitempty"` // YYYY-MM-DD
+	Status            string    `json:"status"`                       // ACTIVE, ENDED, CANCELLED
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+// Timesheet statuses
+const (
+	TimesheetPending   = "PENDING"   // Awaiting the business
+	TimesheetEdited    = "EDITED"    // Business changed the times, awaiting the worker
+	TimesheetContested = "CONTESTED" // Worker rejected an edit, back with the business
+	TimesheetDisputed  = "DISPUTED"  // Business doesn't recognise the hours
+	TimesheetApproved  = "APPROVED"  // Final, ready for payout
+)
+
+// Timesheet actions (recorded in the history)
+const (
+	TimesheetActionCreate  = "CREATE"
+	TimesheetActionApprove = "APPROVE"
+	TimesheetActionEdit    = "EDIT"
+	TimesheetActionDispute = "DISPUTE"
+	TimesheetActionAccept  = "ACCEPT"
+	TimesheetActionContest = "CONTEST"
+)
+
+// Timesheet is the worked time of one accepted application
+type Timesheet struct {
+	ID                 int64     `json:"id"`
+	ApplicationID      int64     `json:"application_id"`
+	AttendanceID       int64     `json:"attendance_id"`
+	ShiftID            int64     `json:"shift_id"`
+	WorkerID           int64     `json:"worker_id"`
+	BusinessID         int64     `json:"business_id"`
+	StartedAt          time.Time `json:"started_at"`
+	EndedAt            time.Time `json:"ended_at"`
+	BreakMinutes       int       `json:"break_minutes"` // Unpaid
+	WorkedMinutes      int       `json:"worked_minutes"`
+	PayRate            Money     `json:"pay_rate"` // Snapshot of the shift's rate
+	PayUnit            string    `json:"pay_unit"`
+	PayAmount          Money     `json:"pay_amount"`
+	RegionCode         string    `json:"region_code,omitempty"`          // Wage region of the shift location
+	HolidayMinutes     int       `json:"holiday_minutes"`                // Paid at the holiday multiplier
+	MinimumWageApplied bool      `json:"minimum_wage_applied,omitempty"` // Rate was raised to the regional minimum
+	Status             string    `json:"status"`
+	Note               string    `json:"note,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+
+	// Populated via JOIN queries
+	ShiftTitle string `json:"shift_title,omitempty"`
+	WorkerName string `json:"worker_name,omitempty"`
+}
+
+// TimesheetHistoryEntry is one audited change of a timesheet
+type TimesheetHistoryEntry struct {
+	ID            int64     `json:"id"`
+	TimesheetID   int64     `json:"timesheet_id"`
+	ActorID       int64     `json:"actor_id"`
+	Action        string    `json:"action"`
+	FromStatus    string    `json:"from_status,omitempty"`
+	ToStatus      string    `json:"to_status"`
+	StartedAt     time.Time `json:"started_at"`
+	EndedAt       time.Time `json:"ended_at"`
+	BreakMinutes  int       `json:"break_minutes"`
+	WorkedMinutes int       `json:"worked_minutes"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
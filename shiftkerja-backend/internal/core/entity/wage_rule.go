@@ -0,0 +1,24 @@
+package entity
+
+// WageRegion is an area with its own statutory minimum wage (a province's UMP
+// or a city's UMK)
+type WageRegion struct {
+	Code          string `json:"code"` // e.g. ID-BA
+	Name          string `json:"name"`
+	MinHourlyRate Money  `json:"min_hourly_rate"`
+	Timezone      string `json:"timezone"` // Decides which local date a shift falls on
+}
+
+// PublicHoliday is a day on which worked time is paid at a multiple of the rate
+type PublicHoliday struct {
+	Date       string   `json:"date"` // YYYY-MM-DD
+	Name       string   `json:"name"`
+	Multiplier float64  `json:"multiplier"`
+	Regions    []string `json:"regions,omitempty"` // Empty = nationwide
+}
+
+// WageCheck is what the wage rules say about a place and date
+type WageCheck struct {
+	Region  *WageRegion    `json:"region,omitempty"` // nil outside every known region
+	Holiday *PublicHoliday `json:"holiday,omitempty"`
+}
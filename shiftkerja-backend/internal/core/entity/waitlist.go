@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// WaitlistOffer is a freed-up spot offered to a standby applicant, or an
+// offer that ran out (see WaitlistService.RunBackfill)
+type WaitlistOffer struct {
+	ApplicationID int64     `json:"application_id"`
+	ShiftID       int64     `json:"shift_id"`
+	WorkerID      int64     `json:"worker_id"`
+	ShiftTitle    string    `json:"shift_title"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// WorkerPreferences describes what a worker is looking for
+type WorkerPreferences struct {
+	WorkerID      int64     `json:"worker_id"`
+	MinPayRate    Money     `json:"min_pay_rate"` // Per hour
+	HomeLat       *float64  `json:"home_lat,omitempty"`
+	HomeLng       *float64  `json:"home_lng,omitempty"`
+	MaxDistanceKm float64   `json:"max_distance_km"`
+	Timezone      string    `json:"timezone"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AvailabilityWindow is a recurring weekly slot, in the worker's local time
+type AvailabilityWindow struct {
+	Weekday     int `json:"weekday"`      // 0 = Sunday ... 6 = Saturday
+	StartMinute int `json:"start_minute"` // Minutes after midnight
+	EndMinute   int `json:"end_minute"`   // Up to 1440
+}
+
+// BlackoutDate is a day on which the worker can't take any shift
+type BlackoutDate struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Reason string `json:"reason,omitempty"`
+}
+
+// WorkerAvailability is the full availability calendar of a worker.
+// No windows means "any time", apart from blackout dates.
+type WorkerAvailability struct {
+	WorkerID  int64                `json:"worker_id"`
+	Timezone  string               `json:"timezone"`
+	Windows   []AvailabilityWindow `json:"windows"`
+	Blackouts []BlackoutDate       `json:"blackouts"`
+}
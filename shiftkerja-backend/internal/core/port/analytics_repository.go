@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// AnalyticsRepository defines the contract for business analytics queries
+type AnalyticsRepository interface {
+	// GetReasonCounts counts the rejections and withdrawals on the business's
+	// shifts decided since the given time, per status and reason code (Share
+	// is left to the caller)
+	GetReasonCounts(ctx context.Context, businessID int64, since time.Time) ([]entity.ReasonCount, error)
+}
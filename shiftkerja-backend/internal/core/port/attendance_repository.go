@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// AttendanceRepository defines the contract for clock-in / clock-out records
+type AttendanceRepository interface {
+	CreateClockIn(ctx context.Context, attendance *entity.Attendance) error
+	UpdateClockOut(ctx context.Context, attendance *entity.Attendance) error
+	// GetAttendanceByApplication returns nil (and no error) when the worker hasn't clocked in
+	GetAttendanceByApplication(ctx context.Context, applicationID int64) (*entity.Attendance, error)
+	GetAttendanceByShift(ctx context.Context, shiftID int64) ([]entity.Attendance, error)
+}
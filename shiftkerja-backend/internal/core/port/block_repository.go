@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// BlockRepository defines the contract for block relationships
+type BlockRepository interface {
+	// CreateBlock stores the block and drops the pair's pool membership and
+	// shift invites. Blocking twice updates the reason.
+	CreateBlock(ctx context.Context, block *entity.Block) error
+	DeleteBlock(ctx context.Context, blockerID, blockedID int64) (bool, error)
+	GetBlocksBy(ctx context.Context, blockerID int64) ([]entity.Block, error)
+	// GetBlocksInvolving lists the blocks made by or against the user
+	GetBlocksInvolving(ctx context.Context, userID int64) ([]entity.Block, error)
+
+	// GetBlockedWith returns the users with a block in either direction
+	GetBlockedWith(ctx context.Context, userID int64) (map[int64]bool, error)
+
+	// GetBlockPatterns sums up blocks made since the given time per user,
+	// keeping users blocked by, or blocking, at least minCount others
+	GetBlockPatterns(ctx context.Context, since time.Time, minCount int) ([]entity.BlockPattern, error)
+}
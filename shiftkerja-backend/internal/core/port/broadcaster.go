@@ -0,0 +1,9 @@
+package port
+
+// Broadcaster pushes realtime messages to connected clients (the WebSocket
+// hub). Services use it for events that don't come from a request, such as
+// background jobs. Anything about one user goes through SendToUser.
+type Broadcaster interface {
+	Broadcast(message interface{})
+	SendToUser(userID int64, message interface{})
+}
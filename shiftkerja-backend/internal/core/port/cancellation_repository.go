@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// CancellationRepository defines the contract for cancelling accepted work.
+// Each cancellation changes the statuses and stores its records atomically.
+type CancellationRepository interface {
+	// CancelShift marks an OPEN or FILLED shift and its pending and accepted
+	// applications CANCELLED; it returns false if the shift was in another status
+	CancelShift(ctx context.Context, shiftID int64, cancellations []*entity.Cancellation) (bool, error)
+	// CancelSpot marks an ACCEPTED application CANCELLED and, if reopen is set,
+	// puts a FILLED shift back to OPEN; it returns false if the application wasn't ACCEPTED
+	CancelSpot(ctx context.Context, cancellation *entity.Cancellation, reopen bool) (bool, error)
+	GetCancellationsByShift(ctx context.Context, shiftID int64) ([]entity.Cancellation, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// EventRepository defines the contract for multi-position events. The
+// positions themselves are shifts.
+type EventRepository interface {
+	// CreateEvent inserts the event and its positions (shifts, with their
+	// RequiredSkills and ScreeningQuestions) in one transaction
+	CreateEvent(ctx context.Context, event *entity.Event, positions []entity.Shift) error
+	GetEvent(ctx context.Context, id int64) (*entity.Event, error)
+	GetEvents(ctx context.Context, ids []int64) (map[int64]*entity.Event, error)
+	GetEventsByOwner(ctx context.Context, ownerID int64) ([]entity.Event, error)
+
+	// GetPositions returns the shifts of the events, by event and in the
+	// order they were added
+	GetPositions(ctx context.Context, eventIDs []int64) ([]entity.Shift, error)
+
+	// CountAccepted counts the accepted workers of each shift
+	CountAccepted(ctx context.Context, shiftIDs []int64) (map[int64]int, error)
+}
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+	"time"
+)
+
+// LedgerRepository defines the contract for the earnings ledger and payouts
+type LedgerRepository interface {
+	// PostTransactions stores balanced transactions all or nothing. It returns false
+	// (and no error) if a timesheet was already posted with the same kind.
+	PostTransactions(ctx context.Context, txns ...*entity.LedgerTransaction) (bool, error)
+	// GetUnpostedTimesheets returns APPROVED timesheets without an EARNING transaction
+	GetUnpostedTimesheets(ctx context.Context) ([]entity.Timesheet, error)
+	// GetUnpostedCompensations returns cancellations owing compensation without a COMPENSATION transaction
+	GetUnpostedCompensations(ctx context.Context) ([]entity.Cancellation, error)
+	GetAccountEntries(ctx context.Context, kind string, ownerID int64, currency string, from, to time.Time) ([]entity.LedgerEntry, error)
+	GetAccountBalance(ctx context.Context, kind string, ownerID int64, currency string) (entity.Money, error)
+
+	// Payouts
+	GetPayableLines(ctx context.Context, currency string) ([]entity.PayoutLine, error)
+	// CreatePayoutBatch calls render once the batch has its ID, inside the same
+	// transaction, so the file can reference it; an error from render aborts the batch
+	CreatePayoutBatch(ctx context.Context, batch *entity.PayoutBatch, lines []entity.PayoutLine, render func(*entity.PayoutBatch) error) error
+	GetPayoutBatches(ctx context.Context) ([]entity.PayoutBatch, error)
+	GetPayoutBatchByID(ctx context.Context, id int64) (*entity.PayoutBatch, error)
+
+	// Bank details
+	GetBankAccount(ctx context.Context, workerID int64) (*entity.WorkerBankAccount, error) // nil if none
+	UpsertBankAccount(ctx context.Context, account *entity.WorkerBankAccount) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// LocationRepository defines the contract for the work sites saved by businesses
+type LocationRepository interface {
+	CreateLocation(ctx context.Context, location *entity.Location) error
+	GetLocation(ctx context.Context, id int64) (*entity.Location, error)
+	GetLocationsByOwner(ctx context.Context, ownerID int64) ([]entity.Location, error)
+
+	// GetOpenShifts returns the OPEN shifts posted at the location
+	GetOpenShifts(ctx context.Context, locationID int64) ([]entity.Shift, error)
+
+	// GetTemplates returns the shift templates posted at the location
+	GetTemplates(ctx context.Context, locationID int64) ([]entity.ShiftTemplate, error)
+
+	// UpdateLocation saves the location and moves its events, templates and
+	// OPEN shifts to its coordinates in one transaction. It returns the shifts it moved.
+	UpdateLocation(ctx context.Context, location *entity.Location) ([]entity.Shift, error)
+
+	// DeleteLocation deletes a location no shift, event or template refers to. It
+	// returns false if one does.
+	DeleteLocation(ctx context.Context, id int64) (bool, error)
+}
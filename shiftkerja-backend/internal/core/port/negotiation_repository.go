@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// NegotiationRepository defines the contract for the rate negotiation thread
+// of applications
+type NegotiationRepository interface {
+	// RecordOffer puts the rate (nil = the shift's rate) on the table with the
+	// side that has to answer it ("" = agreed) and adds the step to the thread.
+	// Nothing changes (false) unless the application is still PENDING or
+	// STANDBY and waiting on turnWas.
+	RecordOffer(ctx context.Context, offer *entity.RateOffer, turnWas string, rate *entity.Money, turn string) (bool, error)
+
+	// GetOffers returns the thread of each application, oldest first
+	GetOffers(ctx context.Context, applicationIDs []int64) (map[int64][]entity.RateOffer, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"io"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// PayoutFormatter writes a payout batch in one bank's bulk-transfer layout
+type PayoutFormatter interface {
+	Code() string // e.g. "bca"
+	FileName(batch *entity.PayoutBatch) string
+	Write(w io.Writer, batch *entity.PayoutBatch, lines []entity.PayoutLine) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// PoolRepository defines the contract for worker pools, shift invites and
+// shift visibility
+type PoolRepository interface {
+	// AddPoolMember adds the worker to the business's pool, or updates the note
+	AddPoolMember(ctx context.Context, member *entity.PoolMember) error
+	RemovePoolMember(ctx context.Context, businessID, workerID int64) (bool, error)
+	GetPoolMembers(ctx context.Context, businessID int64) ([]entity.PoolMember, error)
+
+	// InviteWorkers invites the workers to the shift (inviting twice is a no-op)
+	InviteWorkers(ctx context.Context, shiftID int64, workerIDs []int64) error
+	GetInvites(ctx context.Context, shiftID int64) ([]entity.ShiftInvite, error)
+
+	// GetAdmittedShifts returns which of the shifts the worker is let into
+	// despite their visibility: invited, in the owner's pool for POOL shifts,
+	// or already applied
+	GetAdmittedShifts(ctx context.Context, workerID int64, shiftIDs []int64) (map[int64]bool, error)
+
+	SetVisibility(ctx context.Context, shiftID int64, visibility entity.ShiftVisibility, releaseAt *time.Time) error
+	// ReleaseDue makes restricted OPEN shifts whose release time passed
+	// PUBLIC and returns their IDs
+	ReleaseDue(ctx context.Context, now time.Time) ([]int64, error)
+}
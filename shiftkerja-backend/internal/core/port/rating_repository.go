@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// RatingRepository defines the contract for post-shift ratings and the
+// aggregates kept on users
+type RatingRepository interface {
+	// GetCompletedAt returns when the work of an application ended: the
+	// timesheet's end if there is one, otherwise the shift's scheduled end
+	// (nil if neither is known yet)
+	GetCompletedAt(ctx context.Context, applicationID int64) (*time.Time, error)
+	// CreateRating inserts a hidden rating; if the other side already rated,
+	// both are published and the ratees' aggregates refreshed atomically.
+	// It returns false if this side already rated the application.
+	CreateRating(ctx context.Context, rating *entity.Rating) (bool, error)
+	GetRatingsByApplication(ctx context.Context, applicationID int64) ([]entity.Rating, error)
+	// GetPublishedRatings returns the newest published ratings a user received
+	GetPublishedRatings(ctx context.Context, userID int64, limit int) ([]entity.Rating, error)
+	// GetRatingSummaries returns one summary per requested user (zero values if unrated)
+	GetRatingSummaries(ctx context.Context, userIDs []int64) (map[int64]entity.RatingSummary, error)
+	// PublishDueRatings publishes hidden ratings whose deadline has passed and
+	// refreshes the affected aggregates, returning how many were published
+	PublishDueRatings(ctx context.Context, now time.Time) (int, error)
+}
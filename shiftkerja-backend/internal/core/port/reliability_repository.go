@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// ReliabilityRepository defines the contract for no-show and late withdrawal records
+type ReliabilityRepository interface {
+	// RecordNoShows records a NO_SHOW for every ACCEPTED application whose
+	// shift started in (startedAfter, startedBefore] without a clock-in, and
+	// returns the new events (applications already recorded are skipped)
+	RecordNoShows(ctx context.Context, startedAfter, startedBefore time.Time, strikes int) ([]entity.ReliabilityEvent, error)
+	// CreateEvent returns false if the application already has an event of this kind
+	CreateEvent(ctx context.Context, event *entity.ReliabilityEvent) (bool, error)
+	GetEventByID(ctx context.Context, id int64) (*entity.ReliabilityEvent, error)
+	// GetEventsByWorker returns the worker's events recorded since the given time, newest first
+	GetEventsByWorker(ctx context.Context, workerID int64, since time.Time) ([]entity.ReliabilityEvent, error)
+	GetEventsByAppealStatus(ctx context.Context, status string) ([]entity.ReliabilityEvent, error)
+	// UpdateAppeal saves the appeal fields if the appeal is still in fromStatus
+	UpdateAppeal(ctx context.Context, event *entity.ReliabilityEvent, fromStatus string) (bool, error)
+	// CountAttended counts the worker's clock-ins since the given time
+	CountAttended(ctx context.Context, workerID int64, since time.Time) (int, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// ScreeningRepository defines the contract for shift screening questions and
+// the answers stored on applications
+type ScreeningRepository interface {
+	// SetQuestions replaces the shift's questions, unless somebody has already
+	// applied (reported as false)
+	SetQuestions(ctx context.Context, shiftID int64, questions []entity.ScreeningQuestion) (bool, error)
+	GetQuestions(ctx context.Context, shiftID int64) ([]entity.ScreeningQuestion, error)
+
+	// GetAnswers returns the answers of each application, in question order
+	GetAnswers(ctx context.Context, applicationIDs []int64) (map[int64][]entity.ScreeningAnswer, error)
+}
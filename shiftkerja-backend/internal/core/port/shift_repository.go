@@ -10,15 +10,23 @@ type ShiftRepository interface {
 	CreateShift(ctx context.Context, shift *entity.Shift) error
 	GetShiftByID(ctx context.Context, id int64) (*entity.Shift, error)
 	GetShiftsByOwner(ctx context.Context, ownerID int64) ([]entity.Shift, error)
-	UpdateShiftStatus(ctx context.Context, id int64, status string) error
+	// Status writes are guarded by the expected current status and report
+	// whether the row was still in it
+	TransitionShiftStatus(ctx context.Context, id int64, from, to entity.ShiftStatus) (bool, error)
 	UpdateShift(ctx context.Context, shift *entity.Shift) error
 	DeleteShift(ctx context.Context, id int64) error
+	HasLedgerHistory(ctx context.Context, shiftID int64) (bool, error)
+	
+	// Recurring series occurrences
+	CreateShiftOccurrence(ctx context.Context, shift *entity.Shift, occurrenceDate string) (bool, error)
+	GetUpcomingSeriesShifts(ctx context.Context, seriesID int64) ([]entity.Shift, error)
 	
 	// Application methods
-	ApplyForShift(ctx context.Context, shiftID, workerID int64) error
+	ApplyForShift(ctx context.Context, app *entity.Application) error // PENDING, or REJECTED by a screening knockout, with its answers
 	GetApplicationsByWorker(ctx context.Context, workerID int64) ([]entity.Application, error)
 	GetApplicationsByShift(ctx context.Context, shiftID int64) ([]entity.Application, error)
-	UpdateApplicationStatus(ctx context.Context, applicationID int64, status string) error
+	TransitionApplicationStatus(ctx context.Context, applicationID int64, from, to entity.ApplicationStatus, reasonCode, reasonNote string) (bool, error)
+	AcceptApplication(ctx context.Context, applicationID, shiftID int64, from entity.ApplicationStatus) (accepted, filled bool, err error)
+	BatchTransitionApplications(ctx context.Context, items []entity.ApplicationTransition, to entity.ApplicationStatus, reasonCode, reasonNote string) ([]int64, bool, error)
 	GetApplicationByID(ctx context.Context, id int64) (*entity.Application, error)
-	DeleteApplication(ctx context.Context, id int64) error
 }
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// ShiftRevisionRepository defines the contract for shift edit history and
+// applicant re-confirmation
+type ShiftRevisionRepository interface {
+	// SaveRevision stores the edit as the shift's next version (writing the
+	// shift as posted as version 1 first if needed). If the revision has a
+	// ReconfirmBy deadline, the shift's PENDING and ACCEPTED applications must
+	// re-confirm by then; their worker IDs are returned.
+	SaveRevision(ctx context.Context, before *entity.Shift, revision *entity.ShiftRevision) ([]int64, error)
+	GetRevisions(ctx context.Context, shiftID int64) ([]entity.ShiftRevision, error)
+
+	// ConfirmApplication clears a pending re-confirmation; false if there was none
+	ConfirmApplication(ctx context.Context, applicationID int64) (bool, error)
+	// ReleaseUnconfirmed cancels applications still awaiting re-confirmation:
+	// one application (applicationID > 0) or all whose deadline is before the
+	// given time. FILLED shifts left with a free slot are reopened.
+	ReleaseUnconfirmed(ctx context.Context, applicationID int64, deadlineBefore *time.Time) ([]entity.ReleasedApplication, error)
+}
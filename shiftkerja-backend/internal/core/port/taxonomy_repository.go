@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// TaxonomyRepository defines the contract for categories, skills and worker skill profiles
+type TaxonomyRepository interface {
+	CreateCategory(ctx context.Context, category *entity.Category) error
+	ListCategories(ctx context.Context) ([]entity.Category, error)
+	GetCategoryByID(ctx context.Context, id int64) (*entity.Category, error)
+
+	CreateSkill(ctx context.Context, skill *entity.Skill) error
+	ListSkills(ctx context.Context, categoryID int64) ([]entity.Skill, error)
+	GetSkillsByIDs(ctx context.Context, ids []int64) ([]entity.Skill, error)
+
+	// Shift requirements
+	SetShiftSkills(ctx context.Context, shiftID int64, skillIDs []int64) error
+	GetShiftSkills(ctx context.Context, shiftIDs []int64) (map[int64][]entity.Skill, error)
+
+	// Worker profiles
+	SetWorkerSkills(ctx context.Context, workerID int64, skills []entity.WorkerSkill) error
+	GetWorkerSkills(ctx context.Context, workerID int64) ([]entity.WorkerSkill, error)
+	GetWorkerSkillsByWorkers(ctx context.Context, workerIDs []int64) (map[int64][]entity.WorkerSkill, error)
+}
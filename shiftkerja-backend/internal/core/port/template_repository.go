@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// TemplateRepository defines the contract for shift templates and recurring series
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *entity.ShiftTemplate) error
+	GetTemplateByID(ctx context.Context, id int64) (*entity.ShiftTemplate, error)
+	GetTemplatesByOwner(ctx context.Context, ownerID int64) ([]entity.ShiftTemplate, error)
+	UpdateTemplate(ctx context.Context, template *entity.ShiftTemplate) error
+	DeleteTemplate(ctx context.Context, id int64) error
+
+	CreateSeries(ctx context.Context, series *entity.ShiftSeries) error
+	GetSeriesByID(ctx context.Context, id int64) (*entity.ShiftSeries, error)
+	GetSeriesByOwner(ctx context.Context, ownerID int64) ([]entity.ShiftSeries, error)
+	GetActiveSeries(ctx context.Context) ([]entity.ShiftSeries, error)
+	UpdateSeriesProgress(ctx context.Context, id int64, materialisedUntil, status string) error
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// TimesheetRepository defines the contract for timesheets and their audit trail.
+// Every write stores the timesheet and its history entry atomically.
+type TimesheetRepository interface {
+	CreateTimesheet(ctx context.Context, ts *entity.Timesheet, entry *entity.TimesheetHistoryEntry) error
+	// UpdateTimesheet only applies if the timesheet is still in fromStatus;
+	// it returns false when someone else changed it first
+	UpdateTimesheet(ctx context.Context, ts *entity.Timesheet, fromStatus string, entry *entity.TimesheetHistoryEntry) (bool, error)
+	GetTimesheetByID(ctx context.Context, id int64) (*entity.Timesheet, error)
+	GetTimesheetsByWorker(ctx context.Context, workerID int64, status string) ([]entity.Timesheet, error)
+	GetTimesheetsByBusiness(ctx context.Context, businessID int64, status string) ([]entity.Timesheet, error)
+	GetTimesheetHistory(ctx context.Context, timesheetID int64) ([]entity.TimesheetHistoryEntry, error)
+}
@@ -0,0 +1,12 @@
+package port
+
+import "shiftkerja-backend/internal/core/entity"
+
+// WageRules answers minimum wage and public holiday questions from a local dataset
+type WageRules interface {
+	// RegionAt returns the most specific region containing the point (nil if none)
+	RegionAt(lat, lng float64) *entity.WageRegion
+	RegionByCode(code string) *entity.WageRegion
+	// Holiday returns the holiday on a date (YYYY-MM-DD) in a region (nil if none)
+	Holiday(date, regionCode string) *entity.PublicHoliday
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// WaitlistRepository defines the contract for standby applicants and the
+// offers made to them when a spot frees up
+type WaitlistRepository interface {
+	// SetStandby makes the given PENDING or STANDBY applications the shift's
+	// standby list, ranked in order. STANDBY applications left out go back to
+	// PENDING. False if one of them changed status in the meantime.
+	SetStandby(ctx context.Context, shiftID int64, applicationIDs []int64) (bool, error)
+
+	// ExpireOffers cancels offers whose deadline passed before now and returns
+	// them. Offers on shifts that stopped being OPEN go back to STANDBY.
+	ExpireOffers(ctx context.Context, now time.Time) ([]entity.WaitlistOffer, error)
+	// OfferNext offers every OPEN, not yet started shift that has nobody
+	// accepted or offered to its best ranked standby applicant, until the
+	// deadline (capped at the shift's start)
+	OfferNext(ctx context.Context, now, deadline time.Time) ([]entity.WaitlistOffer, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// WorkerProfileRepository defines the contract for worker preferences and availability
+type WorkerProfileRepository interface {
+	// GetPreferences returns nil (and no error) when the worker has not set any
+	GetPreferences(ctx context.Context, workerID int64) (*entity.WorkerPreferences, error)
+	UpsertPreferences(ctx context.Context, prefs *entity.WorkerPreferences) error
+	GetPreferencesByWorkers(ctx context.Context, workerIDs []int64) (map[int64]entity.WorkerPreferences, error)
+
+	// Availability calendar
+	GetAvailability(ctx context.Context, workerID int64) (*entity.WorkerAvailability, error)
+	SetAvailability(ctx context.Context, availability *entity.WorkerAvailability) error
+}
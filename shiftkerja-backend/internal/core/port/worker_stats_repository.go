@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// WorkerStatsRepository defines the contract for aggregated worker history
+type WorkerStatsRepository interface {
+	// GetTrackRecords returns one record per requested worker (zero values if no history)
+	GetTrackRecords(ctx context.Context, workerIDs []int64) (map[int64]entity.WorkerTrackRecord, error)
+}
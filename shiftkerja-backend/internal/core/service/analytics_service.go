@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// defaultAnalyticsDays is the period analytics cover unless asked otherwise
+const defaultAnalyticsDays = 90
+
+// AnalyticsService sums up a business's hiring activity
+type AnalyticsService struct {
+	analyticsRepo port.AnalyticsRepository
+}
+
+func NewAnalyticsService(analyticsRepo port.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{analyticsRepo: analyticsRepo}
+}
+
+// GetReasonBreakdown returns why the business's applications were rejected
+// or withdrawn over the last days, with each reason's share
+func (s *AnalyticsService) GetReasonBreakdown(ctx context.Context, businessID int64, days int) (*entity.ReasonBreakdown, error) {
+	if days <= 0 {
+		days = defaultAnalyticsDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	counts, err := s.analyticsRepo.GetReasonCounts(ctx, businessID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &entity.ReasonBreakdown{
+		BusinessID:  businessID,
+		Since:       since,
+		Rejections:  []entity.ReasonCount{},
+		Withdrawals: []entity.ReasonCount{},
+	}
+	for _, c := range counts {
+		switch c.Status {
+		case entity.ApplicationRejected:
+			breakdown.Rejected += c.Count
+		case entity.ApplicationWithdrawn:
+			breakdown.Withdrawn += c.Count
+		}
+	}
+	for _, c := range counts {
+		switch c.Status {
+		case entity.ApplicationRejected:
+			c.Share = float64(c.Count) / float64(breakdown.Rejected)
+			breakdown.Rejections = append(breakdown.Rejections, c)
+		case entity.ApplicationWithdrawn:
+			c.Share = float64(c.Count) / float64(breakdown.Withdrawn)
+			breakdown.Withdrawals = append(breakdown.Withdrawals, c)
+		}
+	}
+	return breakdown, nil
+}
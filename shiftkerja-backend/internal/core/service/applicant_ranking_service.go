@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// applicantRadiusKm is the distance at which the distance signal reaches zero
+const applicantRadiusKm = 25.0
+
+// ApplicantWeights controls how applicants of a shift are ranked.
+// Weights are relative: they are normalised by their sum when scoring.
+type ApplicantWeights struct {
+	Reliability float64 `json:"reliability"`
+	Rating      float64 `json:"rating"`
+	Distance    float64 `json:"distance"`
+	Skills      float64 `json:"skills"`
+}
+
+// DefaultApplicantWeights puts showing up above everything else
+func DefaultApplicantWeights() ApplicantWeights {
+	return ApplicantWeights{
+		Reliability: 0.40,
+		Rating:      0.25,
+		Distance:    0.15,
+		Skills:      0.20,
+	}
+}
+
+func (w ApplicantWeights) total() float64 {
+	return w.Reliability + w.Rating + w.Distance + w.Skills
+}
+
+type ApplicantRankingService struct {
+	shiftRepo    port.ShiftRepository
+	taxonomyRepo port.TaxonomyRepository
+	profileRepo  port.WorkerProfileRepository
+	statsRepo    port.WorkerStatsRepository
+	weights      ApplicantWeights
+}
+
+func NewApplicantRankingService(
+	shiftRepo port.ShiftRepository,
+	taxonomyRepo port.TaxonomyRepository,
+	profileRepo port.WorkerProfileRepository,
+	statsRepo port.WorkerStatsRepository,
+	weights ApplicantWeights,
+) *ApplicantRankingService {
+	if weights.total() <= 0 {
+		weights = DefaultApplicantWeights()
+	}
+	return &ApplicantRankingService{
+		shiftRepo:    shiftRepo,
+		taxonomyRepo: taxonomyRepo,
+		profileRepo:  profileRepo,
+		statsRepo:    statsRepo,
+		weights:      weights,
+	}
+}
+
+// Weights returns the weights used for ranking
+func (s *ApplicantRankingService) Weights() ApplicantWeights {
+	return s.weights
+}
+
+// RankApplicants orders the applications of a shift best first.
+// Ownership must already be verified by the caller (see ShiftService.GetShiftApplications).
+func (s *ApplicantRankingService) RankApplicants(ctx context.Context, shiftID int64, apps []entity.Application) ([]entity.RankedApplicant, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	required, err := s.taxonomyRepo.GetShiftSkills(ctx, []int64{shiftID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load required skills: %w", err)
+	}
+
+	workerIDs := make([]int64, len(apps))
+	for i := range apps {
+		workerIDs[i] = apps[i].WorkerID
+	}
+	records, err := s.statsRepo.GetTrackRecords(ctx, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load track records: %w", err)
+	}
+	prefs, err := s.profileRepo.GetPreferencesByWorkers(ctx, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker locations: %w", err)
+	}
+	skills, err := s.taxonomyRepo.GetWorkerSkillsByWorkers(ctx, workerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker skills: %w", err)
+	}
+
+	total := s.weights.total()
+	ranked := make([]entity.RankedApplicant, 0, len(apps))
+	for _, app := range apps {
+		var home *entity.WorkerPreferences
+		if p, ok := prefs[app.WorkerID]; ok {
+			home = &p
+		}
+		signals := []entity.MatchSignal{
+			reliabilitySignal(records[app.WorkerID], s.weights.Reliability),
+			ratingSignal(records[app.WorkerID], s.weights.Rating),
+			applicantDistanceSignal(shift, home, s.weights.Distance),
+			skillSignal(required[shiftID], skills[app.WorkerID], s.weights.Skills),
+		}
+
+		var score float64
+		for i := range signals {
+			signals[i].Contribution = round1(100 * signals[i].Value * signals[i].Weight / total)
+			signals[i].Value = round2(signals[i].Value)
+			score += signals[i].Contribution
+		}
+		ranked = append(ranked, entity.RankedApplicant{
+			Application: app,
+			Score:       round1(score),
+			Signals:     signals,
+		})
+	}
+
+	// Ties keep the original (oldest first) order
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+
+	return ranked, nil
+}
+
+// reliabilitySignal uses a smoothed completion rate so that one shift of
+// history doesn't beat a long, nearly spotless record
+func reliabilitySignal(rec entity.WorkerTrackRecord, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "reliability", Weight: weight}
+	misses := rec.NoShows + rec.LateWithdrawals
+	sig.Value = float64(rec.CompletedShifts+1) / float64(rec.CompletedShifts+misses+2)
+	if rec.CompletedShifts == 0 && misses == 0 {
+		sig.Explanation = "No completed shifts yet"
+		return sig
+	}
+	sig.Explanation = fmt.Sprintf("Completed %d shifts, %d no-shows, %d late withdrawals",
+		rec.CompletedShifts, rec.NoShows, rec.LateWithdrawals)
+	return sig
+}
+
+func ratingSignal(rec entity.WorkerTrackRecord, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "rating", Weight: weight}
+	if rec.RatingAvg == nil || rec.RatingCount == 0 {
+		sig.Value = 0.5
+		sig.Explanation = "Not rated yet"
+		return sig
+	}
+	sig.Value = clamp01((*rec.RatingAvg - 1) / 4)
+	sig.Explanation = fmt.Sprintf("Rated %.1f/5 from %d reviews", *rec.RatingAvg, rec.RatingCount)
+	return sig
+}
+
+func applicantDistanceSignal(shift *entity.Shift, prefs *entity.WorkerPreferences, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "distance", Weight: weight}
+	if prefs == nil || prefs.HomeLat == nil || prefs.HomeLng == nil {
+		sig.Value = 0.5
+		sig.Explanation = "Home location not shared"
+		return sig
+	}
+	d := distanceKm(*prefs.HomeLat, *prefs.HomeLng, shift.Lat, shift.Lng)
+	sig.Value = clamp01(1 - d/applicantRadiusKm)
+	sig.Explanation = fmt.Sprintf("Lives %.1f km from the shift", d)
+	return sig
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrNotAcceptedForShift = errors.New("you are not accepted for this shift")
+	ErrShiftNotActive      = errors.New("shift is not active")
+	ErrClockInTooEarly     = errors.New("too early to clock in for this shift")
+	ErrShiftAlreadyEnded   = errors.New("shift has already ended")
+	ErrAlreadyClockedIn    = errors.New("already clocked in for this shift")
+	ErrNotClockedIn        = errors.New("you have not clocked in for this shift")
+	ErrAlreadyClockedOut   = errors.New("already clocked out for this shift")
+)
+
+// Attendance actions
+const (
+	ActionClockIn  = "CLOCK_IN"
+	ActionClockOut = "CLOCK_OUT"
+)
+
+// AttendanceConfig tunes the clock-in / clock-out checks
+type AttendanceConfig struct {
+	GeofenceRadiusM float64       // Max distance from the shift location before an event is flagged
+	LateGrace       time.Duration // Clock-ins up to this long after starts_at are not late
+	EarlyClockIn    time.Duration // How long before starts_at a worker may clock in
+
+	// Rotating QR check-in (second factor next to GPS)
+	QRSecret  []byte        // HMAC key for check-in tokens
+	QRPeriod  time.Duration // How often the displayed code rotates
+	RequireQR bool          // Reject clock-ins without a valid token
+}
+
+// DefaultAttendanceConfig returns the geofence and timing rules used in production
+func DefaultAttendanceConfig() AttendanceConfig {
+	return AttendanceConfig{
+		GeofenceRadiusM: 150,
+		LateGrace:       10 * time.Minute,
+		EarlyClockIn:    time.Hour,
+		QRPeriod:        30 * time.Second,
+	}
+}
+
+type AttendanceService struct {
+	attendanceRepo port.AttendanceRepository
+	shiftRepo      port.ShiftRepository
+	timesheetRepo  port.TimesheetRepository
+	wages          *WageRuleService
+	config         AttendanceConfig
+	signer         checkInSigner
+}
+
+func NewAttendanceService(
+	attendanceRepo port.AttendanceRepository,
+	shiftRepo port.ShiftRepository,
+	timesheetRepo port.TimesheetRepository,
+	wages *WageRuleService,
+	config AttendanceConfig,
+) *AttendanceService {
+	return &AttendanceService{
+		attendanceRepo: attendanceRepo,
+		shiftRepo:      shiftRepo,
+		timesheetRepo:  timesheetRepo,
+		wages:          wages,
+		config:         config,
+		signer:         checkInSigner{secret: config.QRSecret, period: config.QRPeriod},
+	}
+}
+
+// GetCheckInToken returns the QR token the business displays on site right now
+func (s *AttendanceService) GetCheckInToken(ctx context.Context, shiftID, requesterID int64) (*entity.CheckInToken, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != requesterID {
+		return nil, ErrUnauthorized
+	}
+	if shift.Status == entity.ShiftCancelled {
+		return nil, ErrShiftNotActive
+	}
+
+	roster, err := s.acceptedWorkers(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, expiresAt := s.signer.issue(shiftID, roster, time.Now())
+	return &entity.CheckInToken{
+		ShiftID:       shiftID,
+		Token:         token,
+		ExpiresAt:     expiresAt,
+		PeriodSeconds: int(s.config.QRPeriod.Seconds()),
+	}, nil
+}
+
+// ClockIn records that an accepted worker has arrived. Clock-ins outside the
+// geofence or after the grace period are recorded anyway and flagged; an
+// invalid or stale QR token is always refused.
+func (s *AttendanceService) ClockIn(ctx context.Context, shiftID, workerID int64, lat, lng float64, qrToken string) (*entity.AttendanceEvent, error) {
+	// 1. The worker must be accepted for a shift that is still running
+	shift, app, err := s.acceptedApplication(ctx, shiftID, workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if shift.StartsAt != nil && now.Before(shift.StartsAt.Add(-s.config.EarlyClockIn)) {
+		return nil, ErrClockInTooEarly
+	}
+	if shift.EndsAt != nil && now.After(*shift.EndsAt) {
+		return nil, ErrShiftAlreadyEnded
+	}
+
+	existing, err := s.attendanceRepo.GetAttendanceByApplication(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyClockedIn
+	}
+
+	// 2. Second factor: the rotating QR code shown on site
+	qrVerified := false
+	if qrToken != "" {
+		roster, err := s.acceptedWorkers(ctx, shiftID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.signer.verify(qrToken, shiftID, roster, now); err != nil {
+			return nil, err
+		}
+		qrVerified = true
+	} else if s.config.RequireQR {
+		return nil, ErrCheckInTokenRequired
+	}
+
+	// 3. Geofence and punctuality checks
+	distance := distanceKm(lat, lng, shift.Lat, shift.Lng) * 1000
+	attendance := &entity.Attendance{
+		ApplicationID:      app.ID,
+		ShiftID:            shift.ID,
+		WorkerID:           workerID,
+		ClockInAt:          now,
+		ClockInLat:         lat,
+		ClockInLng:         lng,
+		ClockInDistanceM:   math.Round(distance),
+		ClockInWithinFence: distance <= s.config.GeofenceRadiusM,
+		ClockInQRVerified:  qrVerified,
+	}
+
+	flags := []string{}
+	if !attendance.ClockInWithinFence {
+		flags = append(flags, entity.FlagOutOfFence)
+	}
+	if shift.StartsAt != nil && now.After(shift.StartsAt.Add(s.config.LateGrace)) {
+		attendance.LateMinutes = int(now.Sub(*shift.StartsAt).Minutes())
+		flags = append(flags, entity.FlagLate)
+	}
+
+	// 4. Save
+	if err := s.attendanceRepo.CreateClockIn(ctx, attendance); err != nil {
+		return nil, err
+	}
+
+	return &entity.AttendanceEvent{
+		Attendance: attendance,
+		Action:     ActionClockIn,
+		BusinessID: shift.OwnerID,
+		Flags:      flags,
+	}, nil
+}
+
+// ClockOut records that the worker has left and opens their timesheet for the
+// business to review. Clock-outs outside the geofence or well before ends_at
+// are recorded anyway and flagged.
+func (s *AttendanceService) ClockOut(ctx context.Context, shiftID, workerID int64, lat, lng float64, breakMinutes int) (*entity.AttendanceEvent, error) {
+	shift, app, err := s.acceptedApplication(ctx, shiftID, workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := s.attendanceRepo.GetAttendanceByApplication(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
+		return nil, ErrNotClockedIn
+	}
+	if attendance.ClockOutAt != nil {
+		return nil, ErrAlreadyClockedOut
+	}
+
+	now := time.Now()
+	if breakMinutes < 0 || float64(breakMinutes) > now.Sub(attendance.ClockInAt).Minutes() {
+		return nil, fmt.Errorf("%w: break_minutes must be between 0 and the time since clock-in", ErrInvalidTimesheet)
+	}
+
+	distance := math.Round(distanceKm(lat, lng, shift.Lat, shift.Lng) * 1000)
+	within := distance <= s.config.GeofenceRadiusM
+	attendance.ClockOutAt = &now
+	attendance.ClockOutLat = &lat
+	attendance.ClockOutLng = &lng
+	attendance.ClockOutDistanceM = &distance
+	attendance.ClockOutWithinFence = &within
+
+	flags := []string{}
+	if !within {
+		flags = append(flags, entity.FlagOutOfFence)
+	}
+	if shift.EndsAt != nil && now.Before(shift.EndsAt.Add(-s.config.LateGrace)) {
+		flags = append(flags, entity.FlagEarlyClockOut)
+	}
+
+	if err := s.attendanceRepo.UpdateClockOut(ctx, attendance); err != nil {
+		return nil, err
+	}
+
+	// Open the timesheet
+	ts, err := newTimesheet(attendance, shift, app.PayRate(*shift), breakMinutes, s.wages)
+	if err != nil {
+		return nil, err
+	}
+	entry := timesheetEntry(ts, workerID, entity.TimesheetActionCreate, "", "")
+	if err := s.timesheetRepo.CreateTimesheet(ctx, ts, entry); err != nil {
+		return nil, fmt.Errorf("clocked out but failed to create timesheet: %w", err)
+	}
+
+	return &entity.AttendanceEvent{
+		Attendance: attendance,
+		Action:     ActionClockOut,
+		BusinessID: shift.OwnerID,
+		Flags:      flags,
+		Timesheet:  ts,
+	}, nil
+}
+
+// GetShiftAttendance returns the attendance records of a shift (owner only)
+func (s *AttendanceService) GetShiftAttendance(ctx context.Context, shiftID, requesterID int64) ([]entity.Attendance, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != requesterID {
+		return nil, ErrUnauthorized
+	}
+	return s.attendanceRepo.GetAttendanceByShift(ctx, shiftID)
+}
+
+// GeofenceRadiusM exposes the configured radius so clients can show it
+func (s *AttendanceService) GeofenceRadiusM() float64 {
+	return s.config.GeofenceRadiusM
+}
+
+func (s *AttendanceService) acceptedApplication(ctx context.Context, shiftID, workerID int64) (*entity.Shift, *entity.Application, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, nil, ErrShiftNotFound
+	}
+	if shift.Status == entity.ShiftCancelled {
+		return nil, nil, ErrShiftNotActive
+	}
+
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load applications: %w", err)
+	}
+	for i := range apps {
+		if apps[i].WorkerID == workerID && apps[i].Status == entity.ApplicationAccepted {
+			return shift, &apps[i], nil
+		}
+	}
+	return nil, nil, ErrNotAcceptedForShift
+}
+
+// acceptedWorkers returns the roster a check-in token is bound to
+func (s *AttendanceService) acceptedWorkers(ctx context.Context, shiftID int64) ([]int64, error) {
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applications: %w", err)
+	}
+	var workers []int64
+	for _, app := range apps {
+		if app.Status == entity.ApplicationAccepted {
+			workers = append(workers, app.WorkerID)
+		}
+	}
+	return workers, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// defaultTimezone is used for workers who never picked one
+const defaultTimezone = "Asia/Jakarta"
+
+const minutesPerDay = 24 * 60
+
+// availabilityCovers reports whether a shift running from start to end fits the
+// worker's calendar: no blackout date on any day it touches and, if the worker
+// declared weekly windows, every day's part of the shift inside one window.
+func availabilityCovers(av *entity.WorkerAvailability, start, end time.Time) bool {
+	if av == nil {
+		return true
+	}
+	loc, err := time.LoadLocation(av.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(defaultTimezone)
+	}
+
+	blackouts := make(map[string]bool, len(av.Blackouts))
+	for _, b := range av.Blackouts {
+		blackouts[b.Date] = true
+	}
+
+	s, e := start.In(loc), end.In(loc)
+	day := time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, loc)
+	for day.Before(e) {
+		next := day.AddDate(0, 0, 1)
+		if blackouts[day.Format("2006-01-02")] {
+			return false
+		}
+
+		if len(av.Windows) > 0 {
+			// The slice of the shift that falls on this day
+			segStart, segEnd := s, e
+			if segStart.Before(day) {
+				segStart = day
+			}
+			if segEnd.After(next) {
+				segEnd = next
+			}
+			startMin := int(segStart.Sub(day).Minutes())
+			endMin := int(segEnd.Sub(day).Minutes())
+
+			if !windowCovers(av.Windows, int(day.Weekday()), startMin, endMin) {
+				return false
+			}
+		}
+		day = next
+	}
+
+	return true
+}
+
+func windowCovers(windows []entity.AvailabilityWindow, weekday, startMin, endMin int) bool {
+	for _, w := range windows {
+		if w.Weekday == weekday && w.StartMinute <= startMin && w.EndMinute >= endMin {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictingShift returns the first ACCEPTED application whose shift overlaps
+// the given schedule, ignoring the shift itself
+func conflictingShift(history []entity.Application, shiftID int64, start, end time.Time) *entity.Application {
+	for i := range history {
+		app := &history[i]
+		if app.ShiftID == shiftID || app.Status != entity.ApplicationAccepted || app.ShiftStartsAt == nil || app.ShiftEndsAt == nil {
+			continue
+		}
+		if overlaps(start, end, *app.ShiftStartsAt, *app.ShiftEndsAt) {
+			return app
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrBlocked       = errors.New("one of you has blocked the other")
+	ErrInvalidBlock  = errors.New("invalid block")
+	ErrBlockNotFound = errors.New("you haven't blocked this user")
+)
+
+// Defaults of the admin block pattern report
+const (
+	defaultPatternDays  = 90
+	defaultPatternCount = 3
+)
+
+// BlockService lets workers and businesses block each other after a bad
+// experience. A block works both ways: the worker stops seeing and applying
+// to the business's shifts, and the business can't invite, pool or accept
+// the worker.
+type BlockService struct {
+	blockRepo port.BlockRepository
+	userRepo  port.UserRepository
+}
+
+func NewBlockService(blockRepo port.BlockRepository, userRepo port.UserRepository) *BlockService {
+	return &BlockService{blockRepo: blockRepo, userRepo: userRepo}
+}
+
+// Block blocks a user of the other side (worker ↔ business)
+func (s *BlockService) Block(ctx context.Context, blockerID int64, blockerRole string, blockedID int64, reason string) (*entity.Block, error) {
+	if blockedID == blockerID {
+		return nil, fmt.Errorf("%w: you can't block yourself", ErrInvalidBlock)
+	}
+	blocked, err := s.userRepo.GetUserByID(ctx, blockedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	if blocked == nil {
+		return nil, fmt.Errorf("%w: user not found", ErrInvalidBlock)
+	}
+	switch {
+	case blockerRole == "worker" && blocked.Role == "business", blockerRole == "business" && blocked.Role == "worker":
+	default:
+		return nil, fmt.Errorf("%w: workers can block businesses and businesses can block workers", ErrInvalidBlock)
+	}
+
+	block := &entity.Block{BlockerID: blockerID, BlockedID: blockedID, Reason: strings.TrimSpace(reason)}
+	if err := s.blockRepo.CreateBlock(ctx, block); err != nil {
+		return nil, err
+	}
+	block.BlockedName = blocked.FullName
+	return block, nil
+}
+
+// Unblock lifts a block the user made (a block made by the other side stays)
+func (s *BlockService) Unblock(ctx context.Context, blockerID, blockedID int64) error {
+	deleted, err := s.blockRepo.DeleteBlock(ctx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBlockNotFound
+	}
+	return nil
+}
+
+// GetMyBlocks lists the users the user has blocked
+func (s *BlockService) GetMyBlocks(ctx context.Context, userID int64) ([]entity.Block, error) {
+	return s.blockRepo.GetBlocksBy(ctx, userID)
+}
+
+// BlockedWith returns the users with a block in either direction
+func (s *BlockService) BlockedWith(ctx context.Context, userID int64) (map[int64]bool, error) {
+	return s.blockRepo.GetBlockedWith(ctx, userID)
+}
+
+// CheckNotBlocked returns ErrBlocked if either user blocked the other
+func (s *BlockService) CheckNotBlocked(ctx context.Context, userID, otherID int64) error {
+	blocked, err := s.blockRepo.GetBlockedWith(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+	if blocked[otherID] {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// GetPatterns sums up blocks per user over the last days (admin), keeping
+// users blocked by or blocking at least minCount others
+func (s *BlockService) GetPatterns(ctx context.Context, days, minCount int) ([]entity.BlockPattern, error) {
+	if days <= 0 {
+		days = defaultPatternDays
+	}
+	if minCount <= 0 {
+		minCount = defaultPatternCount
+	}
+	return s.blockRepo.GetBlockPatterns(ctx, time.Now().AddDate(0, 0, -days), minCount)
+}
+
+// GetUserBlocks lists the blocks made by or against a user (admin)
+func (s *BlockService) GetUserBlocks(ctx context.Context, userID int64) ([]entity.Block, error) {
+	return s.blockRepo.GetBlocksInvolving(ctx, userID)
+}
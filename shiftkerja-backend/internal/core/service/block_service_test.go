@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakeUserRepo looks users up by ID
+type fakeUserRepo struct {
+	port.UserRepository
+	users map[int64]*entity.User
+}
+
+func (r *fakeUserRepo) GetUserByID(_ context.Context, id int64) (*entity.User, error) {
+	return r.users[id], nil
+}
+
+// CreateBlock blocks twice as one block, like the upsert it stands in for
+func (r *fakeBlockRepo) CreateBlock(_ context.Context, block *entity.Block) error {
+	for _, b := range r.blocks {
+		if b == [2]int64{block.BlockerID, block.BlockedID} {
+			return nil
+		}
+	}
+	r.blocks = append(r.blocks, [2]int64{block.BlockerID, block.BlockedID})
+	return nil
+}
+
+func (r *fakeBlockRepo) DeleteBlock(_ context.Context, blockerID, blockedID int64) (bool, error) {
+	for i, b := range r.blocks {
+		if b == [2]int64{blockerID, blockedID} {
+			r.blocks = append(r.blocks[:i], r.blocks[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newFakeBlocks() (*BlockService, *fakeBlockRepo) {
+	repo := &fakeBlockRepo{}
+	users := &fakeUserRepo{users: map[int64]*entity.User{
+		1:   {ID: 1, Role: "worker", FullName: "Ayu"},
+		2:   {ID: 2, Role: "worker", FullName: "Budi"},
+		100: {ID: 100, Role: "business", FullName: "Warung Sari"},
+		500: {ID: 500, Role: "admin", FullName: "Admin"},
+	}}
+	return NewBlockService(repo, users), repo
+}
+
+func TestBlock(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockerID int64
+		role      string
+		blockedID int64
+		want      error
+	}{
+		{"worker blocks a business", 1, "worker", 100, nil},
+		{"business blocks a worker", 100, "business", 1, nil},
+		{"worker blocks a worker", 1, "worker", 2, ErrInvalidBlock},
+		{"business blocks a business", 100, "business", 100, ErrInvalidBlock},
+		{"blocks an admin", 1, "worker", 500, ErrInvalidBlock},
+		{"admin blocks a worker", 500, "admin", 1, ErrInvalidBlock},
+		{"blocks themselves", 1, "worker", 1, ErrInvalidBlock},
+		{"unknown user", 1, "worker", 9, ErrInvalidBlock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newFakeBlocks()
+			block, err := s.Block(context.Background(), tt.blockerID, tt.role, tt.blockedID, "  no-show twice ")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Block() = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				if len(repo.blocks) != 0 {
+					t.Errorf("blocks stored = %v, want none", repo.blocks)
+				}
+				return
+			}
+			if block.Reason != "no-show twice" || block.BlockedName == "" {
+				t.Errorf("block = reason %q, name %q", block.Reason, block.BlockedName)
+			}
+		})
+	}
+}
+
+func TestBlocksWorkBothWays(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newFakeBlocks()
+	if _, err := s.Block(ctx, 1, "worker", 100, ""); err != nil {
+		t.Fatalf("Block() = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		userID, otherID int64
+		want            error
+	}{
+		{"blocker", 1, 100, ErrBlocked},
+		{"blocked", 100, 1, ErrBlocked},
+		{"someone else", 2, 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.CheckNotBlocked(ctx, tt.userID, tt.otherID); !errors.Is(err, tt.want) {
+				t.Errorf("CheckNotBlocked(%d, %d) = %v, want %v", tt.userID, tt.otherID, err, tt.want)
+			}
+		})
+	}
+
+	// Only the blocker can lift the block
+	if err := s.Unblock(ctx, 100, 1); !errors.Is(err, ErrBlockNotFound) {
+		t.Errorf("Unblock() by the blocked side = %v, want %v", err, ErrBlockNotFound)
+	}
+	if err := s.Unblock(ctx, 1, 100); err != nil {
+		t.Fatalf("Unblock() = %v", err)
+	}
+	if err := s.CheckNotBlocked(ctx, 100, 1); err != nil {
+		t.Errorf("CheckNotBlocked() after unblocking = %v, want nil", err)
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrCancellationReasonMissing = errors.New("a reason is required to cancel")
+	ErrApplicationNotCancellable = errors.New("only accepted applications can be cancelled, withdraw pending ones instead")
+	ErrCancelTooLate             = errors.New("the shift has already started and can no longer be cancelled")
+)
+
+// CancellationConfig tunes the cancellation policy
+type CancellationConfig struct {
+	// BusinessCutoff: a business cancelling less than this before the start
+	// owes each accepted worker compensation
+	BusinessCutoff time.Duration
+	// CompensationRate is the share of the scheduled pay owed, e.g. 0.5
+	CompensationRate float64
+}
+
+// DefaultCancellationConfig returns the policy used in production
+func DefaultCancellationConfig() CancellationConfig {
+	return CancellationConfig{
+		BusinessCutoff:   12 * time.Hour,
+		CompensationRate: 0.5,
+	}
+}
+
+// CancellationService calls off accepted work on either side. Workers
+// cancelling late are handled by the reliability policy, businesses
+// cancelling late owe compensation through the ledger.
+type CancellationService struct {
+	cancellationRepo port.CancellationRepository
+	shiftRepo        port.ShiftRepository
+	lifecycle        *LifecycleService
+	ledger           *LedgerService
+	reliability      *ReliabilityService
+	config           CancellationConfig
+}
+
+func NewCancellationService(
+	cancellationRepo port.CancellationRepository,
+	shiftRepo port.ShiftRepository,
+	lifecycle *LifecycleService,
+	ledger *LedgerService,
+	reliability *ReliabilityService,
+	config CancellationConfig,
+) *CancellationService {
+	return &CancellationService{
+		cancellationRepo: cancellationRepo,
+		shiftRepo:        shiftRepo,
+		lifecycle:        lifecycle,
+		ledger:           ledger,
+		reliability:      reliability,
+		config:           config,
+	}
+}
+
+// CancelShift lets a business call off a shift, filled or not. Every accepted
+// worker gets a cancellation record, with compensation inside the cutoff.
+func (s *CancellationService) CancelShift(ctx context.Context, shiftID, businessID int64, reason string) (*entity.CancellationResult, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrCancellationReasonMissing
+	}
+
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+	if err := CheckShiftTransition(shift.Status, entity.ShiftCancelled); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if started(shift, now) {
+		return nil, ErrCancelTooLate
+	}
+
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	notice := noticeMinutes(shift, now)
+	within := shift.StartsAt != nil && shift.StartsAt.Sub(now) < s.config.BusinessCutoff
+	result := &entity.CancellationResult{Shift: shift}
+	var cancellations []*entity.Cancellation
+	for _, app := range apps {
+		if CheckApplicationTransition(app.Status, entity.ApplicationCancelled) != nil {
+			continue
+		}
+		result.Affected = append(result.Affected, app.WorkerID)
+		if app.Status != entity.ApplicationAccepted {
+			continue
+		}
+		appID, workerID := app.ID, app.WorkerID
+		c := s.newCancellation(shift, businessID, entity.CancelledByBusiness, reason, notice, within)
+		c.ApplicationID, c.WorkerID = &appID, &workerID
+		if within {
+			c.Compensation = s.compensation(shift, app.PayRate(*shift))
+		}
+		cancellations = append(cancellations, c)
+	}
+	if len(cancellations) == 0 {
+		// Nobody was accepted yet, keep the reason anyway
+		cancellations = append(cancellations, s.newCancellation(shift, businessID, entity.CancelledByBusiness, reason, notice, within))
+	}
+
+	cancelled, err := s.cancellationRepo.CancelShift(ctx, shiftID, cancellations)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, ErrStatusChanged
+	}
+	shift.Status = entity.ShiftCancelled
+	s.lifecycle.SyncGeo(ctx, shift)
+
+	for _, c := range cancellations {
+		// A failure here is retried by the payout run (PostPendingCompensations)
+		if err := s.ledger.RecordCompensation(ctx, c); err != nil {
+			fmt.Printf("⚠️ Compensation warning: %v\n", err)
+		}
+		result.Cancellations = append(result.Cancellations, *c)
+	}
+	return result, nil
+}
+
+// CancelSpot lets a worker give up an accepted spot. The shift is reopened
+// and put back on the map, and the pending applicants are returned so they
+// can be told the spot is free again.
+func (s *CancellationService) CancelSpot(ctx context.Context, applicationID, workerID int64, reason string) (*entity.CancellationResult, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrCancellationReasonMissing
+	}
+
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	if app.WorkerID != workerID {
+		return nil, ErrUnauthorized
+	}
+	if app.Status != entity.ApplicationAccepted {
+		return nil, ErrApplicationNotCancellable
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	now := time.Now()
+	if started(shift, now) {
+		return nil, ErrCancelTooLate
+	}
+
+	c := s.newCancellation(shift, workerID, entity.CancelledByWorker, reason, noticeMinutes(shift, now), s.reliability.IsLateWithdrawal(shift, now))
+	c.ApplicationID, c.WorkerID = &app.ID, &app.WorkerID
+	reopen := shift.Status == entity.ShiftFilled
+
+	cancelled, err := s.cancellationRepo.CancelSpot(ctx, c, reopen)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, ErrStatusChanged
+	}
+
+	if err := s.reliability.RecordWithdrawal(ctx, app, shift, now); err != nil {
+		fmt.Printf("⚠️ Reliability warning: %v\n", err)
+	}
+
+	result := &entity.CancellationResult{Shift: shift, Cancellations: []entity.Cancellation{*c}}
+	if reopen {
+		shift.Status = entity.ShiftOpen
+		result.Reopened = true
+		s.lifecycle.SyncGeo(ctx, shift)
+
+		// The spot is cancelled already; only the waitlist notice is lost
+		apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shift.ID)
+		if err != nil {
+			fmt.Printf("⚠️ Waitlist lookup warning for shift %d: %v\n", shift.ID, err)
+		}
+		for _, a := range apps {
+			if a.Status == entity.ApplicationPending {
+				result.Waitlist = append(result.Waitlist, a.WorkerID)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetCancellations lists the cancellations of a shift (business owner only)
+func (s *CancellationService) GetCancellations(ctx context.Context, shiftID, requesterID int64) ([]entity.Cancellation, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != requesterID {
+		return nil, ErrUnauthorized
+	}
+	return s.cancellationRepo.GetCancellationsByShift(ctx, shiftID)
+}
+
+func (s *CancellationService) newCancellation(shift *entity.Shift, actorID int64, side, reason string, notice *int, within bool) *entity.Cancellation {
+	return &entity.Cancellation{
+		ShiftID:       shift.ID,
+		CancelledBy:   actorID,
+		Side:          side,
+		Reason:        reason,
+		NoticeMinutes: notice,
+		WithinCutoff:  within,
+		Compensation:  entity.NewMoney(0, shift.PayRate.Currency),
+		BusinessID:    shift.OwnerID,
+		ShiftTitle:    shift.Title,
+	}
+}
+
+// compensation is the configured share of the pay the worker was scheduled to
+// earn at their rate
+func (s *CancellationService) compensation(shift *entity.Shift, rate entity.Money) entity.Money {
+	scheduled := rate
+	if shift.PayUnit != entity.PayPerShift {
+		if shift.StartsAt == nil || shift.EndsAt == nil {
+			return entity.NewMoney(0, rate.Currency)
+		}
+		minutes := int64(shift.EndsAt.Sub(*shift.StartsAt) / time.Minute)
+		scheduled = rate.MulDiv(minutes, 60)
+	}
+	return scheduled.MulDiv(int64(math.Round(s.config.CompensationRate*10000)), 10000)
+}
+
+func started(shift *entity.Shift, now time.Time) bool {
+	return shift.StartsAt != nil && !now.Before(*shift.StartsAt)
+}
+
+func noticeMinutes(shift *entity.Shift, now time.Time) *int {
+	if shift.StartsAt == nil {
+		return nil
+	}
+	minutes := int(shift.StartsAt.Sub(now) / time.Minute)
+	return &minutes
+}
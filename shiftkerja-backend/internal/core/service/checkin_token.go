@@ -0,0 +1,78 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCheckInTokenRequired = errors.New("a QR check-in token is required")
+	ErrInvalidCheckInToken  = errors.New("invalid QR check-in token")
+	ErrExpiredCheckInToken  = errors.New("QR check-in token has expired, scan the current code")
+)
+
+// checkInSigner issues and verifies TOTP-style check-in tokens. A token is
+// "<shift id>.<time step>.<signature>", where the signature is an HMAC over
+// the shift, the step and the sorted list of accepted workers. Changing the
+// roster therefore invalidates codes that are already on display.
+type checkInSigner struct {
+	secret []byte
+	period time.Duration
+}
+
+// issue returns the token for the current time step and when it stops being valid
+func (c checkInSigner) issue(shiftID int64, acceptedWorkers []int64, now time.Time) (string, time.Time) {
+	step := now.Unix() / int64(c.period.Seconds())
+	token := fmt.Sprintf("%d.%d.%s", shiftID, step, c.sign(shiftID, step, acceptedWorkers))
+	expiresAt := time.Unix((step+1)*int64(c.period.Seconds()), 0)
+	return token, expiresAt
+}
+
+// verify accepts a token of the current or the previous step (so a code
+// scanned just before it rotates still works) and refuses anything older.
+func (c checkInSigner) verify(token string, shiftID int64, acceptedWorkers []int64, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidCheckInToken
+	}
+	tokenShift, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || tokenShift != shiftID {
+		return ErrInvalidCheckInToken
+	}
+	step, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ErrInvalidCheckInToken
+	}
+
+	expected := c.sign(shiftID, step, acceptedWorkers)
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return ErrInvalidCheckInToken
+	}
+
+	current := now.Unix() / int64(c.period.Seconds())
+	if step > current || step < current-1 {
+		return ErrExpiredCheckInToken
+	}
+	return nil
+}
+
+func (c checkInSigner) sign(shiftID, step int64, acceptedWorkers []int64) string {
+	workers := append([]int64(nil), acceptedWorkers...)
+	sort.Slice(workers, func(i, j int) bool { return workers[i] < workers[j] })
+
+	roster := make([]string, len(workers))
+	for i, id := range workers {
+		roster[i] = strconv.FormatInt(id, 10)
+	}
+
+	mac := hmac.New(sha256.New, c.secret)
+	fmt.Fprintf(mac, "%d|%d|%s", shiftID, step, strings.Join(roster, ","))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:18])
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckInSignerVerify(t *testing.T) {
+	signer := checkInSigner{secret: []byte("test-secret"), period: 30 * time.Second}
+	roster := []int64{7, 3}
+	issuedAt := time.Unix(1_800_000_000, 0) // On a step boundary
+
+	token, expiresAt := signer.issue(42, roster, issuedAt)
+	if want := issuedAt.Add(30 * time.Second); !expiresAt.Equal(want) {
+		t.Fatalf("expiresAt = %v, want %v", expiresAt, want)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		shiftID int64
+		roster  []int64
+		now     time.Time
+		want    error
+	}{
+		{"same step", token, 42, roster, issuedAt.Add(29 * time.Second), nil},
+		{"roster order doesn't matter", token, 42, []int64{3, 7}, issuedAt, nil},
+		{"previous step is still accepted", token, 42, roster, issuedAt.Add(59 * time.Second), nil},
+		{"two steps old", token, 42, roster, issuedAt.Add(60 * time.Second), ErrExpiredCheckInToken},
+		{"from a future step", token, 42, roster, issuedAt.Add(-time.Second), ErrExpiredCheckInToken},
+		{"other shift", token, 43, roster, issuedAt, ErrInvalidCheckInToken},
+		{"roster changed", token, 42, []int64{3, 7, 9}, issuedAt, ErrInvalidCheckInToken},
+		{"tampered step", strings.Replace(token, ".60000000.", ".60000001.", 1), 42, roster, issuedAt, ErrInvalidCheckInToken},
+		{"malformed", "42.60000000", 42, roster, issuedAt, ErrInvalidCheckInToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := signer.verify(tt.token, tt.shiftID, tt.roster, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	other := checkInSigner{secret: []byte("other-secret"), period: 30 * time.Second}
+	if err := other.verify(token, 42, roster, issuedAt); !errors.Is(err, ErrInvalidCheckInToken) {
+		t.Errorf("verify() with another secret = %v, want %v", err, ErrInvalidCheckInToken)
+	}
+}
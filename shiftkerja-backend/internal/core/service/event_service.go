@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrEventNotFound = errors.New("event not found")
+	ErrInvalidEvent  = errors.New("invalid event")
+)
+
+// maxEventPositions caps the positions of one event
+const maxEventPositions = 20
+
+// EventService manages events: one posting staffing several positions (e.g.
+// waiters, bartenders and a supervisor for a wedding). Every position is a
+// shift at the event's place and time with its own headcount, pay, required
+// skills and applications, so applying, accepting and paying work as for any
+// other shift. On the map an event is shown once (see
+// ShiftService.GetNearbyShifts).
+type EventService struct {
+	eventRepo    port.EventRepository
+	taxonomyRepo port.TaxonomyRepository
+	shifts       *ShiftService
+	pools        *PoolService
+}
+
+func NewEventService(eventRepo port.EventRepository, taxonomyRepo port.TaxonomyRepository, shifts *ShiftService, pools *PoolService) *EventService {
+	return &EventService{eventRepo: eventRepo, taxonomyRepo: taxonomyRepo, shifts: shifts, pools: pools}
+}
+
+// CreateEvent creates an event with its positions. Every position is checked
+// before anything is written.
+func (s *EventService) CreateEvent(ctx context.Context, event *entity.Event, positions []entity.Shift) (*entity.Event, error) {
+	event.Title = strings.TrimSpace(event.Title)
+	if event.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidEvent)
+	}
+	if len(positions) == 0 || len(positions) > maxEventPositions {
+		return nil, fmt.Errorf("%w: an event has 1 to %d positions", ErrInvalidEvent, maxEventPositions)
+	}
+	if !validSchedule(&entity.Shift{StartsAt: event.StartsAt, EndsAt: event.EndsAt}) {
+		return nil, ErrInvalidSchedule
+	}
+	if event.LocationID != nil {
+		location, err := s.shifts.findLocation(ctx, *event.LocationID, event.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		event.Lat, event.Lng = location.Lat, location.Lng
+	}
+
+	drafts := make([]*shiftDraft, len(positions))
+	for i := range positions {
+		placePosition(event, &positions[i])
+		draft, err := s.shifts.prepareShift(ctx, &positions[i])
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", i+1, err)
+		}
+		drafts[i] = draft
+	}
+
+	// The event and all its positions are written together or not at all
+	for i := range positions {
+		positions[i].RequiredSkills = drafts[i].skills
+		positions[i].ScreeningQuestions = drafts[i].questions
+	}
+	if err := s.eventRepo.CreateEvent(ctx, event, positions); err != nil {
+		return nil, err
+	}
+	event.Positions = make([]entity.PositionAvailability, 0, len(positions))
+	for i := range positions {
+		s.shifts.indexShift(ctx, &positions[i], drafts[i])
+		event.Positions = append(event.Positions, entity.NewPositionAvailability(positions[i], 0))
+	}
+	return event, nil
+}
+
+// AddPosition adds a position to an event (owner only)
+func (s *EventService) AddPosition(ctx context.Context, eventID, businessID int64, position *entity.Shift) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+	if event.OwnerID != businessID {
+		return ErrUnauthorized
+	}
+	positions, err := s.eventRepo.GetPositions(ctx, []int64{eventID})
+	if err != nil {
+		return err
+	}
+	if len(positions) >= maxEventPositions {
+		return fmt.Errorf("%w: an event has at most %d positions", ErrInvalidEvent, maxEventPositions)
+	}
+
+	placePosition(event, position)
+	draft, err := s.shifts.prepareShift(ctx, position)
+	if err != nil {
+		return err
+	}
+	position.EventID = &event.ID
+	return s.shifts.saveShift(ctx, position, draft)
+}
+
+// GetEvent returns an event with the availability of the positions the user
+// may see. An event none of whose positions the user may see is reported as
+// not found.
+func (s *EventService) GetEvent(ctx context.Context, eventID, userID int64, role string) (*entity.Event, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	positions, err := s.eventRepo.GetPositions(ctx, []int64{eventID})
+	if err != nil {
+		return nil, err
+	}
+	if event.OwnerID != userID && role != "admin" {
+		if positions, err = s.pools.FilterVisible(ctx, userID, role, positions); err != nil {
+			return nil, err
+		}
+		if len(positions) == 0 {
+			return nil, ErrEventNotFound
+		}
+	}
+
+	if err := s.attachPositions(ctx, []*entity.Event{event}, positions); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetMyEvents retrieves the events of a business with all their positions
+func (s *EventService) GetMyEvents(ctx context.Context, ownerID int64) ([]entity.Event, error) {
+	events, err := s.eventRepo.GetEventsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	ids := make([]int64, len(events))
+	refs := make([]*entity.Event, len(events))
+	for i := range events {
+		ids[i] = events[i].ID
+		refs[i] = &events[i]
+	}
+	positions, err := s.eventRepo.GetPositions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachPositions(ctx, refs, positions); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// placePosition puts a position at the event's place and time
+func placePosition(event *entity.Event, position *entity.Shift) {
+	position.OwnerID = event.OwnerID
+	position.Lat, position.Lng, position.LocationID = event.Lat, event.Lng, event.LocationID
+	position.StartsAt, position.EndsAt = event.StartsAt, event.EndsAt
+}
+
+// attachPositions fills in the availability of the events' positions, with
+// their required skills
+func (s *EventService) attachPositions(ctx context.Context, events []*entity.Event, positions []entity.Shift) error {
+	ids := make([]int64, len(positions))
+	for i := range positions {
+		ids[i] = positions[i].ID
+	}
+	skills, err := s.taxonomyRepo.GetShiftSkills(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load required skills: %w", err)
+	}
+	accepted, err := s.eventRepo.CountAccepted(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.Positions = []entity.PositionAvailability{}
+		for _, position := range positions {
+			if position.EventID == nil || *position.EventID != event.ID {
+				continue
+			}
+			position.RequiredSkills = skills[position.ID]
+			event.Positions = append(event.Positions, entity.NewPositionAvailability(position, accepted[position.ID]))
+		}
+	}
+	return nil
+}
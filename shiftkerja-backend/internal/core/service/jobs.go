@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunEvery runs job once immediately and then on every tick until ctx is cancelled.
+// Errors are logged and the job keeps its schedule.
+func RunEvery(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			fmt.Printf("⚠️ Job %s failed: %v\n", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("🛑 Job %s stopped\n", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidAdjustment   = errors.New("invalid adjustment")
+	ErrInvalidMonth        = errors.New("month must be YYYY-MM")
+	ErrInvalidBankAccount  = errors.New("invalid bank account")
+	ErrPayoutBatchNotFound = errors.New("payout batch not found")
+	ErrUnknownPayoutFormat = errors.New("unknown payout bank format")
+)
+
+var (
+	bankCodePattern      = regexp.MustCompile(`^[0-9]{3}$`)
+	accountNumberPattern = regexp.MustCompile(`^[0-9]{5,20}$`)
+)
+
+// LedgerConfig tunes fees and payout exports
+type LedgerConfig struct {
+	FeeRate        float64 // Share of gross earnings kept by the platform, e.g. 0.10
+	BankFormat     string  // Payout file layout, see adapter/payout
+	PayoutCurrency string  // Balances in other currencies are not paid out by bank transfer
+	ExportDir      string  // If set, payout files are also written here
+}
+
+// DefaultLedgerConfig returns the fee and export settings used in production
+func DefaultLedgerConfig() LedgerConfig {
+	return LedgerConfig{
+		FeeRate:        0.10,
+		BankFormat:     "generic",
+		PayoutCurrency: entity.DefaultCurrency,
+	}
+}
+
+type LedgerService struct {
+	ledgerRepo port.LedgerRepository
+	formats    map[string]port.PayoutFormatter
+	config     LedgerConfig
+}
+
+func NewLedgerService(ledgerRepo port.LedgerRepository, formats map[string]port.PayoutFormatter, config LedgerConfig) *LedgerService {
+	return &LedgerService{
+		ledgerRepo: ledgerRepo,
+		formats:    formats,
+		config:     config,
+	}
+}
+
+// RecordEarnings posts an approved timesheet: the business owes the gross pay,
+// the worker is owed the gross pay minus the platform fee. Posting is
+// idempotent, so it is safe to call again for the same timesheet.
+func (s *LedgerService) RecordEarnings(ctx context.Context, ts *entity.Timesheet) error {
+	if !ts.PayAmount.IsPositive() {
+		return nil
+	}
+	timesheetID := ts.ID
+
+	txns := []*entity.LedgerTransaction{{
+		Kind:        entity.LedgerEarning,
+		TimesheetID: &timesheetID,
+		Description: fmt.Sprintf("%s (%d min)", ts.ShiftTitle, ts.WorkedMinutes),
+		Entries: []entity.LedgerEntry{
+			{AccountKind: entity.AccountBusinessReceivable, OwnerID: ts.BusinessID, Amount: ts.PayAmount},
+			{AccountKind: entity.AccountWorkerPayable, OwnerID: ts.WorkerID, Amount: ts.PayAmount.Neg()},
+		},
+	}}
+
+	if fee := ts.PayAmount.MulDiv(s.feeBasisPoints(), 10000); fee.IsPositive() {
+		txns = append(txns, &entity.LedgerTransaction{
+			Kind:        entity.LedgerFee,
+			TimesheetID: &timesheetID,
+			Description: fmt.Sprintf("Platform fee %.0f%%", s.config.FeeRate*100),
+			Entries: []entity.LedgerEntry{
+				{AccountKind: entity.AccountWorkerPayable, OwnerID: ts.WorkerID, Amount: fee},
+				{AccountKind: entity.AccountPlatformRevenue, Amount: fee.Neg()},
+			},
+		})
+	}
+
+	if err := checkBalanced(txns...); err != nil {
+		return err
+	}
+	if _, err := s.ledgerRepo.PostTransactions(ctx, txns...); err != nil {
+		return fmt.Errorf("failed to post earnings of timesheet %d: %w", ts.ID, err)
+	}
+	return nil
+}
+
+// PostApprovedTimesheets catches up on approved timesheets that aren't in the ledger yet
+func (s *LedgerService) PostApprovedTimesheets(ctx context.Context) error {
+	timesheets, err := s.ledgerRepo.GetUnpostedTimesheets(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range timesheets {
+		if err := s.RecordEarnings(ctx, &timesheets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordCompensation posts what a business owes a worker for cancelling late.
+// Like earnings it is idempotent; no platform fee is taken.
+func (s *LedgerService) RecordCompensation(ctx context.Context, c *entity.Cancellation) error {
+	if !c.Compensation.IsPositive() || c.WorkerID == nil {
+		return nil
+	}
+	cancellationID := c.ID
+	txn := &entity.LedgerTransaction{
+		Kind:           entity.LedgerCompensation,
+		CancellationID: &cancellationID,
+		Description:    fmt.Sprintf("Late cancellation: %s", c.ShiftTitle),
+		Entries: []entity.LedgerEntry{
+			{AccountKind: entity.AccountBusinessReceivable, OwnerID: c.BusinessID, Amount: c.Compensation},
+			{AccountKind: entity.AccountWorkerPayable, OwnerID: *c.WorkerID, Amount: c.Compensation.Neg()},
+		},
+	}
+	if err := checkBalanced(txn); err != nil {
+		return err
+	}
+	if _, err := s.ledgerRepo.PostTransactions(ctx, txn); err != nil {
+		return fmt.Errorf("failed to post compensation of cancellation %d: %w", c.ID, err)
+	}
+	return nil
+}
+
+// PostPendingCompensations catches up on compensations that aren't in the ledger yet
+func (s *LedgerService) PostPendingCompensations(ctx context.Context) error {
+	cancellations, err := s.ledgerRepo.GetUnpostedCompensations(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range cancellations {
+		if err := s.RecordCompensation(ctx, &cancellations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddAdjustment credits (positive amount) or debits (negative amount) a worker
+func (s *LedgerService) AddAdjustment(ctx context.Context, adminID, workerID int64, amount entity.Money, description string) (*entity.LedgerTransaction, error) {
+	description = strings.TrimSpace(description)
+	if workerID <= 0 {
+		return nil, fmt.Errorf("%w: worker_id is required", ErrInvalidAdjustment)
+	}
+	if amount.IsZero() {
+		return nil, fmt.Errorf("%w: amount cannot be zero", ErrInvalidAdjustment)
+	}
+	if !entity.ValidCurrency(amount.Currency) {
+		return nil, fmt.Errorf("%w: unknown currency %q", ErrInvalidAdjustment, amount.Currency)
+	}
+	if description == "" {
+		return nil, fmt.Errorf("%w: description is required", ErrInvalidAdjustment)
+	}
+
+	txn := &entity.LedgerTransaction{
+		Kind:        entity.LedgerAdjustment,
+		Description: description,
+		CreatedBy:   &adminID,
+		Entries: []entity.LedgerEntry{
+			{AccountKind: entity.AccountPlatformAdjustments, Amount: amount},
+			{AccountKind: entity.AccountWorkerPayable, OwnerID: workerID, Amount: amount.Neg()},
+		},
+	}
+	if _, err := s.ledgerRepo.PostTransactions(ctx, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// GetEarnings summarises a worker's month (YYYY-MM, default: current month) in one currency
+func (s *LedgerService) GetEarnings(ctx context.Context, workerID int64, month, currency string) (*entity.EarningsSummary, error) {
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+	if !entity.ValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: %q", entity.ErrUnknownCurrency, currency)
+	}
+
+	loc, _ := time.LoadLocation(defaultTimezone)
+	var from time.Time
+	if month == "" {
+		now := time.Now().In(loc)
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	} else {
+		parsed, err := time.ParseInLocation("2006-01", month, loc)
+		if err != nil {
+			return nil, ErrInvalidMonth
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 1, 0)
+
+	entries, err := s.ledgerRepo.GetAccountEntries(ctx, entity.AccountWorkerPayable, workerID, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.ledgerRepo.GetAccountBalance(ctx, entity.AccountWorkerPayable, workerID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payable account is a liability: credits (negative) are money for the worker
+	var gross, fees, adjustments, compensation, paidOut int64
+	for _, e := range entries {
+		switch e.TransactionKind {
+		case entity.LedgerEarning:
+			gross -= e.Amount.Minor
+		case entity.LedgerFee:
+			fees += e.Amount.Minor
+		case entity.LedgerAdjustment:
+			adjustments -= e.Amount.Minor
+		case entity.LedgerCompensation:
+			compensation -= e.Amount.Minor
+		case entity.LedgerPayout:
+			paidOut += e.Amount.Minor
+		}
+	}
+	summary := &entity.EarningsSummary{
+		WorkerID:      workerID,
+		Month:         from.Format("2006-01"),
+		Currency:      currency,
+		Gross:         entity.NewMoney(gross, currency),
+		Fees:          entity.NewMoney(fees, currency),
+		Adjustments:   entity.NewMoney(adjustments, currency),
+		Compensation:  entity.NewMoney(compensation, currency),
+		Net:           entity.NewMoney(gross-fees+adjustments+compensation, currency),
+		PaidOut:       entity.NewMoney(paidOut, currency),
+		UnpaidBalance: balance.Neg(),
+		Entries:       entries,
+	}
+	if summary.Entries == nil {
+		summary.Entries = []entity.LedgerEntry{}
+	}
+	return summary, nil
+}
+
+// RunPayoutBatch pays out every worker with a positive unpaid balance and bank
+// details: it writes the bulk-transfer file and marks the entries as paid.
+// It returns nil when there is nothing to pay.
+func (s *LedgerService) RunPayoutBatch(ctx context.Context) (*entity.PayoutBatch, error) {
+	formatter, ok := s.formats[s.config.BankFormat]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPayoutFormat, s.config.BankFormat)
+	}
+
+	if err := s.PostApprovedTimesheets(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.PostPendingCompensations(ctx); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.ledgerRepo.GetPayableLines(ctx, s.config.PayoutCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	batch := &entity.PayoutBatch{
+		BankFormat:  formatter.Code(),
+		Status:      "EXPORTED",
+		TotalAmount: entity.NewMoney(0, s.config.PayoutCurrency),
+		LineCount:   len(lines),
+	}
+	for _, l := range lines {
+		if batch.TotalAmount, err = batch.TotalAmount.Add(l.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	render := func(b *entity.PayoutBatch) error {
+		var buf bytes.Buffer
+		if err := formatter.Write(&buf, b, lines); err != nil {
+			return fmt.Errorf("failed to write payout file: %w", err)
+		}
+		b.FileName = formatter.FileName(b)
+		b.FileContent = buf.String()
+		return nil
+	}
+	if err := s.ledgerRepo.CreatePayoutBatch(ctx, batch, lines, render); err != nil {
+		return nil, err
+	}
+
+	if s.config.ExportDir != "" {
+		path := filepath.Join(s.config.ExportDir, batch.FileName)
+		if err := os.WriteFile(path, []byte(batch.FileContent), 0o600); err != nil {
+			// The batch is saved and can still be downloaded
+			fmt.Printf("⚠️ Payout file export warning: %v\n", err)
+		}
+	}
+
+	fmt.Printf("💸 Payout batch %d: %d transfers, total %s\n", batch.ID, batch.LineCount, batch.TotalAmount)
+	return batch, nil
+}
+
+// PayoutJob is RunPayoutBatch in the shape the scheduler expects
+func (s *LedgerService) PayoutJob(ctx context.Context) error {
+	_, err := s.RunPayoutBatch(ctx)
+	return err
+}
+
+// GetPayoutBatches lists all payout batches
+func (s *LedgerService) GetPayoutBatches(ctx context.Context) ([]entity.PayoutBatch, error) {
+	return s.ledgerRepo.GetPayoutBatches(ctx)
+}
+
+// GetPayoutBatch returns a payout batch including its file
+func (s *LedgerService) GetPayoutBatch(ctx context.Context, batchID int64) (*entity.PayoutBatch, error) {
+	batch, err := s.ledgerRepo.GetPayoutBatchByID(ctx, batchID)
+	if err != nil {
+		return nil, ErrPayoutBatchNotFound
+	}
+	return batch, nil
+}
+
+// GetBankAccount returns the worker's payout details (nil if none saved)
+func (s *LedgerService) GetBankAccount(ctx context.Context, workerID int64) (*entity.WorkerBankAccount, error) {
+	return s.ledgerRepo.GetBankAccount(ctx, workerID)
+}
+
+// UpdateBankAccount validates and saves the worker's payout details
+func (s *LedgerService) UpdateBankAccount(ctx context.Context, account *entity.WorkerBankAccount) error {
+	account.AccountNumber = strings.ReplaceAll(strings.TrimSpace(account.AccountNumber), " ", "")
+	account.AccountHolder = strings.TrimSpace(account.AccountHolder)
+	switch {
+	case !bankCodePattern.MatchString(account.BankCode):
+		return fmt.Errorf("%w: bank_code must be the 3-digit bank code, e.g. 014 for BCA", ErrInvalidBankAccount)
+	case !accountNumberPattern.MatchString(account.AccountNumber):
+		return fmt.Errorf("%w: account_number must be 5 to 20 digits", ErrInvalidBankAccount)
+	case account.AccountHolder == "":
+		return fmt.Errorf("%w: account_holder is required", ErrInvalidBankAccount)
+	}
+	return s.ledgerRepo.UpsertBankAccount(ctx, account)
+}
+
+// feeBasisPoints is the fee rate in hundredths of a percent, so fees are computed exactly
+func (s *LedgerService) feeBasisPoints() int64 {
+	return int64(math.Round(s.config.FeeRate * 10000))
+}
+
+// checkBalanced refuses transactions whose entries don't sum to zero in a single currency
+func checkBalanced(txns ...*entity.LedgerTransaction) error {
+	for _, txn := range txns {
+		if len(txn.Entries) == 0 {
+			continue
+		}
+		sum := entity.NewMoney(0, txn.Entries[0].Amount.Currency)
+		for _, e := range txn.Entries {
+			var err error
+			if sum, err = sum.Add(e.Amount); err != nil {
+				return fmt.Errorf("%s transaction: %w", txn.Kind, err)
+			}
+		}
+		if !sum.IsZero() {
+			return fmt.Errorf("unbalanced %s transaction: entries sum to %s", txn.Kind, sum)
+		}
+	}
+	return nil
+}
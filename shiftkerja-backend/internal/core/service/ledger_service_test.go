@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakeLedgerRepo keeps posted transactions and settles payable lines once a
+// payout batch is created
+type fakeLedgerRepo struct {
+	port.LedgerRepository
+	posted  []*entity.LedgerTransaction
+	lines   []entity.PayoutLine
+	paid    map[int64]bool // Entry → settled
+	batches int64
+}
+
+func (r *fakeLedgerRepo) PostTransactions(_ context.Context, txns ...*entity.LedgerTransaction) (bool, error) {
+	for _, txn := range txns {
+		for _, p := range r.posted {
+			if txn.TimesheetID != nil && p.TimesheetID != nil && *txn.TimesheetID == *p.TimesheetID && txn.Kind == p.Kind {
+				return false, nil
+			}
+		}
+	}
+	r.posted = append(r.posted, txns...)
+	return true, nil
+}
+
+func (r *fakeLedgerRepo) GetUnpostedTimesheets(context.Context) ([]entity.Timesheet, error) {
+	return nil, nil
+}
+
+func (r *fakeLedgerRepo) GetUnpostedCompensations(context.Context) ([]entity.Cancellation, error) {
+	return nil, nil
+}
+
+func (r *fakeLedgerRepo) GetPayableLines(_ context.Context, currency string) ([]entity.PayoutLine, error) {
+	var lines []entity.PayoutLine
+	for _, l := range r.lines {
+		if l.Amount.Currency == currency && !r.paid[l.EntryIDs[0]] {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+func (r *fakeLedgerRepo) CreatePayoutBatch(_ context.Context, batch *entity.PayoutBatch, lines []entity.PayoutLine, render func(*entity.PayoutBatch) error) error {
+	batch.ID = r.batches + 1
+	if err := render(batch); err != nil {
+		return err
+	}
+	r.batches = batch.ID
+	for _, l := range lines {
+		for _, id := range l.EntryIDs {
+			r.paid[id] = true
+		}
+	}
+	return nil
+}
+
+// fakeBankRepo keeps the last saved bank account
+type fakeBankRepo struct {
+	port.LedgerRepository
+	saved *entity.WorkerBankAccount
+}
+
+func (r *fakeBankRepo) UpsertBankAccount(_ context.Context, account *entity.WorkerBankAccount) error {
+	r.saved = account
+	return nil
+}
+
+// fakePayoutFormat writes one "worker;amount" row per transfer
+type fakePayoutFormat struct {
+	fail bool
+}
+
+func (fakePayoutFormat) Code() string { return "test" }
+
+func (fakePayoutFormat) FileName(batch *entity.PayoutBatch) string {
+	return fmt.Sprintf("payout-%d.txt", batch.ID)
+}
+
+func (f fakePayoutFormat) Write(w io.Writer, batch *entity.PayoutBatch, lines []entity.PayoutLine) error {
+	if f.fail {
+		return errors.New("disk full")
+	}
+	for _, l := range lines {
+		fmt.Fprintf(w, "%d;%s\n", l.WorkerID, l.Amount.Decimal())
+	}
+	return nil
+}
+
+func TestCheckBalanced(t *testing.T) {
+	entries := func(amounts ...entity.Money) *entity.LedgerTransaction {
+		txn := &entity.LedgerTransaction{Kind: entity.LedgerAdjustment}
+		for _, a := range amounts {
+			txn.Entries = append(txn.Entries, entity.LedgerEntry{Amount: a})
+		}
+		return txn
+	}
+
+	tests := []struct {
+		name    string
+		txns    []*entity.LedgerTransaction
+		wantErr bool
+	}{
+		{"balanced", []*entity.LedgerTransaction{entries(idr(100), idr(-100))}, false},
+		{"three ways", []*entity.LedgerTransaction{entries(idr(100), idr(-90), idr(-10))}, false},
+		{"no entries", []*entity.LedgerTransaction{entries()}, false},
+		{"off by one minor unit", []*entity.LedgerTransaction{entries(idr(100), entity.NewMoney(-9999, "IDR"))}, true},
+		{"mixed currencies", []*entity.LedgerTransaction{entries(idr(100), entity.NewMoney(-10000, "USD"))}, true},
+		{"one bad among good", []*entity.LedgerTransaction{entries(idr(1), idr(-1)), entries(idr(1))}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkBalanced(tt.txns...); (err != nil) != tt.wantErr {
+				t.Errorf("checkBalanced() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordEarnings(t *testing.T) {
+	const worker, business = 1, 100
+
+	tests := []struct {
+		name      string
+		feeRate   float64
+		pay       entity.Money
+		wantTxns  int
+		wantFee   int64 // Minor units
+		wantOwed  int64 // To the worker, minor units
+		wantBills int64 // To the business, minor units
+	}{
+		{"fee taken", 0.10, idr(200000), 2, 2000000, 18000000, 20000000},
+		{"fee rounded half up", 0.10, entity.NewMoney(105, "IDR"), 2, 11, 94, 105},
+		{"no fee", 0, idr(200000), 1, 0, 20000000, 20000000},
+		{"nothing earned", 0.10, idr(0), 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLedgerRepo{}
+			s := NewLedgerService(repo, nil, LedgerConfig{FeeRate: tt.feeRate})
+			ts := &entity.Timesheet{ID: 7, WorkerID: worker, BusinessID: business, PayAmount: tt.pay}
+
+			for range 2 { // Posting again changes nothing
+				if err := s.RecordEarnings(context.Background(), ts); err != nil {
+					t.Fatalf("RecordEarnings() = %v", err)
+				}
+			}
+			if len(repo.posted) != tt.wantTxns {
+				t.Fatalf("%d transactions posted, want %d", len(repo.posted), tt.wantTxns)
+			}
+			if err := checkBalanced(repo.posted...); err != nil {
+				t.Error(err)
+			}
+
+			var fee, owed, bills int64
+			for _, txn := range repo.posted {
+				for _, e := range txn.Entries {
+					switch e.AccountKind {
+					case entity.AccountPlatformRevenue:
+						fee -= e.Amount.Minor
+					case entity.AccountWorkerPayable:
+						owed -= e.Amount.Minor
+					case entity.AccountBusinessReceivable:
+						bills += e.Amount.Minor
+					}
+				}
+			}
+			if fee != tt.wantFee || owed != tt.wantOwed || bills != tt.wantBills {
+				t.Errorf("fee %d, owed %d, billed %d; want %d, %d, %d", fee, owed, bills, tt.wantFee, tt.wantOwed, tt.wantBills)
+			}
+		})
+	}
+}
+
+func TestRunPayoutBatch(t *testing.T) {
+	line := func(worker int64, amount entity.Money, entry int64) entity.PayoutLine {
+		return entity.PayoutLine{WorkerID: worker, Amount: amount, EntryIDs: []int64{entry}}
+	}
+	lines := []entity.PayoutLine{
+		line(1, idr(180000), 10),
+		line(2, idr(45500), 11),
+		line(3, entity.NewMoney(2500, "USD"), 12),
+	}
+
+	tests := []struct {
+		name      string
+		format    string
+		fail      bool
+		want      error
+		wantLines int
+		wantTotal entity.Money
+		wantFile  string
+	}{
+		{"pays the payout currency", "test", false, nil, 2, idr(225500), "1;180000.00\n2;45500.00\n"},
+		{"unknown bank format", "swift", false, ErrUnknownPayoutFormat, 0, entity.Money{}, ""},
+		{"file can't be written", "test", true, nil, 0, entity.Money{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLedgerRepo{lines: lines, paid: map[int64]bool{}}
+			formats := map[string]port.PayoutFormatter{"test": fakePayoutFormat{fail: tt.fail}}
+			s := NewLedgerService(repo, formats, LedgerConfig{BankFormat: tt.format, PayoutCurrency: "IDR"})
+
+			batch, err := s.RunPayoutBatch(context.Background())
+			if tt.fail {
+				if err == nil || len(repo.paid) != 0 {
+					t.Fatalf("RunPayoutBatch() = %v with %d entries settled, want an error and none", err, len(repo.paid))
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RunPayoutBatch() = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if batch.LineCount != tt.wantLines || batch.TotalAmount != tt.wantTotal {
+				t.Errorf("batch = %d lines totalling %v, want %d totalling %v", batch.LineCount, batch.TotalAmount, tt.wantLines, tt.wantTotal)
+			}
+			if batch.FileName != "payout-1.txt" || batch.FileContent != tt.wantFile {
+				t.Errorf("file %s = %q, want payout-1.txt = %q", batch.FileName, batch.FileContent, tt.wantFile)
+			}
+			if !repo.paid[10] || !repo.paid[11] || repo.paid[12] {
+				t.Errorf("settled entries = %v, want 10 and 11", repo.paid)
+			}
+
+			// Everything is paid, the next run has nothing to do
+			if again, err := s.RunPayoutBatch(context.Background()); again != nil || err != nil {
+				t.Errorf("second RunPayoutBatch() = %v, %v, want nothing", again, err)
+			}
+		})
+	}
+}
+
+func TestUpdateBankAccount(t *testing.T) {
+	tests := []struct {
+		name, bank, number, holder string
+		want                       error
+		wantNumber                 string
+	}{
+		{"valid", "014", " 1234 5678 90 ", " Ayu Lestari ", nil, "1234567890"},
+		{"bank code too short", "14", "1234567890", "Ayu", ErrInvalidBankAccount, ""},
+		{"letters in the number", "014", "12345ABC", "Ayu", ErrInvalidBankAccount, ""},
+		{"number too short", "014", "1234", "Ayu", ErrInvalidBankAccount, ""},
+		{"no holder", "014", "1234567890", "  ", ErrInvalidBankAccount, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeBankRepo{}
+			s := NewLedgerService(repo, nil, DefaultLedgerConfig())
+			account := &entity.WorkerBankAccount{BankCode: tt.bank, AccountNumber: tt.number, AccountHolder: tt.holder}
+
+			if err := s.UpdateBankAccount(context.Background(), account); !errors.Is(err, tt.want) {
+				t.Fatalf("UpdateBankAccount() = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if repo.saved != nil {
+					t.Error("invalid account saved")
+				}
+				return
+			}
+			if repo.saved.AccountNumber != tt.wantNumber || repo.saved.AccountHolder != strings.TrimSpace(tt.holder) {
+				t.Errorf("saved %q for %q", repo.saved.AccountNumber, repo.saved.AccountHolder)
+			}
+		})
+	}
+}
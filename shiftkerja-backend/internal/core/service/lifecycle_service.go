@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrIllegalTransition = errors.New("illegal status transition")
+	ErrStatusChanged     = errors.New("status was changed by someone else, reload and try again")
+)
+
+// shiftTransitions lists the statuses a shift can move to from each status.
+// CANCELLED is final.
+var shiftTransitions = map[entity.ShiftStatus][]entity.ShiftStatus{
+	entity.ShiftOpen:   {entity.ShiftFilled, entity.ShiftCancelled},
+	entity.ShiftFilled: {entity.ShiftOpen, entity.ShiftCancelled},
+}
+
+// applicationTransitions lists the statuses an application can move to from
+// each status. REJECTED, CANCELLED and WITHDRAWN are final. STANDBY and OFFERED
+// belong to the waitlist (see WaitlistService).
+var applicationTransitions = map[entity.ApplicationStatus][]entity.ApplicationStatus{
+	entity.ApplicationPending:  {entity.ApplicationStandby, entity.ApplicationAccepted, entity.ApplicationRejected, entity.ApplicationCancelled, entity.ApplicationWithdrawn},
+	entity.ApplicationStandby:  {entity.ApplicationPending, entity.ApplicationOffered, entity.ApplicationAccepted, entity.ApplicationRejected, entity.ApplicationCancelled, entity.ApplicationWithdrawn},
+	entity.ApplicationOffered:  {entity.ApplicationStandby, entity.ApplicationAccepted, entity.ApplicationCancelled},
+	entity.ApplicationAccepted: {entity.ApplicationCancelled},
+}
+
+// CheckShiftTransition returns ErrIllegalTransition (wrapped) unless the shift
+// lifecycle allows moving from one status to the other
+func CheckShiftTransition(from, to entity.ShiftStatus) error {
+	next, ok := shiftTransitions[from]
+	if !ok {
+		return fmt.Errorf("%w: a %s shift can't change any more", ErrIllegalTransition, from)
+	}
+	if !slices.Contains(next, to) {
+		return fmt.Errorf("%w: a %s shift can't become %s", ErrIllegalTransition, from, to)
+	}
+	return nil
+}
+
+// CheckApplicationTransition returns ErrIllegalTransition (wrapped) unless the
+// application lifecycle allows moving from one status to the other
+func CheckApplicationTransition(from, to entity.ApplicationStatus) error {
+	next, ok := applicationTransitions[from]
+	if !ok {
+		return fmt.Errorf("%w: a %s application can't change any more", ErrIllegalTransition, from)
+	}
+	if !slices.Contains(next, to) {
+		return fmt.Errorf("%w: a %s application can't become %s", ErrIllegalTransition, from, to)
+	}
+	return nil
+}
+
+// LifecycleService is the single place shift and application statuses change.
+// Each transition is checked against the tables above, then against its guard,
+// written only if the row is still in the status it was read in, and followed
+// by its side effects (the geo index only holds OPEN shifts).
+//
+// Cancelling accepted work changes several rows at once and goes through
+// CancellationService, which checks the same tables.
+type LifecycleService struct {
+	shiftRepo    port.ShiftRepository
+	geoRepo      port.GeoRepository
+	taxonomyRepo port.TaxonomyRepository
+}
+
+func NewLifecycleService(shiftRepo port.ShiftRepository, geoRepo port.GeoRepository, taxonomyRepo port.TaxonomyRepository) *LifecycleService {
+	return &LifecycleService{shiftRepo: shiftRepo, geoRepo: geoRepo, taxonomyRepo: taxonomyRepo}
+}
+
+// TransitionShift moves a shift to a new status. A shift is only FILLED once a
+// worker is accepted (a business may stop short of all its slots), can only be
+// reopened while a slot is free, and can only be cancelled here while nobody
+// is accepted (otherwise the accepted workers would be left dangling).
+func (s *LifecycleService) TransitionShift(ctx context.Context, shift *entity.Shift, to entity.ShiftStatus) error {
+	if err := CheckShiftTransition(shift.Status, to); err != nil {
+		return err
+	}
+
+	accepted, err := s.CountAccepted(ctx, shift.ID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case to == entity.ShiftFilled && accepted == 0:
+		return fmt.Errorf("%w: a shift is filled by accepting a worker", ErrIllegalTransition)
+	case to == entity.ShiftOpen && accepted >= max(shift.Slots, 1):
+		return fmt.Errorf("%w: all %d slots of this shift are taken, cancel a spot to reopen it", ErrIllegalTransition, accepted)
+	case to == entity.ShiftOpen && started(shift, time.Now()):
+		return fmt.Errorf("%w: the shift has already started", ErrIllegalTransition)
+	case to == entity.ShiftCancelled && accepted > 0:
+		return fmt.Errorf("%w: a worker is accepted for this shift, cancel it with a reason instead", ErrIllegalTransition)
+	}
+
+	updated, err := s.shiftRepo.TransitionShiftStatus(ctx, shift.ID, shift.Status, to)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrStatusChanged
+	}
+	shift.Status = to
+
+	s.SyncGeo(ctx, shift)
+	return nil
+}
+
+// TransitionApplication moves an application to a new status. Accepting needs
+// an OPEN shift that hasn't started with a free slot, and fills the shift in
+// the same write when it takes the last one.
+func (s *LifecycleService) TransitionApplication(ctx context.Context, app *entity.Application, shift *entity.Shift, to entity.ApplicationStatus) error {
+	return s.TransitionApplicationWithReason(ctx, app, shift, to, "", "")
+}
+
+// TransitionApplicationWithReason is TransitionApplication for rejections and
+// withdrawals, storing the reason code and note given for them
+func (s *LifecycleService) TransitionApplicationWithReason(ctx context.Context, app *entity.Application, shift *entity.Shift, to entity.ApplicationStatus, reasonCode, reasonNote string) error {
+	if err := CheckApplicationTransition(app.Status, to); err != nil {
+		return err
+	}
+
+	switch to {
+	case entity.ApplicationAccepted:
+		if shift.Status != entity.ShiftOpen {
+			return fmt.Errorf("%w: the shift is %s, only OPEN shifts accept workers", ErrIllegalTransition, shift.Status)
+		}
+		if started(shift, time.Now()) {
+			return fmt.Errorf("%w: the shift has already started", ErrIllegalTransition)
+		}
+		if app.RateTurn != "" {
+			return rateWaiting(app)
+		}
+		accepted, filled, err := s.shiftRepo.AcceptApplication(ctx, app.ID, shift.ID, app.Status)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return ErrStatusChanged
+		}
+		app.Status = to
+		app.StandbyRank, app.OfferExpiresAt = nil, nil
+		if filled {
+			shift.Status = entity.ShiftFilled
+			s.SyncGeo(ctx, shift)
+		}
+		return nil
+
+	case entity.ApplicationCancelled:
+		if app.Status == entity.ApplicationAccepted {
+			return fmt.Errorf("%w: an accepted spot is cancelled with a reason", ErrIllegalTransition)
+		}
+	}
+
+	updated, err := s.shiftRepo.TransitionApplicationStatus(ctx, app.ID, app.Status, to, reasonCode, reasonNote)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrStatusChanged
+	}
+	app.Status = to
+	app.StandbyRank, app.OfferExpiresAt = nil, nil
+	app.ReasonCode, app.ReasonNote = reasonCode, reasonNote
+	return nil
+}
+
+// rateWaiting is the error for accepting an application whose rate offer is
+// still waiting for an answer
+func rateWaiting(app *entity.Application) error {
+	return fmt.Errorf("%w: a rate offer is waiting for the %s to answer it", ErrIllegalTransition, strings.ToLower(app.RateTurn))
+}
+
+// TransitionApplications moves several applications to the same status in one
+// write: all of them or none (ErrStatusChanged). Every transition and shift
+// must already have been checked by the caller, see
+// ShiftService.BatchUpdateApplicationStatus. Shifts that were filled are
+// updated in place and taken off the map.
+func (s *LifecycleService) TransitionApplications(ctx context.Context, apps []*entity.Application, shifts map[int64]*entity.Shift, to entity.ApplicationStatus, reasonCode, reasonNote string) error {
+	items := make([]entity.ApplicationTransition, len(apps))
+	for i, app := range apps {
+		if err := CheckApplicationTransition(app.Status, to); err != nil {
+			return err
+		}
+		items[i] = entity.ApplicationTransition{ApplicationID: app.ID, ShiftID: app.ShiftID, From: app.Status}
+	}
+
+	filled, ok, err := s.shiftRepo.BatchTransitionApplications(ctx, items, to, reasonCode, reasonNote)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrStatusChanged
+	}
+	for _, app := range apps {
+		app.Status = to
+		app.StandbyRank, app.OfferExpiresAt = nil, nil
+		app.ReasonCode, app.ReasonNote = reasonCode, reasonNote
+	}
+	for _, id := range filled {
+		if shift := shifts[id]; shift != nil {
+			shift.Status = entity.ShiftFilled
+			s.SyncGeo(ctx, shift)
+		}
+	}
+	return nil
+}
+
+// SyncGeo makes the geo index follow the shift's status: OPEN shifts are
+// (re)indexed with their required skills, any other status is removed
+func (s *LifecycleService) SyncGeo(ctx context.Context, shift *entity.Shift) {
+	if shift.Status != entity.ShiftOpen {
+		if err := s.geoRepo.RemoveShift(ctx, shift.ID); err != nil {
+			fmt.Printf("⚠️ Redis remove warning: %v\n", err)
+		}
+		return
+	}
+
+	if shift.RequiredSkills == nil {
+		skills, err := s.taxonomyRepo.GetShiftSkills(ctx, []int64{shift.ID})
+		if err != nil {
+			fmt.Printf("⚠️ Redis sync warning: %v\n", err)
+			return
+		}
+		shift.RequiredSkills = skills[shift.ID]
+	}
+	if err := s.geoRepo.AddShift(ctx, *shift); err != nil {
+		fmt.Printf("⚠️ Redis sync warning: %v\n", err)
+	}
+}
+
+// CountAccepted counts the workers accepted for a shift
+func (s *LifecycleService) CountAccepted(ctx context.Context, shiftID int64) (int, error) {
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check applications: %w", err)
+	}
+	n := 0
+	for _, app := range apps {
+		if app.Status == entity.ApplicationAccepted {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakeShiftRepo keeps shifts and applications in memory. Methods a test
+// doesn't set up panic through the nil embedded interface.
+type fakeShiftRepo struct {
+	port.ShiftRepository
+	shifts map[int64]*entity.Shift
+	apps   []*entity.Application
+	stale  bool // Guarded writes find the row changed by someone else
+}
+
+func (r *fakeShiftRepo) GetShiftByID(_ context.Context, id int64) (*entity.Shift, error) {
+	shift, ok := r.shifts[id]
+	if !ok {
+		return nil, errors.New("shift not found")
+	}
+	found := *shift
+	return &found, nil
+}
+
+func (r *fakeShiftRepo) GetApplicationByID(_ context.Context, id int64) (*entity.Application, error) {
+	for _, app := range r.apps {
+		if app.ID == id {
+			found := *app
+			return &found, nil
+		}
+	}
+	return nil, errors.New("application not found")
+}
+
+func (r *fakeShiftRepo) GetApplicationsByShift(_ context.Context, shiftID int64) ([]entity.Application, error) {
+	var apps []entity.Application
+	for _, app := range r.apps {
+		if app.ShiftID == shiftID {
+			apps = append(apps, *app)
+		}
+	}
+	return apps, nil
+}
+
+func (r *fakeShiftRepo) TransitionShiftStatus(_ context.Context, id int64, from, to entity.ShiftStatus) (bool, error) {
+	shift := r.shifts[id]
+	if r.stale || shift == nil || shift.Status != from {
+		return false, nil
+	}
+	shift.Status = to
+	return true, nil
+}
+
+func (r *fakeShiftRepo) TransitionApplicationStatus(ctx context.Context, id int64, from, to entity.ApplicationStatus, reasonCode, reasonNote string) (bool, error) {
+	app := r.app(id)
+	if r.stale || app == nil || app.Status != from {
+		return false, nil
+	}
+	app.Status, app.ReasonCode, app.ReasonNote = to, reasonCode, reasonNote
+	return true, nil
+}
+
+// AcceptApplication takes a free slot like the locked write in Postgres does
+// and fills the shift when it takes the last one
+func (r *fakeShiftRepo) AcceptApplication(_ context.Context, id, shiftID int64, from entity.ApplicationStatus) (bool, bool, error) {
+	app, shift := r.app(id), r.shifts[shiftID]
+	if r.stale || app == nil || shift == nil || app.Status != from || shift.Status != entity.ShiftOpen {
+		return false, false, nil
+	}
+	accepted := r.accepted(shiftID)
+	if accepted >= shift.Slots {
+		return false, false, nil
+	}
+	app.Status = entity.ApplicationAccepted
+	if accepted+1 == shift.Slots {
+		shift.Status = entity.ShiftFilled
+		return true, true, nil
+	}
+	return true, false, nil
+}
+
+func (r *fakeShiftRepo) app(id int64) *entity.Application {
+	for _, app := range r.apps {
+		if app.ID == id {
+			return app
+		}
+	}
+	return nil
+}
+
+func (r *fakeShiftRepo) accepted(shiftID int64) int {
+	n := 0
+	for _, app := range r.apps {
+		if app.ShiftID == shiftID && app.Status == entity.ApplicationAccepted {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeGeoRepo records which shifts are on the map
+type fakeGeoRepo struct {
+	port.GeoRepository
+	indexed map[int64]bool
+}
+
+func (g *fakeGeoRepo) AddShift(_ context.Context, shift entity.Shift) error {
+	g.indexed[shift.ID] = true
+	return nil
+}
+
+func (g *fakeGeoRepo) RemoveShift(_ context.Context, shiftID int64) error {
+	delete(g.indexed, shiftID)
+	return nil
+}
+
+type fakeTaxonomyRepo struct {
+	port.TaxonomyRepository
+}
+
+func (fakeTaxonomyRepo) GetShiftSkills(context.Context, []int64) (map[int64][]entity.Skill, error) {
+	return map[int64][]entity.Skill{}, nil
+}
+
+func newFakeLifecycle(repo *fakeShiftRepo) (*LifecycleService, *fakeGeoRepo) {
+	geo := &fakeGeoRepo{indexed: map[int64]bool{}}
+	return NewLifecycleService(repo, geo, fakeTaxonomyRepo{}), geo
+}
+
+func TestCheckShiftTransition(t *testing.T) {
+	tests := []struct {
+		from, to entity.ShiftStatus
+		ok       bool
+	}{
+		{entity.ShiftOpen, entity.ShiftFilled, true},
+		{entity.ShiftOpen, entity.ShiftCancelled, true},
+		{entity.ShiftFilled, entity.ShiftOpen, true},
+		{entity.ShiftFilled, entity.ShiftCancelled, true},
+		{entity.ShiftOpen, entity.ShiftOpen, false},
+		{entity.ShiftCancelled, entity.ShiftOpen, false},
+		{entity.ShiftCancelled, entity.ShiftFilled, false},
+		{"UNKNOWN", entity.ShiftOpen, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			err := CheckShiftTransition(tt.from, tt.to)
+			if tt.ok && err != nil {
+				t.Errorf("CheckShiftTransition() = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrIllegalTransition) {
+				t.Errorf("CheckShiftTransition() = %v, want %v", err, ErrIllegalTransition)
+			}
+		})
+	}
+}
+
+func TestCheckApplicationTransition(t *testing.T) {
+	tests := []struct {
+		from, to entity.ApplicationStatus
+		ok       bool
+	}{
+		{entity.ApplicationPending, entity.ApplicationAccepted, true},
+		{entity.ApplicationPending, entity.ApplicationStandby, true},
+		{entity.ApplicationPending, entity.ApplicationWithdrawn, true},
+		{entity.ApplicationPending, entity.ApplicationOffered, false},
+		{entity.ApplicationStandby, entity.ApplicationOffered, true},
+		{entity.ApplicationStandby, entity.ApplicationPending, true},
+		{entity.ApplicationOffered, entity.ApplicationAccepted, true},
+		{entity.ApplicationOffered, entity.ApplicationRejected, false},
+		{entity.ApplicationAccepted, entity.ApplicationCancelled, true},
+		{entity.ApplicationAccepted, entity.ApplicationRejected, false},
+		{entity.ApplicationAccepted, entity.ApplicationWithdrawn, false},
+		{entity.ApplicationRejected, entity.ApplicationAccepted, false},
+		{entity.ApplicationWithdrawn, entity.ApplicationPending, false},
+		{entity.ApplicationCancelled, entity.ApplicationAccepted, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			err := CheckApplicationTransition(tt.from, tt.to)
+			if tt.ok && err != nil {
+				t.Errorf("CheckApplicationTransition() = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrIllegalTransition) {
+				t.Errorf("CheckApplicationTransition() = %v, want %v", err, ErrIllegalTransition)
+			}
+		})
+	}
+}
+
+func TestTransitionShift(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name      string
+		shift     entity.Shift
+		accepted  int
+		stale     bool
+		to        entity.ShiftStatus
+		want      error
+		wantOnMap bool
+	}{
+		{"fill once a worker is accepted", entity.Shift{Status: entity.ShiftOpen, Slots: 2}, 1, false, entity.ShiftFilled, nil, false},
+		{"fill with nobody accepted", entity.Shift{Status: entity.ShiftOpen, Slots: 2}, 0, false, entity.ShiftFilled, ErrIllegalTransition, true},
+		{"reopen with a free slot", entity.Shift{Status: entity.ShiftFilled, Slots: 2}, 1, false, entity.ShiftOpen, nil, true},
+		{"reopen with every slot taken", entity.Shift{Status: entity.ShiftFilled, Slots: 2}, 2, false, entity.ShiftOpen, ErrIllegalTransition, false},
+		{"reopen after the start", entity.Shift{Status: entity.ShiftFilled, Slots: 2, StartsAt: &past}, 1, false, entity.ShiftOpen, ErrIllegalTransition, false},
+		{"cancel with nobody accepted", entity.Shift{Status: entity.ShiftOpen, Slots: 1}, 0, false, entity.ShiftCancelled, nil, false},
+		{"cancel with a worker accepted", entity.Shift{Status: entity.ShiftFilled, Slots: 1}, 1, false, entity.ShiftCancelled, ErrIllegalTransition, false},
+		{"cancelled is final", entity.Shift{Status: entity.ShiftCancelled, Slots: 1}, 0, false, entity.ShiftOpen, ErrIllegalTransition, false},
+		{"changed by someone else", entity.Shift{Status: entity.ShiftOpen, Slots: 1}, 0, true, entity.ShiftCancelled, ErrStatusChanged, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shift := tt.shift
+			shift.ID = 1
+			repo := &fakeShiftRepo{shifts: map[int64]*entity.Shift{1: &shift}, stale: tt.stale}
+			for i := 0; i < tt.accepted; i++ {
+				repo.apps = append(repo.apps, &entity.Application{ID: int64(i + 1), ShiftID: 1, Status: entity.ApplicationAccepted})
+			}
+			lifecycle, geo := newFakeLifecycle(repo)
+			if tt.shift.Status == entity.ShiftOpen {
+				geo.indexed[1] = true
+			}
+
+			working := shift
+			err := lifecycle.TransitionShift(context.Background(), &working, tt.to)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("TransitionShift() = %v, want %v", err, tt.want)
+			}
+			if err == nil && working.Status != tt.to {
+				t.Errorf("status = %s, want %s", working.Status, tt.to)
+			}
+			if geo.indexed[1] != tt.wantOnMap {
+				t.Errorf("on the map = %v, want %v", geo.indexed[1], tt.wantOnMap)
+			}
+		})
+	}
+}
+
+func TestTransitionApplication(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name  string
+		app   entity.Application
+		shift entity.Shift
+		to    entity.ApplicationStatus
+		want  error
+	}{
+		{"accept a pending applicant", entity.Application{Status: entity.ApplicationPending}, entity.Shift{Status: entity.ShiftOpen, Slots: 2}, entity.ApplicationAccepted, nil},
+		{"accept on a filled shift", entity.Application{Status: entity.ApplicationPending}, entity.Shift{Status: entity.ShiftFilled, Slots: 1}, entity.ApplicationAccepted, ErrIllegalTransition},
+		{"accept after the start", entity.Application{Status: entity.ApplicationPending}, entity.Shift{Status: entity.ShiftOpen, Slots: 1, StartsAt: &past}, entity.ApplicationAccepted, ErrIllegalTransition},
+		{"accept while a rate offer waits", entity.Application{Status: entity.ApplicationPending, RateTurn: entity.SideBusiness}, entity.Shift{Status: entity.ShiftOpen, Slots: 1}, entity.ApplicationAccepted, ErrIllegalTransition},
+		{"accept a rejected applicant", entity.Application{Status: entity.ApplicationRejected}, entity.Shift{Status: entity.ShiftOpen, Slots: 1}, entity.ApplicationAccepted, ErrIllegalTransition},
+		{"reject a pending applicant", entity.Application{Status: entity.ApplicationPending}, entity.Shift{Status: entity.ShiftOpen, Slots: 1}, entity.ApplicationRejected, nil},
+		{"cancel an accepted spot without a reason", entity.Application{Status: entity.ApplicationAccepted}, entity.Shift{Status: entity.ShiftFilled, Slots: 1}, entity.ApplicationCancelled, ErrIllegalTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shift, app := tt.shift, tt.app
+			shift.ID, app.ID, app.ShiftID = 1, 10, 1
+			stored := app
+			repo := &fakeShiftRepo{shifts: map[int64]*entity.Shift{1: &shift}, apps: []*entity.Application{&stored}}
+			lifecycle, _ := newFakeLifecycle(repo)
+
+			err := lifecycle.TransitionApplication(context.Background(), &app, &shift, tt.to)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("TransitionApplication() = %v, want %v", err, tt.want)
+			}
+			wantStatus := tt.app.Status
+			if err == nil {
+				wantStatus = tt.to
+			}
+			if stored.Status != wantStatus {
+				t.Errorf("stored status = %s, want %s", stored.Status, wantStatus)
+			}
+		})
+	}
+}
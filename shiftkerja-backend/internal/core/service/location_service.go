@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+	ErrInvalidLocation  = errors.New("invalid location")
+	ErrLocationInUse    = errors.New("shifts, events or templates are posted at this location")
+	ErrLocationRequired = errors.New("location_id is required, save the work site under /my-locations first")
+)
+
+// Limits of a saved work site
+const (
+	maxLocationNameLength = 100
+	maxAddressLength      = 300
+	maxInstructionsLength = 1000
+	maxContactNameLength  = 100
+	maxContactPhoneLength = 30
+)
+
+// LocationService manages the work sites a business saves so it doesn't type
+// coordinates for every shift. Businesses post shifts, events and templates at
+// a location_id and they take its coordinates; moving the location moves its
+// events, templates and OPEN shifts, which
+// are versioned like any edit (applicants re-confirm a material move) and
+// re-indexed on the map. FILLED and past shifts stay where they were worked.
+// A move that would leave an OPEN shift paying below the minimum wage of its
+// new region is refused.
+type LocationService struct {
+	locationRepo port.LocationRepository
+	wages        *WageRuleService
+	revisions    *ShiftRevisionService
+	lifecycle    *LifecycleService
+}
+
+func NewLocationService(locationRepo port.LocationRepository, wages *WageRuleService, revisions *ShiftRevisionService, lifecycle *LifecycleService) *LocationService {
+	return &LocationService{locationRepo: locationRepo, wages: wages, revisions: revisions, lifecycle: lifecycle}
+}
+
+// CreateLocation saves a work site for the business
+func (s *LocationService) CreateLocation(ctx context.Context, location *entity.Location) error {
+	if err := normalizeLocation(location); err != nil {
+		return err
+	}
+	if err := s.checkName(ctx, location); err != nil {
+		return err
+	}
+	return s.locationRepo.CreateLocation(ctx, location)
+}
+
+// GetMyLocations retrieves the business's work sites
+func (s *LocationService) GetMyLocations(ctx context.Context, ownerID int64) ([]entity.Location, error) {
+	return s.locationRepo.GetLocationsByOwner(ctx, ownerID)
+}
+
+// UpdateLocation edits a work site (owner only) and moves its OPEN shifts if
+// its coordinates changed. It returns the revisions of the moved shifts.
+func (s *LocationService) UpdateLocation(ctx context.Context, location *entity.Location, businessID int64) ([]entity.ShiftRevision, error) {
+	existing, err := s.locationRepo.GetLocation(ctx, location.ID)
+	if err != nil {
+		return nil, ErrLocationNotFound
+	}
+	if existing.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+	location.OwnerID, location.CreatedAt = existing.OwnerID, existing.CreatedAt
+	if err := normalizeLocation(location); err != nil {
+		return nil, err
+	}
+	if err := s.checkName(ctx, location); err != nil {
+		return nil, err
+	}
+	if location.Lat != existing.Lat || location.Lng != existing.Lng {
+		if err := s.checkWages(ctx, location); err != nil {
+			return nil, err
+		}
+	}
+
+	moved, err := s.locationRepo.UpdateLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []entity.ShiftRevision{}
+	for i := range moved {
+		shift := &moved[i]
+		before := *shift
+		before.Lat, before.Lng = existing.Lat, existing.Lng
+		rev, err := s.revisions.Record(ctx, &before, shift, businessID)
+		if err != nil {
+			// The shift has moved already; only its history is missing
+			fmt.Printf("⚠️ Shift %d history warning: %v\n", shift.ID, err)
+		} else if rev != nil {
+			revisions = append(revisions, *rev)
+		}
+		s.lifecycle.SyncGeo(ctx, shift)
+	}
+	return revisions, nil
+}
+
+// DeleteLocation deletes a work site (owner only) nothing is posted at
+func (s *LocationService) DeleteLocation(ctx context.Context, locationID, businessID int64) error {
+	location, err := s.locationRepo.GetLocation(ctx, locationID)
+	if err != nil {
+		return ErrLocationNotFound
+	}
+	if location.OwnerID != businessID {
+		return ErrUnauthorized
+	}
+	deleted, err := s.locationRepo.DeleteLocation(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrLocationInUse
+	}
+	return nil
+}
+
+// checkWages runs the minimum wage check on the location's OPEN shifts and
+// templates at its new coordinates, as CreateShift and CreateTemplate would
+func (s *LocationService) checkWages(ctx context.Context, location *entity.Location) error {
+	shifts, err := s.locationRepo.GetOpenShifts(ctx, location.ID)
+	if err != nil {
+		return err
+	}
+	for _, shift := range shifts {
+		shift.Lat, shift.Lng = location.Lat, location.Lng
+		if err := s.wages.CheckShift(&shift); err != nil {
+			return fmt.Errorf("can't move shift %d (%s): %w", shift.ID, shift.Title, err)
+		}
+	}
+
+	templates, err := s.locationRepo.GetTemplates(ctx, location.ID)
+	if err != nil {
+		return err
+	}
+	for _, t := range templates {
+		t.Lat, t.Lng = location.Lat, location.Lng
+		sample := templateSample(&t, time.Now())
+		if err := s.wages.CheckShift(&sample); err != nil {
+			return fmt.Errorf("can't move template %d (%s): %w", t.ID, t.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkName keeps a business's location names unique, ignoring case
+func (s *LocationService) checkName(ctx context.Context, location *entity.Location) error {
+	locations, err := s.locationRepo.GetLocationsByOwner(ctx, location.OwnerID)
+	if err != nil {
+		return err
+	}
+	for _, other := range locations {
+		if other.ID != location.ID && strings.EqualFold(other.Name, location.Name) {
+			return fmt.Errorf("%w: you already have a location named %q", ErrInvalidLocation, other.Name)
+		}
+	}
+	return nil
+}
+
+// normalizeLocation trims a work site's details and checks them
+func normalizeLocation(l *entity.Location) error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Address = strings.TrimSpace(l.Address)
+	l.Instructions = strings.TrimSpace(l.Instructions)
+	l.ContactName = strings.TrimSpace(l.ContactName)
+	l.ContactPhone = strings.TrimSpace(l.ContactPhone)
+
+	switch {
+	case l.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidLocation)
+	case len(l.Name) > maxLocationNameLength:
+		return fmt.Errorf("%w: the name is longer than %d characters", ErrInvalidLocation, maxLocationNameLength)
+	case len(l.Address) > maxAddressLength:
+		return fmt.Errorf("%w: the address is longer than %d characters", ErrInvalidLocation, maxAddressLength)
+	case len(l.Instructions) > maxInstructionsLength:
+		return fmt.Errorf("%w: the instructions are longer than %d characters", ErrInvalidLocation, maxInstructionsLength)
+	case len(l.ContactName) > maxContactNameLength:
+		return fmt.Errorf("%w: the contact name is longer than %d characters", ErrInvalidLocation, maxContactNameLength)
+	case len(l.ContactPhone) > maxContactPhoneLength:
+		return fmt.Errorf("%w: the contact phone is longer than %d characters", ErrInvalidLocation, maxContactPhoneLength)
+	case l.Lat < -90 || l.Lat > 90:
+		return fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidLocation)
+	case l.Lng < -180 || l.Lng > 180:
+		return fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidLocation)
+	}
+	return nil
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var ErrNoSearchLocation = errors.New("no location given and no home location saved in preferences")
+
+const (
+	defaultRecommendRadiusKm = 25.0
+	defaultPageSize          = 20
+	maxPageSize              = 50
+)
+
+// MatchWeights controls how much each signal counts towards the match score.
+// Weights are relative: they are normalised by their sum when scoring.
+type MatchWeights struct {
+	Distance     float64 `json:"distance"`
+	Pay          float64 `json:"pay"`
+	Skills       float64 `json:"skills"`
+	History      float64 `json:"history"`
+	Availability float64 `json:"availability"`
+}
+
+// DefaultMatchWeights favours shifts that are close by and pay well
+func DefaultMatchWeights() MatchWeights {
+	return MatchWeights{
+		Distance:     0.30,
+		Pay:          0.25,
+		Skills:       0.25,
+		History:      0.10,
+		Availability: 0.10,
+	}
+}
+
+func (w MatchWeights) total() float64 {
+	return w.Distance + w.Pay + w.Skills + w.History + w.Availability
+}
+
+// RecommendationQuery describes a "recommended for you" request
+type RecommendationQuery struct {
+	WorkerID int64
+	Lat      *float64 // Falls back to the worker's home location
+	Lng      *float64
+	RadiusKm float64 // 0 = worker preference or default
+	Page     int
+	PageSize int
+
+	// AvailableOnly hides shifts outside the worker's availability calendar
+	AvailableOnly bool
+}
+
+// RecommendationPage is one page of ranked shifts
+type RecommendationPage struct {
+	Items    []entity.ShiftRecommendation `json:"items"`
+	Page     int                          `json:"page"`
+	PageSize int                          `json:"page_size"`
+	Total    int                          `json:"total"`
+	Weights  MatchWeights                 `json:"weights"`
+}
+
+type MatchingService struct {
+	shiftRepo    port.ShiftRepository
+	geoRepo      port.GeoRepository
+	taxonomyRepo port.TaxonomyRepository
+	profileRepo  port.WorkerProfileRepository
+	pools        *PoolService
+	weights      MatchWeights
+}
+
+func NewMatchingService(
+	shiftRepo port.ShiftRepository,
+	geoRepo port.GeoRepository,
+	taxonomyRepo port.TaxonomyRepository,
+	profileRepo port.WorkerProfileRepository,
+	pools *PoolService,
+	weights MatchWeights,
+) *MatchingService {
+	if weights.total() <= 0 {
+		weights = DefaultMatchWeights()
+	}
+	return &MatchingService{
+		shiftRepo:    shiftRepo,
+		geoRepo:      geoRepo,
+		taxonomyRepo: taxonomyRepo,
+		profileRepo:  profileRepo,
+		pools:        pools,
+		weights:      weights,
+	}
+}
+
+// Recommend scores the OPEN shifts around a worker and returns one page, best first
+func (s *MatchingService) Recommend(ctx context.Context, q RecommendationQuery) (*RecommendationPage, error) {
+	// 1. Load what we know about the worker
+	prefs, err := s.profileRepo.GetPreferences(ctx, q.WorkerID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		prefs = &entity.WorkerPreferences{WorkerID: q.WorkerID}
+	}
+
+	lat, lng, ok := searchOrigin(q, prefs)
+	if !ok {
+		return nil, ErrNoSearchLocation
+	}
+	radius := q.RadiusKm
+	if radius <= 0 {
+		radius = prefs.MaxDistanceKm
+	}
+	if radius <= 0 {
+		radius = defaultRecommendRadiusKm
+	}
+
+	history, err := s.shiftRepo.GetApplicationsByWorker(ctx, q.WorkerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application history: %w", err)
+	}
+	workerSkills, err := s.taxonomyRepo.GetWorkerSkills(ctx, q.WorkerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker skills: %w", err)
+	}
+	availability, err := s.profileRepo.GetAvailability(ctx, q.WorkerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load availability: %w", err)
+	}
+
+	// 2. Candidates: OPEN shifts in the geo index the worker may see and hasn't applied to yet
+	candidates, err := s.geoRepo.FindNearby(ctx, lat, lng, radius)
+	if err != nil {
+		return nil, err
+	}
+	if candidates, err = s.pools.FilterVisible(ctx, q.WorkerID, "worker", candidates); err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]bool, len(history))
+	for _, app := range history {
+		applied[app.ShiftID] = true
+	}
+
+	// 3. Score
+	profile := matchProfile{
+		prefs:    prefs,
+		lat:      lat,
+		lng:      lng,
+		radiusKm: radius,
+		skills:   workerSkills,
+		history:  history,
+
+		availability: availability,
+	}
+	items := make([]entity.ShiftRecommendation, 0, len(candidates))
+	for _, shift := range candidates {
+		if applied[shift.ID] {
+			continue
+		}
+		if q.AvailableOnly && !shiftFitsCalendar(shift, history, availability) {
+			continue
+		}
+		items = append(items, s.score(shift, profile))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].DistanceKm < items[j].DistanceKm
+	})
+
+	// 4. Paginate
+	page, pageSize := normalisePage(q.Page, q.PageSize)
+	result := &RecommendationPage{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(items),
+		Weights:  s.weights,
+		Items:    []entity.ShiftRecommendation{},
+	}
+	start := (page - 1) * pageSize
+	if start < len(items) {
+		end := start + pageSize
+		if end > len(items) {
+			end = len(items)
+		}
+		result.Items = items[start:end]
+	}
+
+	return result, nil
+}
+
+// matchProfile bundles everything known about the worker for scoring
+type matchProfile struct {
+	prefs    *entity.WorkerPreferences
+	lat      float64
+	lng      float64
+	radiusKm float64
+	skills   []entity.WorkerSkill
+	history  []entity.Application
+
+	availability *entity.WorkerAvailability
+}
+
+func (s *MatchingService) score(shift entity.Shift, p matchProfile) entity.ShiftRecommendation {
+	distance := distanceKm(p.lat, p.lng, shift.Lat, shift.Lng)
+
+	signals := []entity.MatchSignal{
+		distanceSignal(distance, p.radiusKm, s.weights.Distance),
+		paySignal(shift, p.prefs.MinPayRate, s.weights.Pay),
+		skillSignal(shift.RequiredSkills, p.skills, s.weights.Skills),
+		historySignal(shift, p.history, s.weights.History),
+		availabilitySignal(shift, p.history, p.availability, s.weights.Availability),
+	}
+
+	total := s.weights.total()
+	var score float64
+	for i := range signals {
+		signals[i].Contribution = round1(100 * signals[i].Value * signals[i].Weight / total)
+		signals[i].Value = round2(signals[i].Value)
+		score += signals[i].Contribution
+	}
+
+	return entity.ShiftRecommendation{
+		Shift:      shift,
+		Score:      round1(score),
+		DistanceKm: round2(distance),
+		Signals:    signals,
+	}
+}
+
+func distanceSignal(distance, radius, weight float64) entity.MatchSignal {
+	return entity.MatchSignal{
+		Name:        "distance",
+		Value:       clamp01(1 - distance/radius),
+		Weight:      weight,
+		Explanation: fmt.Sprintf("%.1f km away (search radius %.0f km)", distance, radius),
+	}
+}
+
+// paySignal gives 0.5 at the worker's minimum hourly rate, rising to 1 at double it.
+// Shifts paying below the minimum drop to at most 0.25. Per-shift pay is
+// compared by its hourly equivalent.
+func paySignal(shift entity.Shift, minRate entity.Money, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "pay", Weight: weight}
+	hourly, ok := shift.HourlyRate()
+	switch {
+	case !minRate.IsPositive():
+		sig.Value = 1
+		sig.Explanation = "No minimum rate set in preferences"
+	case !ok:
+		sig.Value = 0.5
+		sig.Explanation = "Pays per shift without a schedule to compare with your minimum"
+	case hourly.Currency != minRate.Currency:
+		sig.Value = 0.5
+		sig.Explanation = fmt.Sprintf("Pays in %s, your minimum is in %s", hourly.Currency, minRate.Currency)
+	case hourly.Minor >= minRate.Minor:
+		rate, min := hourly.Float(), minRate.Float()
+		sig.Value = 0.5 + 0.5*clamp01((rate-min)/min)
+		sig.Explanation = fmt.Sprintf("Pays %s/hour, at or above your minimum of %s", hourly, minRate)
+	default:
+		sig.Value = 0.25 * clamp01(hourly.Float()/minRate.Float())
+		sig.Explanation = fmt.Sprintf("Pays %s/hour, below your minimum of %s", hourly, minRate)
+	}
+	return sig
+}
+
+// skillLevelFit rates how well a declared level satisfies a required skill
+var skillLevelFit = map[string]float64{
+	entity.SkillLevelBeginner:     0.6,
+	entity.SkillLevelIntermediate: 0.8,
+	entity.SkillLevelExpert:       1.0,
+}
+
+func skillSignal(required []entity.Skill, have []entity.WorkerSkill, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "skills", Weight: weight}
+	if len(required) == 0 {
+		sig.Value = 1
+		sig.Explanation = "No specific skills required"
+		return sig
+	}
+
+	levels := make(map[int64]string, len(have))
+	for _, ws := range have {
+		levels[ws.SkillID] = ws.Level
+	}
+	var fit float64
+	matched := 0
+	for _, skill := range required {
+		if level, ok := levels[skill.ID]; ok {
+			fit += skillLevelFit[level]
+			matched++
+		}
+	}
+	sig.Value = fit / float64(len(required))
+	sig.Explanation = fmt.Sprintf("%d of %d required skills matched", matched, len(required))
+	return sig
+}
+
+// historySignal rewards businesses that accepted the worker before, then
+// categories where the worker's applications tend to get accepted
+func historySignal(shift entity.Shift, history []entity.Application, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "history", Weight: weight}
+
+	decided, accepted := 0, 0
+	for _, app := range history {
+		if app.ShiftOwnerID == shift.OwnerID && app.Status == entity.ApplicationAccepted {
+			sig.Value = 1
+			sig.Explanation = "You were accepted by this business before"
+			return sig
+		}
+		if shift.CategoryID == nil || app.ShiftCategoryID == nil || *app.ShiftCategoryID != *shift.CategoryID {
+			continue
+		}
+		if app.Status == entity.ApplicationAccepted || app.Status == entity.ApplicationRejected {
+			decided++
+			if app.Status == entity.ApplicationAccepted {
+				accepted++
+			}
+		}
+	}
+
+	if decided == 0 {
+		sig.Value = 0.5
+		sig.Explanation = "No past applications in this category"
+		return sig
+	}
+	sig.Value = float64(accepted) / float64(decided)
+	sig.Explanation = fmt.Sprintf("Accepted in %d of %d past applications in this category", accepted, decided)
+	return sig
+}
+
+// availabilitySignal checks the shift against the worker's ACCEPTED shifts and calendar
+func availabilitySignal(shift entity.Shift, history []entity.Application, av *entity.WorkerAvailability, weight float64) entity.MatchSignal {
+	sig := entity.MatchSignal{Name: "availability", Weight: weight}
+	if shift.StartsAt == nil || shift.EndsAt == nil {
+		sig.Value = 0.5
+		sig.Explanation = "Shift has no fixed schedule"
+		return sig
+	}
+
+	if app := conflictingShift(history, shift.ID, *shift.StartsAt, *shift.EndsAt); app != nil {
+		sig.Value = 0
+		sig.Explanation = fmt.Sprintf("Overlaps your accepted shift \"%s\"", app.ShiftTitle)
+		return sig
+	}
+	if !availabilityCovers(av, *shift.StartsAt, *shift.EndsAt) {
+		sig.Value = 0.2
+		sig.Explanation = "Outside your availability calendar"
+		return sig
+	}
+
+	sig.Value = 1
+	sig.Explanation = "Fits your availability"
+	return sig
+}
+
+// shiftFitsCalendar is the hard version of availabilitySignal used for filtering.
+// Unscheduled shifts always fit.
+func shiftFitsCalendar(shift entity.Shift, history []entity.Application, av *entity.WorkerAvailability) bool {
+	if shift.StartsAt == nil || shift.EndsAt == nil {
+		return true
+	}
+	if conflictingShift(history, shift.ID, *shift.StartsAt, *shift.EndsAt) != nil {
+		return false
+	}
+	return availabilityCovers(av, *shift.StartsAt, *shift.EndsAt)
+}
+
+// overlaps reports whether [aStart, aEnd) and [bStart, bEnd) intersect
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+func searchOrigin(q RecommendationQuery, prefs *entity.WorkerPreferences) (float64, float64, bool) {
+	if q.Lat != nil && q.Lng != nil {
+		return *q.Lat, *q.Lng, true
+	}
+	if prefs.HomeLat != nil && prefs.HomeLng != nil {
+		return *prefs.HomeLat, *prefs.HomeLng, true
+	}
+	return 0, 0, false
+}
+
+func normalisePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func round1(v float64) float64 { return math.Round(v*10) / 10 }
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
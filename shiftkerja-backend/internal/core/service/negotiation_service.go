@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidRateOffer = errors.New("invalid rate offer")
+	ErrNotYourTurn      = errors.New("the rate on the table is waiting for the other side")
+	ErrRateLocked       = errors.New("the rate can't be negotiated any more")
+)
+
+// maxRateNoteLength caps the note on a step of a rate negotiation
+const maxRateNoteLength = 500
+
+// NegotiationService lets a worker ask for a different rate than the shift
+// pays and the business answer it. Whoever has a rate waiting on them may
+// accept it, decline it (the shift's rate stands) or counter with another, and
+// either side may put a new rate on the table once nothing is waiting. An
+// application can't be accepted while an offer is waiting; once it is
+// accepted the agreed rate is locked and used for its earnings.
+type NegotiationService struct {
+	negotiationRepo port.NegotiationRepository
+	shiftRepo       port.ShiftRepository
+	wages           *WageRuleService
+}
+
+func NewNegotiationService(negotiationRepo port.NegotiationRepository, shiftRepo port.ShiftRepository, wages *WageRuleService) *NegotiationService {
+	return &NegotiationService{negotiationRepo: negotiationRepo, shiftRepo: shiftRepo, wages: wages}
+}
+
+// Respond records a step of the negotiation by the worker who applied or the
+// business that owns the shift, and returns the updated application
+func (s *NegotiationService) Respond(ctx context.Context, applicationID, userID int64, action string, rate *entity.Money, note string) (*entity.Application, *entity.RateOffer, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, nil, ErrApplicationNotFound
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, nil, ErrShiftNotFound
+	}
+
+	var side string
+	switch userID {
+	case app.WorkerID:
+		side = entity.SideWorker
+	case shift.OwnerID:
+		side = entity.SideBusiness
+	default:
+		return nil, nil, ErrUnauthorized
+	}
+
+	switch {
+	case app.Status == entity.ApplicationAccepted:
+		return nil, nil, fmt.Errorf("%w: the application is accepted and its rate is locked", ErrRateLocked)
+	case app.Status != entity.ApplicationPending && app.Status != entity.ApplicationStandby:
+		return nil, nil, fmt.Errorf("%w: the application is %s", ErrRateLocked, app.Status)
+	case shift.Status != entity.ShiftOpen:
+		return nil, nil, fmt.Errorf("%w: the shift is %s", ErrRateLocked, shift.Status)
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > maxRateNoteLength {
+		return nil, nil, fmt.Errorf("%w: the note is longer than %d characters", ErrInvalidRateOffer, maxRateNoteLength)
+	}
+
+	offer := &entity.RateOffer{ApplicationID: app.ID, AuthorID: userID, Side: side, Action: action, Note: note}
+	var newRate *entity.Money
+	newTurn := ""
+	switch action {
+	case entity.RateCounter:
+		if app.RateTurn != "" && app.RateTurn != side {
+			return nil, nil, ErrNotYourTurn
+		}
+		if rate == nil {
+			return nil, nil, fmt.Errorf("%w: a counter needs a rate", ErrInvalidRateOffer)
+		}
+		if err := s.checkRate(shift, rate); err != nil {
+			return nil, nil, err
+		}
+		offer.Rate, newRate, newTurn = rate, rate, entity.OtherSide(side)
+	case entity.RateAccept, entity.RateDecline:
+		if app.RateTurn == "" {
+			return nil, nil, fmt.Errorf("%w: no rate is waiting for an answer", ErrInvalidRateOffer)
+		}
+		if app.RateTurn != side {
+			return nil, nil, ErrNotYourTurn
+		}
+		offer.Rate = app.NegotiatedRate
+		if action == entity.RateAccept {
+			newRate = app.NegotiatedRate
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: action must be ACCEPT, DECLINE or COUNTER", ErrInvalidRateOffer)
+	}
+
+	updated, err := s.negotiationRepo.RecordOffer(ctx, offer, app.RateTurn, newRate, newTurn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !updated {
+		return nil, nil, ErrStatusChanged
+	}
+	app.NegotiatedRate, app.RateTurn = newRate, newTurn
+	app.ShiftOwnerID = shift.OwnerID
+	return app, offer, nil
+}
+
+// GetThread returns the negotiation of an application to the worker who
+// applied, the business that owns the shift and admins
+func (s *NegotiationService) GetThread(ctx context.Context, applicationID, userID int64, role string) (*entity.Application, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	if role != "admin" && app.WorkerID != userID {
+		shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+		if err != nil {
+			return nil, ErrShiftNotFound
+		}
+		if shift.OwnerID != userID {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	offers, err := s.negotiationRepo.GetOffers(ctx, []int64{app.ID})
+	if err != nil {
+		return nil, err
+	}
+	app.RateOffers = offers[app.ID]
+	if app.RateOffers == nil {
+		app.RateOffers = []entity.RateOffer{}
+	}
+	return app, nil
+}
+
+// AttachOffers fills in the negotiation threads of the applications
+func (s *NegotiationService) AttachOffers(ctx context.Context, apps []entity.Application) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(apps))
+	for i := range apps {
+		ids[i] = apps[i].ID
+	}
+	offers, err := s.negotiationRepo.GetOffers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range apps {
+		apps[i].RateOffers = offers[apps[i].ID]
+	}
+	return nil
+}
+
+// checkRate validates a rate offered for the shift: in the shift's currency
+// (filled in if left out), positive and not below the minimum wage
+func (s *NegotiationService) checkRate(shift *entity.Shift, rate *entity.Money) error {
+	if rate.Currency == "" {
+		rate.Currency = shift.PayRate.Currency
+	}
+	if rate.Currency != shift.PayRate.Currency {
+		return fmt.Errorf("%w: the rate must be in %s like the shift's", ErrInvalidRateOffer, shift.PayRate.Currency)
+	}
+	if !rate.IsPositive() {
+		return fmt.Errorf("%w: the rate must be positive", ErrInvalidRateOffer)
+	}
+	offered := *shift
+	offered.PayRate = *rate
+	return s.wages.CheckShift(&offered)
+}
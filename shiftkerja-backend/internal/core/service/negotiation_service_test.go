@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakeNegotiationRepo records offers on the applications of a fakeShiftRepo,
+// guarded like the Postgres write
+type fakeNegotiationRepo struct {
+	port.NegotiationRepository
+	shifts *fakeShiftRepo
+	offers []entity.RateOffer
+}
+
+func (r *fakeNegotiationRepo) RecordOffer(_ context.Context, offer *entity.RateOffer, turnWas string, rate *entity.Money, turn string) (bool, error) {
+	app := r.shifts.app(offer.ApplicationID)
+	if r.shifts.stale || app == nil || app.RateTurn != turnWas ||
+		(app.Status != entity.ApplicationPending && app.Status != entity.ApplicationStandby) {
+		return false, nil
+	}
+	app.NegotiatedRate, app.RateTurn = rate, turn
+	r.offers = append(r.offers, *offer)
+	return true, nil
+}
+
+func TestNegotiationRespond(t *testing.T) {
+	const worker, business = 1, 100
+	offered := idr(30000)
+	rate := func(m entity.Money) *entity.Money { return &m }
+
+	tests := []struct {
+		name        string
+		appStatus   entity.ApplicationStatus
+		turn        string // Side the rate on the table waits for
+		onTable     *entity.Money
+		shiftStatus entity.ShiftStatus
+		stale       bool
+		userID      int64
+		action      string
+		rate        *entity.Money
+		want        error
+		wantRate    *entity.Money
+		wantTurn    string
+	}{
+		{"worker asks for more", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, entity.RateCounter, rate(offered), nil, &offered, entity.SideBusiness},
+		{"business offers first", entity.ApplicationStandby, "", nil, entity.ShiftOpen, false, business, entity.RateCounter, rate(offered), nil, &offered, entity.SideWorker},
+		{"currency filled in from the shift", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, entity.RateCounter, &entity.Money{Minor: offered.Minor}, nil, &offered, entity.SideBusiness},
+		{"business counters", entity.ApplicationPending, entity.SideBusiness, rate(idr(40000)), entity.ShiftOpen, false, business, entity.RateCounter, rate(offered), nil, &offered, entity.SideWorker},
+		{"business accepts", entity.ApplicationPending, entity.SideBusiness, rate(offered), entity.ShiftOpen, false, business, entity.RateAccept, nil, nil, &offered, ""},
+		{"business declines", entity.ApplicationPending, entity.SideBusiness, rate(offered), entity.ShiftOpen, false, business, entity.RateDecline, nil, nil, nil, ""},
+		{"worker counters their own offer", entity.ApplicationPending, entity.SideBusiness, rate(offered), entity.ShiftOpen, false, worker, entity.RateCounter, rate(idr(35000)), ErrNotYourTurn, &offered, entity.SideBusiness},
+		{"worker accepts their own offer", entity.ApplicationPending, entity.SideBusiness, rate(offered), entity.ShiftOpen, false, worker, entity.RateAccept, nil, ErrNotYourTurn, &offered, entity.SideBusiness},
+		{"accept with nothing waiting", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, business, entity.RateAccept, nil, ErrInvalidRateOffer, nil, ""},
+		{"counter without a rate", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, entity.RateCounter, nil, ErrInvalidRateOffer, nil, ""},
+		{"counter below the minimum wage", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, business, entity.RateCounter, rate(idr(15000)), ErrBelowMinimumWage, nil, ""},
+		{"counter in another currency", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, entity.RateCounter, rate(entity.NewMoney(3000, "USD")), ErrInvalidRateOffer, nil, ""},
+		{"counter of nothing", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, entity.RateCounter, rate(idr(0)), ErrInvalidRateOffer, nil, ""},
+		{"unknown action", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, worker, "HAGGLE", nil, ErrInvalidRateOffer, nil, ""},
+		{"someone else", entity.ApplicationPending, "", nil, entity.ShiftOpen, false, 50, entity.RateCounter, rate(offered), ErrUnauthorized, nil, ""},
+		{"accepted application", entity.ApplicationAccepted, "", nil, entity.ShiftFilled, false, worker, entity.RateCounter, rate(offered), ErrRateLocked, nil, ""},
+		{"shift no longer open", entity.ApplicationPending, "", nil, entity.ShiftCancelled, false, worker, entity.RateCounter, rate(offered), ErrRateLocked, nil, ""},
+		{"answered by someone else meanwhile", entity.ApplicationPending, entity.SideBusiness, rate(offered), entity.ShiftOpen, true, business, entity.RateAccept, nil, ErrStatusChanged, &offered, entity.SideBusiness},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts := &fakeShiftRepo{
+				shifts: map[int64]*entity.Shift{1: {ID: 1, OwnerID: business, Status: tt.shiftStatus, Lat: 1, PayRate: idr(25000), PayUnit: entity.PayHourly}},
+				apps:   []*entity.Application{{ID: 10, ShiftID: 1, WorkerID: worker, Status: tt.appStatus, RateTurn: tt.turn, NegotiatedRate: tt.onTable}},
+				stale:  tt.stale,
+			}
+			negotiations := &fakeNegotiationRepo{shifts: shifts}
+			s := NewNegotiationService(negotiations, shifts, newFakeWages(true))
+
+			app, offer, err := s.Respond(context.Background(), 10, tt.userID, tt.action, tt.rate, " ")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Respond() = %v, want %v", err, tt.want)
+			}
+
+			stored := shifts.app(10)
+			if !sameRate(stored.NegotiatedRate, tt.wantRate) || stored.RateTurn != tt.wantTurn {
+				t.Errorf("on the table = %v waiting for %q, want %v waiting for %q", stored.NegotiatedRate, stored.RateTurn, tt.wantRate, tt.wantTurn)
+			}
+			if err != nil {
+				if len(negotiations.offers) != 0 {
+					t.Errorf("%d offers recorded, want none", len(negotiations.offers))
+				}
+				return
+			}
+			if app.ShiftOwnerID != business {
+				t.Errorf("ShiftOwnerID = %d, want %d", app.ShiftOwnerID, business)
+			}
+			wantSide := entity.SideWorker
+			if tt.userID == business {
+				wantSide = entity.SideBusiness
+			}
+			if offer.Side != wantSide || offer.Action != tt.action || offer.Note != "" {
+				t.Errorf("offer = %s %s %q, want %s %s with no note", offer.Side, offer.Action, offer.Note, wantSide, tt.action)
+			}
+		})
+	}
+}
+
+func sameRate(a, b *entity.Money) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	ErrNotAWorker        = errors.New("only workers can be added to a pool or invited")
+	ErrNotInPool         = errors.New("this worker is not in your pool")
+)
+
+// PoolService manages each business's worker pool (favourites), invites and
+// who may see a shift:
+//
+//   - PUBLIC shifts are seen by everyone
+//   - POOL shifts by the owner's pool and invited workers
+//   - INVITE shifts by invited workers only
+//
+// Owners, admins and workers who already applied always see the shift. A
+// restricted shift with a release time becomes PUBLIC once it passes. Workers
+// never see the shifts of a business they have a block with (see BlockService).
+type PoolService struct {
+	poolRepo    port.PoolRepository
+	shiftRepo   port.ShiftRepository
+	userRepo    port.UserRepository
+	lifecycle   *LifecycleService
+	blocks      *BlockService
+	broadcaster port.Broadcaster
+}
+
+func NewPoolService(
+	poolRepo port.PoolRepository,
+	shiftRepo port.ShiftRepository,
+	userRepo port.UserRepository,
+	lifecycle *LifecycleService,
+	blocks *BlockService,
+	broadcaster port.Broadcaster,
+) *PoolService {
+	return &PoolService{
+		poolRepo:    poolRepo,
+		shiftRepo:   shiftRepo,
+		userRepo:    userRepo,
+		lifecycle:   lifecycle,
+		blocks:      blocks,
+		broadcaster: broadcaster,
+	}
+}
+
+// AddToPool adds a worker to the business's pool (or updates the note)
+func (s *PoolService) AddToPool(ctx context.Context, businessID, workerID int64, note string) (*entity.PoolMember, error) {
+	if err := s.checkWorker(ctx, workerID); err != nil {
+		return nil, err
+	}
+	if err := s.blocks.CheckNotBlocked(ctx, businessID, workerID); err != nil {
+		return nil, err
+	}
+	member := &entity.PoolMember{BusinessID: businessID, WorkerID: workerID, Note: strings.TrimSpace(note)}
+	if err := s.poolRepo.AddPoolMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveFromPool takes a worker out of the business's pool
+func (s *PoolService) RemoveFromPool(ctx context.Context, businessID, workerID int64) error {
+	removed, err := s.poolRepo.RemovePoolMember(ctx, businessID, workerID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotInPool
+	}
+	return nil
+}
+
+// GetPool lists the business's pool
+func (s *PoolService) GetPool(ctx context.Context, businessID int64) ([]entity.PoolMember, error) {
+	return s.poolRepo.GetPoolMembers(ctx, businessID)
+}
+
+// SetVisibility changes who may see the shift. releaseAfter (from now) makes
+// a restricted shift PUBLIC if it is still unfilled by then; 0 never does.
+func (s *PoolService) SetVisibility(ctx context.Context, shiftID, businessID int64, visibility entity.ShiftVisibility, releaseAfter time.Duration) (*entity.Shift, error) {
+	shift, err := s.ownedShift(ctx, shiftID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	var releaseAt *time.Time
+	if releaseAfter != 0 {
+		at := time.Now().Add(releaseAfter)
+		releaseAt = &at
+	}
+	if err := checkVisibility(visibility, releaseAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.poolRepo.SetVisibility(ctx, shiftID, visibility, releaseAt); err != nil {
+		return nil, err
+	}
+
+	wasPublic := shift.IsPublic(time.Now())
+	shift.Visibility, shift.ReleaseAt = visibility, releaseAt
+	s.lifecycle.SyncGeo(ctx, shift)
+	if !wasPublic && shift.IsPublic(time.Now()) {
+		s.announce(shift)
+	}
+	return shift, nil
+}
+
+// Invite lets the workers see and apply to the shift whatever its visibility
+func (s *PoolService) Invite(ctx context.Context, shiftID, businessID int64, workerIDs []int64) ([]entity.ShiftInvite, error) {
+	if _, err := s.ownedShift(ctx, shiftID, businessID); err != nil {
+		return nil, err
+	}
+	blocked, err := s.blocks.BlockedWith(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, workerID := range workerIDs {
+		if err := s.checkWorker(ctx, workerID); err != nil {
+			return nil, fmt.Errorf("%w (user %d)", err, workerID)
+		}
+		if blocked[workerID] {
+			return nil, fmt.Errorf("%w (user %d)", ErrBlocked, workerID)
+		}
+	}
+	if err := s.poolRepo.InviteWorkers(ctx, shiftID, workerIDs); err != nil {
+		return nil, err
+	}
+	return s.poolRepo.GetInvites(ctx, shiftID)
+}
+
+// GetInvites lists the workers invited to the shift (owner only)
+func (s *PoolService) GetInvites(ctx context.Context, shiftID, businessID int64) ([]entity.ShiftInvite, error) {
+	if _, err := s.ownedShift(ctx, shiftID, businessID); err != nil {
+		return nil, err
+	}
+	return s.poolRepo.GetInvites(ctx, shiftID)
+}
+
+// FilterVisible drops the shifts the user may not see
+func (s *PoolService) FilterVisible(ctx context.Context, userID int64, role string, shifts []entity.Shift) ([]entity.Shift, error) {
+	if len(shifts) == 0 {
+		return shifts, nil
+	}
+	blocked := map[int64]bool{}
+	if role == "worker" {
+		var err error
+		if blocked, err = s.blocks.BlockedWith(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var restricted []int64
+	for _, shift := range shifts {
+		if !blocked[shift.OwnerID] && !s.seesAnyway(userID, role, shift, now) {
+			restricted = append(restricted, shift.ID)
+		}
+	}
+	admitted := map[int64]bool{}
+	if role == "worker" && len(restricted) > 0 {
+		var err error
+		if admitted, err = s.poolRepo.GetAdmittedShifts(ctx, userID, restricted); err != nil {
+			return nil, err
+		}
+	}
+
+	visible := make([]entity.Shift, 0, len(shifts))
+	for _, shift := range shifts {
+		if blocked[shift.OwnerID] {
+			continue
+		}
+		if s.seesAnyway(userID, role, shift, now) || admitted[shift.ID] {
+			visible = append(visible, shift)
+		}
+	}
+	return visible, nil
+}
+
+// CanSee reports whether the user may see the shift
+func (s *PoolService) CanSee(ctx context.Context, userID int64, role string, shift *entity.Shift) (bool, error) {
+	visible, err := s.FilterVisible(ctx, userID, role, []entity.Shift{*shift})
+	if err != nil {
+		return false, err
+	}
+	return len(visible) == 1, nil
+}
+
+// ReleaseDue opens unfilled restricted shifts to everyone once their release
+// time passes (background job)
+func (s *PoolService) ReleaseDue(ctx context.Context) error {
+	ids, err := s.poolRepo.ReleaseDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		shift, err := s.shiftRepo.GetShiftByID(ctx, id)
+		if err != nil {
+			fmt.Printf("⚠️ Redis sync warning: %v\n", err)
+			continue
+		}
+		s.lifecycle.SyncGeo(ctx, shift)
+		s.announce(shift)
+		fmt.Printf("📢 Shift released to everyone: %d\n", id)
+	}
+	return nil
+}
+
+// seesAnyway: public shifts, the owner's own shifts and admins need no lookup
+func (s *PoolService) seesAnyway(userID int64, role string, shift entity.Shift, now time.Time) bool {
+	return shift.IsPublic(now) || shift.OwnerID == userID || role == "admin"
+}
+
+// announce tells the map about a shift everyone can now see
+func (s *PoolService) announce(shift *entity.Shift) {
+	if s.broadcaster == nil || shift.Status != entity.ShiftOpen {
+		return
+	}
+	msg := map[string]interface{}{
+		"type":     "shift_released",
+		"id":       shift.ID,
+		"title":    shift.Title,
+		"lat":      shift.Lat,
+		"lng":      shift.Lng,
+		"pay_rate": shift.PayRate,
+		"pay_unit": shift.PayUnit,
+		"status":   shift.Status,
+	}
+	if shift.CategoryID != nil {
+		msg["category_id"] = *shift.CategoryID
+	}
+	s.broadcaster.Broadcast(msg)
+}
+
+func (s *PoolService) ownedShift(ctx context.Context, shiftID, businessID int64) (*entity.Shift, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+	return shift, nil
+}
+
+func (s *PoolService) checkWorker(ctx context.Context, workerID int64) error {
+	user, err := s.userRepo.GetUserByID(ctx, workerID)
+	if err != nil {
+		return fmt.Errorf("failed to load user: %w", err)
+	}
+	if user == nil || user.Role != "worker" {
+		return ErrNotAWorker
+	}
+	return nil
+}
+
+// checkVisibility validates a visibility setting; only restricted shifts can
+// have a release time, and it must lie ahead
+func checkVisibility(visibility entity.ShiftVisibility, releaseAt *time.Time, now time.Time) error {
+	switch visibility {
+	case entity.VisibilityPublic:
+		if releaseAt != nil {
+			return fmt.Errorf("%w: only restricted shifts can be released later", ErrInvalidVisibility)
+		}
+	case entity.VisibilityPool, entity.VisibilityInvite:
+		if releaseAt != nil && !releaseAt.After(now) {
+			return fmt.Errorf("%w: the release time must be in the future", ErrInvalidVisibility)
+		}
+	default:
+		return fmt.Errorf("%w: must be PUBLIC, POOL or INVITE", ErrInvalidVisibility)
+	}
+	return nil
+}
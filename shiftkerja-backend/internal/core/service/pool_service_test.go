@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakePoolRepo admits each worker into the listed restricted shifts
+type fakePoolRepo struct {
+	port.PoolRepository
+	admitted map[int64][]int64 // Worker → shifts
+}
+
+func (r *fakePoolRepo) GetAdmittedShifts(_ context.Context, workerID int64, shiftIDs []int64) (map[int64]bool, error) {
+	admitted := map[int64]bool{}
+	for _, id := range shiftIDs {
+		if slices.Contains(r.admitted[workerID], id) {
+			admitted[id] = true
+		}
+	}
+	return admitted, nil
+}
+
+func TestCheckVisibility(t *testing.T) {
+	now := time.Now()
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		visibility entity.ShiftVisibility
+		releaseAt  *time.Time
+		want       error
+	}{
+		{"public", entity.VisibilityPublic, nil, nil},
+		{"pool", entity.VisibilityPool, nil, nil},
+		{"pool released later", entity.VisibilityPool, &later, nil},
+		{"invite released later", entity.VisibilityInvite, &later, nil},
+		{"public released later", entity.VisibilityPublic, &later, ErrInvalidVisibility},
+		{"release time passed", entity.VisibilityInvite, &earlier, ErrInvalidVisibility},
+		{"release time now", entity.VisibilityPool, &now, ErrInvalidVisibility},
+		{"unknown", "FRIENDS", nil, ErrInvalidVisibility},
+		{"empty", "", nil, ErrInvalidVisibility},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkVisibility(tt.visibility, tt.releaseAt, now); !errors.Is(err, tt.want) {
+				t.Errorf("checkVisibility() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTemplateVisibility(t *testing.T) {
+	tests := []struct {
+		name           string
+		visibility     entity.ShiftVisibility
+		releaseAfter   float64
+		want           error
+		wantVisibility entity.ShiftVisibility
+	}{
+		{"defaults to public", "", 0, nil, entity.VisibilityPublic},
+		{"pool", entity.VisibilityPool, 0, nil, entity.VisibilityPool},
+		{"pool released later", entity.VisibilityPool, 12, nil, entity.VisibilityPool},
+		{"invite", entity.VisibilityInvite, 0, ErrInvalidVisibility, entity.VisibilityInvite},
+		{"public released later", entity.VisibilityPublic, 12, ErrInvalidVisibility, entity.VisibilityPublic},
+		{"released before it is posted", entity.VisibilityPool, -1, ErrInvalidVisibility, entity.VisibilityPool},
+		{"unknown", "FRIENDS", 0, ErrInvalidVisibility, "FRIENDS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &entity.ShiftTemplate{Visibility: tt.visibility, ReleaseAfterHours: tt.releaseAfter}
+			if err := checkTemplateVisibility(template); !errors.Is(err, tt.want) {
+				t.Errorf("checkTemplateVisibility() = %v, want %v", err, tt.want)
+			}
+			if template.Visibility != tt.wantVisibility {
+				t.Errorf("Visibility = %s, want %s", template.Visibility, tt.wantVisibility)
+			}
+		})
+	}
+}
+
+func TestFilterVisible(t *testing.T) {
+	const business, other = 100, 200
+	released := time.Now().Add(-time.Minute)
+	pending := time.Now().Add(time.Hour)
+	shifts := []entity.Shift{
+		{ID: 1, OwnerID: business, Visibility: entity.VisibilityPublic},
+		{ID: 2, OwnerID: business, Visibility: entity.VisibilityPool},
+		{ID: 3, OwnerID: business, Visibility: entity.VisibilityInvite},
+		{ID: 4, OwnerID: business, Visibility: entity.VisibilityPool, ReleaseAt: &released},
+		{ID: 5, OwnerID: business, Visibility: entity.VisibilityInvite, ReleaseAt: &pending},
+		{ID: 6, OwnerID: other, Visibility: entity.VisibilityPublic},
+		{ID: 7, OwnerID: other, Visibility: entity.VisibilityPool},
+	}
+
+	// Worker 1 is in the business's pool, worker 2 was invited to shift 3,
+	// worker 3 blocked the other business
+	pool := &fakePoolRepo{admitted: map[int64][]int64{1: {2}, 2: {3}, 3: {2, 7}}}
+	blocks := NewBlockService(&fakeBlockRepo{blocks: [][2]int64{{3, other}}}, nil)
+	s := &PoolService{poolRepo: pool, blocks: blocks}
+
+	tests := []struct {
+		name   string
+		userID int64
+		role   string
+		want   []int64
+	}{
+		{"stranger", 9, "worker", []int64{1, 4, 6}},
+		{"in the pool", 1, "worker", []int64{1, 2, 4, 6}},
+		{"invited", 2, "worker", []int64{1, 3, 4, 6}},
+		{"blocked business hidden even when admitted", 3, "worker", []int64{1, 2, 4}},
+		{"owner", business, "business", []int64{1, 2, 3, 4, 5, 6}},
+		{"other business", other, "business", []int64{1, 4, 6, 7}},
+		{"admin", 500, "admin", []int64{1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visible, err := s.FilterVisible(context.Background(), tt.userID, tt.role, shifts)
+			if err != nil {
+				t.Fatalf("FilterVisible() = %v", err)
+			}
+			var got []int64
+			for _, shift := range visible {
+				got = append(got, shift.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("visible shifts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidRating      = errors.New("invalid rating")
+	ErrNotRatable         = errors.New("only completed accepted applications can be rated")
+	ErrRatingWindowClosed = errors.New("the rating window for this shift has closed")
+	ErrAlreadyRated       = errors.New("you have already rated this shift")
+)
+
+const (
+	maxRatingTags      = 5
+	maxRatingComment   = 1000
+	defaultRatingLimit = 20
+)
+
+// RatingConfig tunes the rating window
+type RatingConfig struct {
+	// Window is how long after the work ended both sides can rate; a rating
+	// whose counterpart never came is published when it closes
+	Window time.Duration
+}
+
+// DefaultRatingConfig returns the settings used in production
+func DefaultRatingConfig() RatingConfig {
+	return RatingConfig{Window: 14 * 24 * time.Hour}
+}
+
+// RatingInput is one side's feedback on an application
+type RatingInput struct {
+	ApplicationID int64
+	Stars         int
+	Tags          []string
+	Comment       string
+}
+
+// RatingService handles two-sided ratings. Each side's rating is hidden from
+// the other until both have rated or the window closes, so neither can
+// retaliate against what they read.
+type RatingService struct {
+	ratingRepo port.RatingRepository
+	shiftRepo  port.ShiftRepository
+	config     RatingConfig
+}
+
+func NewRatingService(ratingRepo port.RatingRepository, shiftRepo port.ShiftRepository, config RatingConfig) *RatingService {
+	return &RatingService{ratingRepo: ratingRepo, shiftRepo: shiftRepo, config: config}
+}
+
+// Rate records the caller's rating of the other side of an application:
+// a worker rates the business, the business rates the worker
+func (s *RatingService) Rate(ctx context.Context, raterID int64, in RatingInput) (*entity.Rating, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, in.ApplicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+
+	rating := &entity.Rating{ApplicationID: app.ID, ShiftID: shift.ID, RaterID: raterID}
+	switch raterID {
+	case app.WorkerID:
+		rating.Direction, rating.RateeID = entity.RateBusiness, shift.OwnerID
+	case shift.OwnerID:
+		rating.Direction, rating.RateeID = entity.RateWorker, app.WorkerID
+	default:
+		return nil, ErrUnauthorized
+	}
+
+	// Only work that actually happened can be rated
+	if app.Status != entity.ApplicationAccepted {
+		return nil, ErrNotRatable
+	}
+	completedAt, err := s.ratingRepo.GetCompletedAt(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if completedAt == nil || completedAt.After(now) {
+		return nil, ErrNotRatable
+	}
+	rating.DeadlineAt = completedAt.Add(s.config.Window)
+	if now.After(rating.DeadlineAt) {
+		return nil, ErrRatingWindowClosed
+	}
+
+	if err := validateRating(rating, in); err != nil {
+		return nil, err
+	}
+
+	created, err := s.ratingRepo.CreateRating(ctx, rating)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrAlreadyRated
+	}
+	return rating, nil
+}
+
+// GetApplicationRatings returns the ratings of an application to one of its
+// two sides. The other side's rating is left out while it is still hidden.
+func (s *RatingService) GetApplicationRatings(ctx context.Context, applicationID, userID int64) ([]entity.Rating, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if userID != app.WorkerID && userID != shift.OwnerID {
+		return nil, ErrUnauthorized
+	}
+
+	ratings, err := s.ratingRepo.GetRatingsByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []entity.Rating
+	for _, r := range ratings {
+		if r.RaterID == userID || r.PublishedAt != nil {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
+
+// GetUserRatings returns a user's rating summary and newest published ratings
+func (s *RatingService) GetUserRatings(ctx context.Context, userID int64, limit int) (entity.RatingSummary, []entity.Rating, error) {
+	if limit <= 0 || limit > 100 {
+		limit = defaultRatingLimit
+	}
+	summaries, err := s.ratingRepo.GetRatingSummaries(ctx, []int64{userID})
+	if err != nil {
+		return entity.RatingSummary{}, nil, err
+	}
+	ratings, err := s.ratingRepo.GetPublishedRatings(ctx, userID, limit)
+	if err != nil {
+		return entity.RatingSummary{}, nil, err
+	}
+	return summaries[userID], ratings, nil
+}
+
+// PublishDue reveals ratings whose window has closed (background job)
+func (s *RatingService) PublishDue(ctx context.Context) error {
+	published, err := s.ratingRepo.PublishDueRatings(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if published > 0 {
+		fmt.Printf("⭐ Published %d ratings after their window closed\n", published)
+	}
+	return nil
+}
+
+// validateRating checks stars, tags and comment and copies them, normalised, onto the rating
+func validateRating(rating *entity.Rating, in RatingInput) error {
+	if in.Stars < 1 || in.Stars > 5 {
+		return fmt.Errorf("%w: stars must be between 1 and 5", ErrInvalidRating)
+	}
+	rating.Stars = in.Stars
+
+	allowed := map[string]bool{}
+	for _, tag := range entity.RatingTags[rating.Direction] {
+		allowed[tag] = true
+	}
+	seen := map[string]bool{}
+	rating.Tags = []string{}
+	for _, tag := range in.Tags {
+		tag = strings.ToUpper(strings.TrimSpace(tag))
+		if !allowed[tag] {
+			return fmt.Errorf("%w: unknown tag %q", ErrInvalidRating, tag)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			rating.Tags = append(rating.Tags, tag)
+		}
+	}
+	if len(rating.Tags) > maxRatingTags {
+		return fmt.Errorf("%w: at most %d tags", ErrInvalidRating, maxRatingTags)
+	}
+
+	rating.Comment = strings.TrimSpace(in.Comment)
+	if utf8.RuneCountInString(rating.Comment) > maxRatingComment {
+		return fmt.Errorf("%w: comment is limited to %d characters", ErrInvalidRating, maxRatingComment)
+	}
+	return nil
+}
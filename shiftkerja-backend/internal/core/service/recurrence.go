@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRRule = errors.New("invalid recurrence rule")
+
+// maxOccurrences bounds COUNT so a typo can't flood the shifts table
+const maxOccurrences = 366
+
+// RecurrenceRule is the subset of RFC 5545 RRULE we support:
+// FREQ=DAILY|WEEKLY, INTERVAL, BYDAY (weekly only) and either UNTIL or COUNT.
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time // Inclusive, civil date in UTC
+	Count    int
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRRule parses e.g. "FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20261231" or "FREQ=WEEKLY;BYDAY=MO;COUNT=8"
+func ParseRRule(raw string) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "RRULE:")
+
+	for _, part := range strings.Split(raw, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		switch key {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" {
+				return nil, fmt.Errorf("%w: only FREQ=DAILY or FREQ=WEEKLY are supported", ErrInvalidRRule)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRRule)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("%w: unknown weekday %q", ErrInvalidRRule, day)
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "UNTIL":
+			// Accept both the date form (20261231) and the date-time form (20261231T235959Z)
+			until, err := time.Parse("20060102", value[:min(len(value), 8)])
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL must look like 20261231", ErrInvalidRRule)
+			}
+			rule.Until = &until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxOccurrences {
+				return nil, fmt.Errorf("%w: COUNT must be between 1 and %d", ErrInvalidRRule, maxOccurrences)
+			}
+			rule.Count = n
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return nil, fmt.Errorf("%w: use either UNTIL or COUNT, not both", ErrInvalidRRule)
+	}
+	if rule.Until == nil && rule.Count == 0 {
+		return nil, fmt.Errorf("%w: an end is required (UNTIL or COUNT)", ErrInvalidRRule)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != "WEEKLY" {
+		return nil, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRRule)
+	}
+	return rule, nil
+}
+
+// Occurrences lists the dates matched by the rule from startsOn up to and including
+// through. Dates are civil dates (midnight UTC). exhausted is true once the rule
+// has no dates left after through.
+func (r *RecurrenceRule) Occurrences(startsOn, through time.Time) (dates []time.Time, exhausted bool) {
+	startsOn = civilDate(startsOn)
+	through = civilDate(through)
+
+	byDay := r.ByDay
+	if r.Freq == "WEEKLY" && len(byDay) == 0 {
+		byDay = []time.Weekday{startsOn.Weekday()}
+	}
+	// Weeks are counted from the Monday on or before startsOn (RFC 5545 default WKST=MO)
+	weekStart := startsOn.AddDate(0, 0, -((int(startsOn.Weekday()) + 6) % 7))
+
+	matched := 0
+	for day := startsOn; !day.After(through); day = day.AddDate(0, 0, 1) {
+		if r.Until != nil && day.After(*r.Until) {
+			return dates, true
+		}
+
+		var match bool
+		switch r.Freq {
+		case "DAILY":
+			match = int(day.Sub(startsOn).Hours()/24)%r.Interval == 0
+		case "WEEKLY":
+			week := int(day.Sub(weekStart).Hours()/24) / 7
+			match = week%r.Interval == 0 && containsWeekday(byDay, day.Weekday())
+		}
+		if !match {
+			continue
+		}
+
+		matched++
+		if r.Count > 0 && matched > r.Count {
+			return dates, true
+		}
+		dates = append(dates, day)
+		if r.Count > 0 && matched == r.Count {
+			return dates, true
+		}
+	}
+
+	if r.Until != nil && !through.Before(*r.Until) {
+		return dates, true
+	}
+	return dates, false
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// civilDate drops the time of day and timezone, keeping the calendar date
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
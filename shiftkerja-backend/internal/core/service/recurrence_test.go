@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseRRule(t *testing.T) {
+	valid := []string{
+		"FREQ=DAILY;COUNT=5",
+		"RRULE:FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20261231",
+		"freq=weekly;interval=2;byday=mo;until=20261231T235959Z",
+	}
+	for _, raw := range valid {
+		if _, err := ParseRRule(raw); err != nil {
+			t.Errorf("ParseRRule(%q) error = %v", raw, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"FREQ=MONTHLY;COUNT=3",
+		"FREQ=DAILY",
+		"FREQ=DAILY;COUNT=3;UNTIL=20261231",
+		"FREQ=DAILY;BYDAY=MO;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=XX;COUNT=3",
+		"FREQ=DAILY;INTERVAL=0;COUNT=3",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=367",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;COUNT",
+		"FREQ=DAILY;BYMONTH=1;COUNT=3",
+	}
+	for _, raw := range invalid {
+		if _, err := ParseRRule(raw); !errors.Is(err, ErrInvalidRRule) {
+			t.Errorf("ParseRRule(%q) error = %v, want %v", raw, err, ErrInvalidRRule)
+		}
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	tests := []struct {
+		name          string
+		rule          string
+		startsOn      time.Time
+		through       string
+		want          []string
+		wantExhausted bool
+	}{
+		{
+			name:     "daily with interval and count",
+			rule:     "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			startsOn: date("2026-11-02"),
+			through:  "2026-12-31",
+			want:     []string{"2026-11-02", "2026-11-04", "2026-11-06"}, wantExhausted: true,
+		},
+		{
+			name:     "weekends until an inclusive date",
+			rule:     "FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20261115",
+			startsOn: date("2026-11-02"),
+			through:  "2026-12-31",
+			want:     []string{"2026-11-07", "2026-11-08", "2026-11-14", "2026-11-15"}, wantExhausted: true,
+		},
+		{
+			name:     "weekly defaults to the start weekday",
+			rule:     "FREQ=WEEKLY;COUNT=10",
+			startsOn: date("2026-11-04"),
+			through:  "2026-11-20",
+			want:     []string{"2026-11-04", "2026-11-11", "2026-11-18"},
+		},
+		{
+			name:     "every other week counts from the Monday of the start week",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4",
+			startsOn: date("2026-11-04"),
+			through:  "2026-12-31",
+			want:     []string{"2026-11-06", "2026-11-16", "2026-11-20", "2026-11-30"}, wantExhausted: true,
+		},
+		{
+			name:     "window ends before the rule does",
+			rule:     "FREQ=DAILY;UNTIL=20261110",
+			startsOn: date("2026-11-01"),
+			through:  "2026-11-03",
+			want:     []string{"2026-11-01", "2026-11-02", "2026-11-03"},
+		},
+		{
+			name:     "until before the start",
+			rule:     "FREQ=DAILY;UNTIL=20261031",
+			startsOn: date("2026-11-01"),
+			through:  "2026-11-30",
+			want:     nil, wantExhausted: true,
+		},
+		{
+			name:     "start time and zone are dropped",
+			rule:     "FREQ=DAILY;COUNT=2",
+			startsOn: time.Date(2026, 11, 2, 23, 30, 0, 0, time.FixedZone("WIB", 7*60*60)),
+			through:  "2026-12-31",
+			want:     []string{"2026-11-02", "2026-11-03"}, wantExhausted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", tt.rule, err)
+			}
+			dates, exhausted := rule.Occurrences(tt.startsOn, date(tt.through))
+
+			got := make([]string, len(dates))
+			for i, d := range dates {
+				got[i] = d.Format("2006-01-02")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+				}
+			}
+			if exhausted != tt.wantExhausted {
+				t.Errorf("exhausted = %v, want %v", exhausted, tt.wantExhausted)
+			}
+		})
+	}
+}
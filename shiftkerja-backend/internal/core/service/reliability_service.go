@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrWorkerSuspended          = errors.New("you are temporarily suspended from applying")
+	ErrReliabilityEventNotFound = errors.New("reliability event not found")
+	ErrAppealNotAllowed         = errors.New("this event can't be appealed")
+	ErrInvalidAppeal            = errors.New("invalid appeal")
+)
+
+// Appeal decisions
+const (
+	AppealUphold   = "UPHOLD"
+	AppealOverturn = "OVERTURN"
+)
+
+// SuspensionThreshold blocks applying for Duration once a worker has Strikes
+// strikes inside the rolling window
+type SuspensionThreshold struct {
+	Strikes  int
+	Duration time.Duration
+}
+
+// ReliabilityConfig tunes no-show detection and the strike policy
+type ReliabilityConfig struct {
+	Window                time.Duration // Rolling window for the score and for strikes
+	NoShowGrace           time.Duration // A shift started this long ago without a clock-in is a no-show
+	NoShowLookback        time.Duration // Older shifts are not checked (no backfill of old history)
+	LateWithdrawalCutoff  time.Duration // Withdrawing this close to the start is a late withdrawal
+	NoShowStrikes         int
+	LateWithdrawalStrikes int
+	Suspensions           []SuspensionThreshold
+}
+
+// DefaultReliabilityConfig returns the settings used in production
+func DefaultReliabilityConfig() ReliabilityConfig {
+	return ReliabilityConfig{
+		Window:                90 * 24 * time.Hour,
+		NoShowGrace:           time.Hour,
+		NoShowLookback:        7 * 24 * time.Hour,
+		LateWithdrawalCutoff:  24 * time.Hour,
+		NoShowStrikes:         2,
+		LateWithdrawalStrikes: 1,
+		Suspensions: []SuspensionThreshold{
+			{Strikes: 3, Duration: 7 * 24 * time.Hour},
+			{Strikes: 5, Duration: 30 * 24 * time.Hour},
+		},
+	}
+}
+
+// ParseSuspensionThresholds reads thresholds written as "strikes:duration"
+// pairs, e.g. "3:168h,5:720h"
+func ParseSuspensionThresholds(raw string) ([]SuspensionThreshold, error) {
+	var thresholds []SuspensionThreshold
+	for _, part := range strings.Split(raw, ",") {
+		strikes, duration, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("threshold %q must be strikes:duration", part)
+		}
+		n, err := strconv.Atoi(strikes)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("threshold %q: strikes must be a positive integer", part)
+		}
+		d, err := time.ParseDuration(duration)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("threshold %q: invalid duration", part)
+		}
+		thresholds = append(thresholds, SuspensionThreshold{Strikes: n, Duration: d})
+	}
+	return thresholds, nil
+}
+
+// ReliabilityService records no-shows and late withdrawals, scores workers on
+// them and suspends workers who collect too many strikes
+type ReliabilityService struct {
+	repo   port.ReliabilityRepository
+	config ReliabilityConfig
+}
+
+func NewReliabilityService(repo port.ReliabilityRepository, config ReliabilityConfig) *ReliabilityService {
+	sort.Slice(config.Suspensions, func(i, j int) bool {
+		return config.Suspensions[i].Strikes < config.Suspensions[j].Strikes
+	})
+	return &ReliabilityService{repo: repo, config: config}
+}
+
+// DetectNoShows records accepted workers who never clocked in (background job)
+func (s *ReliabilityService) DetectNoShows(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.config.NoShowGrace)
+	events, err := s.repo.RecordNoShows(ctx, cutoff.Add(-s.config.NoShowLookback), cutoff, s.config.NoShowStrikes)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		fmt.Printf("🚫 No-show recorded: Worker %d, shift %q\n", e.WorkerID, e.ShiftTitle)
+	}
+	return nil
+}
+
+// IsLateWithdrawal reports whether pulling out of the shift at the given time
+// is within the cutoff before its start
+func (s *ReliabilityService) IsLateWithdrawal(shift *entity.Shift, at time.Time) bool {
+	return shift.StartsAt != nil && shift.StartsAt.Sub(at) < s.config.LateWithdrawalCutoff
+}
+
+// RecordWithdrawal records a late withdrawal if the worker pulled out of the
+// shift within the cutoff before its start. Other withdrawals are free.
+func (s *ReliabilityService) RecordWithdrawal(ctx context.Context, app *entity.Application, shift *entity.Shift, at time.Time) error {
+	if !s.IsLateWithdrawal(shift, at) {
+		return nil
+	}
+	appID, shiftID := app.ID, shift.ID
+	event := &entity.ReliabilityEvent{
+		WorkerID:      app.WorkerID,
+		ApplicationID: &appID,
+		ShiftID:       &shiftID,
+		Kind:          entity.ReliabilityLateWithdrawal,
+		Strikes:       s.config.LateWithdrawalStrikes,
+		OccurredAt:    at,
+		Detail:        fmt.Sprintf("Withdrew %s before the start", shift.StartsAt.Sub(at).Round(time.Minute)),
+	}
+	if _, err := s.repo.CreateEvent(ctx, event); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckCanApply returns ErrWorkerSuspended (wrapped with the end date) while
+// the worker is suspended
+func (s *ReliabilityService) CheckCanApply(ctx context.Context, workerID int64) error {
+	now := time.Now()
+	events, err := s.repo.GetEventsByWorker(ctx, workerID, now.Add(-s.config.Window))
+	if err != nil {
+		return fmt.Errorf("failed to check suspension: %w", err)
+	}
+	if until := s.suspendedUntil(events, now); until != nil {
+		return fmt.Errorf("%w until %s", ErrWorkerSuspended, until.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// GetReliability returns the worker's rolling score, strikes, suspension and events
+func (s *ReliabilityService) GetReliability(ctx context.Context, workerID int64) (*entity.WorkerReliability, error) {
+	now := time.Now()
+	since := now.Add(-s.config.Window)
+	events, err := s.repo.GetEventsByWorker(ctx, workerID, since)
+	if err != nil {
+		return nil, err
+	}
+	attended, err := s.repo.CountAttended(ctx, workerID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := &entity.WorkerReliability{
+		WorkerID:       workerID,
+		WindowDays:     int(s.config.Window.Hours() / 24),
+		Attended:       attended,
+		SuspendedUntil: s.suspendedUntil(events, now),
+		Events:         events,
+	}
+	for _, e := range events {
+		if !e.Counts() {
+			continue
+		}
+		rel.Strikes += e.Strikes
+		switch e.Kind {
+		case entity.ReliabilityNoShow:
+			rel.NoShows++
+		case entity.ReliabilityLateWithdrawal:
+			rel.LateWithdrawals++
+		}
+	}
+	if total := rel.Attended + rel.NoShows + rel.LateWithdrawals; total > 0 {
+		score := math.Round(1000*float64(rel.Attended)/float64(total)) / 10
+		rel.Score = &score
+	}
+	return rel, nil
+}
+
+// Appeal lets a worker contest one of their events
+func (s *ReliabilityService) Appeal(ctx context.Context, workerID, eventID int64, reason string) (*entity.ReliabilityEvent, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrReliabilityEventNotFound
+	}
+	if event.WorkerID != workerID {
+		return nil, ErrUnauthorized
+	}
+	if event.AppealStatus != entity.AppealNone {
+		return nil, fmt.Errorf("%w: it has already been appealed", ErrAppealNotAllowed)
+	}
+	if time.Since(event.CreatedAt) > s.config.Window {
+		return nil, fmt.Errorf("%w: it no longer counts", ErrAppealNotAllowed)
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidAppeal)
+	}
+
+	event.AppealStatus = entity.AppealPending
+	event.AppealReason = reason
+	return event, s.saveAppeal(ctx, event, entity.AppealNone)
+}
+
+// GetAppeals lists events by appeal status for the admin queue (default PENDING)
+func (s *ReliabilityService) GetAppeals(ctx context.Context, status string) ([]entity.ReliabilityEvent, error) {
+	if status == "" {
+		status = entity.AppealPending
+	}
+	switch status {
+	case entity.AppealPending, entity.AppealUpheld, entity.AppealOverturned:
+	default:
+		return nil, fmt.Errorf("%w: status must be PENDING, UPHELD or OVERTURNED", ErrInvalidAppeal)
+	}
+	return s.repo.GetEventsByAppealStatus(ctx, status)
+}
+
+// DecideAppeal lets an admin uphold or overturn a pending appeal.
+// An overturned event stops counting, which can lift a suspension.
+func (s *ReliabilityService) DecideAppeal(ctx context.Context, adminID, eventID int64, decision, note string) (*entity.ReliabilityEvent, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrReliabilityEventNotFound
+	}
+	if event.AppealStatus != entity.AppealPending {
+		return nil, fmt.Errorf("%w: there is no pending appeal", ErrAppealNotAllowed)
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(decision)) {
+	case AppealUphold:
+		event.AppealStatus = entity.AppealUpheld
+	case AppealOverturn:
+		event.AppealStatus = entity.AppealOverturned
+	default:
+		return nil, fmt.Errorf("%w: decision must be UPHOLD or OVERTURN", ErrInvalidAppeal)
+	}
+	now := time.Now()
+	event.AppealNote = strings.TrimSpace(note)
+	event.AppealDecidedBy = &adminID
+	event.AppealDecidedAt = &now
+	return event, s.saveAppeal(ctx, event, entity.AppealPending)
+}
+
+func (s *ReliabilityService) saveAppeal(ctx context.Context, event *entity.ReliabilityEvent, fromStatus string) error {
+	updated, err := s.repo.UpdateAppeal(ctx, event, fromStatus)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("%w: it was changed by someone else, reload and try again", ErrAppealNotAllowed)
+	}
+	return nil
+}
+
+// suspendedUntil walks the counting events in the window oldest first; each
+// threshold reached suspends the worker for its duration from the event that
+// reached it. It returns nil if no suspension is still running.
+func (s *ReliabilityService) suspendedUntil(events []entity.ReliabilityEvent, now time.Time) *time.Time {
+	counting := make([]entity.ReliabilityEvent, 0, len(events))
+	for _, e := range events {
+		if e.Counts() && !e.CreatedAt.Before(now.Add(-s.config.Window)) {
+			counting = append(counting, e)
+		}
+	}
+	sort.Slice(counting, func(i, j int) bool {
+		return counting[i].CreatedAt.Before(counting[j].CreatedAt)
+	})
+
+	var until *time.Time
+	strikes, next := 0, 0
+	for _, e := range counting {
+		strikes += e.Strikes
+		for next < len(s.config.Suspensions) && strikes >= s.config.Suspensions[next].Strikes {
+			end := e.CreatedAt.Add(s.config.Suspensions[next].Duration)
+			if until == nil || end.After(*until) {
+				until = &end
+			}
+			next++
+		}
+	}
+	if until == nil || !until.After(now) {
+		return nil
+	}
+	return until
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidQuestions = errors.New("invalid screening questions")
+	ErrInvalidAnswers   = errors.New("invalid screening answers")
+	ErrQuestionsLocked  = errors.New("screening questions can't change once workers have applied")
+)
+
+// Limits of the application form
+const (
+	maxScreeningQuestions = 10
+	maxPromptLength       = 300
+	maxOptionLength       = 100
+	maxFreeTextAnswer     = 500
+	maxCoverNoteLength    = 1000
+)
+
+// ScreeningService manages the questions a business asks every applicant of a
+// shift (yes/no, multiple choice or free text). An answer the business marked
+// as a knockout rejects the application as soon as it is submitted.
+type ScreeningService struct {
+	screeningRepo port.ScreeningRepository
+	shiftRepo     port.ShiftRepository
+}
+
+func NewScreeningService(screeningRepo port.ScreeningRepository, shiftRepo port.ShiftRepository) *ScreeningService {
+	return &ScreeningService{screeningRepo: screeningRepo, shiftRepo: shiftRepo}
+}
+
+// SetQuestions replaces the shift's questions (owner only). An empty list
+// removes them. They can't change once somebody has applied.
+func (s *ScreeningService) SetQuestions(ctx context.Context, shiftID, businessID int64, questions []entity.ScreeningQuestion) ([]entity.ScreeningQuestion, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+	if shift.Status != entity.ShiftOpen {
+		return nil, fmt.Errorf("%w: the shift is %s", ErrQuestionsLocked, shift.Status)
+	}
+
+	questions, err = normalizeQuestions(questions)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveQuestions(ctx, shiftID, questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// GetQuestions lists the shift's questions. Knockout answers are left out
+// unless showKnockouts is set (the owner and admins).
+func (s *ScreeningService) GetQuestions(ctx context.Context, shiftID int64, showKnockouts bool) ([]entity.ScreeningQuestion, error) {
+	questions, err := s.screeningRepo.GetQuestions(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if !showKnockouts {
+		for i := range questions {
+			questions[i].KnockoutAnswers = nil
+		}
+	}
+	return questions, nil
+}
+
+// Screen checks an applicant's answers against the shift's questions and
+// returns them ready to store, and whether one of them is a knockout
+func (s *ScreeningService) Screen(ctx context.Context, shiftID int64, answers []entity.ScreeningAnswer) ([]entity.ScreeningAnswer, bool, error) {
+	questions, err := s.screeningRepo.GetQuestions(ctx, shiftID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load screening questions: %w", err)
+	}
+	return gradeAnswers(questions, answers)
+}
+
+// AttachAnswers fills in the screening answers of the applications
+func (s *ScreeningService) AttachAnswers(ctx context.Context, apps []entity.Application) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(apps))
+	for i := range apps {
+		ids[i] = apps[i].ID
+	}
+	answers, err := s.screeningRepo.GetAnswers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range apps {
+		apps[i].Answers = answers[apps[i].ID]
+	}
+	return nil
+}
+
+func (s *ScreeningService) saveQuestions(ctx context.Context, shiftID int64, questions []entity.ScreeningQuestion) error {
+	updated, err := s.screeningRepo.SetQuestions(ctx, shiftID, questions)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrQuestionsLocked
+	}
+	return nil
+}
+
+// normalizeQuestions validates a question list and tidies it up: prompts and
+// options trimmed, yes/no answers upper-cased, knockouts spelled like the
+// option they refer to
+func normalizeQuestions(questions []entity.ScreeningQuestion) ([]entity.ScreeningQuestion, error) {
+	if len(questions) > maxScreeningQuestions {
+		return nil, fmt.Errorf("%w: at most %d questions", ErrInvalidQuestions, maxScreeningQuestions)
+	}
+
+	normalized := make([]entity.ScreeningQuestion, 0, len(questions))
+	for i, q := range questions {
+		n := i + 1
+		q.ID, q.ShiftID, q.Position = 0, 0, n
+		q.Prompt = strings.TrimSpace(q.Prompt)
+		if q.Prompt == "" || len(q.Prompt) > maxPromptLength {
+			return nil, fmt.Errorf("%w: question %d needs a prompt of at most %d characters", ErrInvalidQuestions, n, maxPromptLength)
+		}
+
+		var choices []string
+		switch q.Kind {
+		case entity.QuestionYesNo:
+			q.Options = nil
+			choices = []string{entity.AnswerYes, entity.AnswerNo}
+		case entity.QuestionMultipleChoice:
+			options := []string{}
+			for _, o := range q.Options {
+				o = strings.TrimSpace(o)
+				if o == "" || len(o) > maxOptionLength {
+					return nil, fmt.Errorf("%w: question %d has an empty or too long option", ErrInvalidQuestions, n)
+				}
+				if matchChoice(options, o) != "" {
+					return nil, fmt.Errorf("%w: question %d lists %q twice", ErrInvalidQuestions, n, o)
+				}
+				options = append(options, o)
+			}
+			if len(options) < 2 {
+				return nil, fmt.Errorf("%w: question %d needs at least two options", ErrInvalidQuestions, n)
+			}
+			q.Options = options
+			choices = options
+		case entity.QuestionFreeText:
+			if len(q.Options) > 0 || len(q.KnockoutAnswers) > 0 {
+				return nil, fmt.Errorf("%w: free text question %d can't have options or knockout answers", ErrInvalidQuestions, n)
+			}
+			q.Options, q.KnockoutAnswers = nil, nil
+		default:
+			return nil, fmt.Errorf("%w: question %d must be YES_NO, MULTIPLE_CHOICE or FREE_TEXT", ErrInvalidQuestions, n)
+		}
+
+		if q.Kind != entity.QuestionFreeText {
+			knockouts := []string{}
+			for _, k := range q.KnockoutAnswers {
+				choice := matchChoice(choices, k)
+				if choice == "" {
+					return nil, fmt.Errorf("%w: knockout answer %q of question %d isn't one of its answers", ErrInvalidQuestions, k, n)
+				}
+				if !slices.Contains(knockouts, choice) {
+					knockouts = append(knockouts, choice)
+				}
+			}
+			if len(knockouts) == len(choices) {
+				return nil, fmt.Errorf("%w: every answer to question %d is a knockout", ErrInvalidQuestions, n)
+			}
+			q.KnockoutAnswers = knockouts
+		}
+		normalized = append(normalized, q)
+	}
+	return normalized, nil
+}
+
+// gradeAnswers checks the answers against the questions: every required
+// question answered once, choices picked from the list, free text kept short.
+// It reports whether any answer is a knockout.
+func gradeAnswers(questions []entity.ScreeningQuestion, answers []entity.ScreeningAnswer) ([]entity.ScreeningAnswer, bool, error) {
+	given := make(map[int64]string, len(answers))
+	for _, a := range answers {
+		if _, dup := given[a.QuestionID]; dup {
+			return nil, false, fmt.Errorf("%w: question %d is answered twice", ErrInvalidAnswers, a.QuestionID)
+		}
+		given[a.QuestionID] = strings.TrimSpace(a.Answer)
+	}
+
+	graded := make([]entity.ScreeningAnswer, 0, len(questions))
+	knockedOut := false
+	for _, q := range questions {
+		answer, ok := given[q.ID]
+		delete(given, q.ID)
+		if !ok || answer == "" {
+			if q.Required {
+				return nil, false, fmt.Errorf("%w: %q needs an answer", ErrInvalidAnswers, q.Prompt)
+			}
+			continue
+		}
+
+		switch q.Kind {
+		case entity.QuestionYesNo:
+			answer = matchChoice([]string{entity.AnswerYes, entity.AnswerNo}, answer)
+		case entity.QuestionMultipleChoice:
+			answer = matchChoice(q.Options, answer)
+		case entity.QuestionFreeText:
+			if len(answer) > maxFreeTextAnswer {
+				return nil, false, fmt.Errorf("%w: the answer to %q is longer than %d characters", ErrInvalidAnswers, q.Prompt, maxFreeTextAnswer)
+			}
+		}
+		if answer == "" {
+			return nil, false, fmt.Errorf("%w: %q must be answered with one of its options", ErrInvalidAnswers, q.Prompt)
+		}
+
+		knockout := slices.Contains(q.KnockoutAnswers, answer)
+		knockedOut = knockedOut || knockout
+		graded = append(graded, entity.ScreeningAnswer{QuestionID: q.ID, Answer: answer, Knockout: knockout, Prompt: q.Prompt})
+	}
+	for id := range given {
+		return nil, false, fmt.Errorf("%w: question %d isn't asked for this shift", ErrInvalidAnswers, id)
+	}
+	return graded, knockedOut, nil
+}
+
+// matchChoice returns the choice the answer stands for (ignoring case), or ""
+func matchChoice(choices []string, answer string) string {
+	answer = strings.TrimSpace(answer)
+	for _, c := range choices {
+		if strings.EqualFold(c, answer) {
+			return c
+		}
+	}
+	return ""
+}
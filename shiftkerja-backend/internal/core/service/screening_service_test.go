@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+func TestNormalizeQuestions(t *testing.T) {
+	yesNo := func(knockouts ...string) entity.ScreeningQuestion {
+		return entity.ScreeningQuestion{Kind: entity.QuestionYesNo, Prompt: " Do you have a motorbike? ", KnockoutAnswers: knockouts}
+	}
+	choice := func(options []string, knockouts ...string) entity.ScreeningQuestion {
+		return entity.ScreeningQuestion{Kind: entity.QuestionMultipleChoice, Prompt: "Shirt size", Options: options, KnockoutAnswers: knockouts}
+	}
+	tooMany := make([]entity.ScreeningQuestion, maxScreeningQuestions+1)
+	for i := range tooMany {
+		tooMany[i] = yesNo()
+	}
+
+	tests := []struct {
+		name          string
+		questions     []entity.ScreeningQuestion
+		want          error
+		wantKnockouts []string // Of the first question
+		wantOptions   []string
+	}{
+		{"yes/no knockout upper-cased", []entity.ScreeningQuestion{yesNo("no")}, nil, []string{entity.AnswerNo}, nil},
+		{"yes/no drops its options", []entity.ScreeningQuestion{{Kind: entity.QuestionYesNo, Prompt: "Ready?", Options: []string{"maybe"}}}, nil, []string{}, nil},
+		{"knockout spelled like its option", []entity.ScreeningQuestion{choice([]string{" S ", "M", "L"}, "s", "S")}, nil, []string{"S"}, []string{"S", "M", "L"}},
+		{"free text", []entity.ScreeningQuestion{{Kind: entity.QuestionFreeText, Prompt: "Tell us about yourself"}}, nil, nil, nil},
+		{"none at all", nil, nil, nil, nil},
+		{"too many questions", tooMany, ErrInvalidQuestions, nil, nil},
+		{"blank prompt", []entity.ScreeningQuestion{{Kind: entity.QuestionYesNo, Prompt: "  "}}, ErrInvalidQuestions, nil, nil},
+		{"prompt too long", []entity.ScreeningQuestion{{Kind: entity.QuestionYesNo, Prompt: strings.Repeat("a", maxPromptLength+1)}}, ErrInvalidQuestions, nil, nil},
+		{"unknown kind", []entity.ScreeningQuestion{{Kind: "RATING", Prompt: "Rate us"}}, ErrInvalidQuestions, nil, nil},
+		{"one option", []entity.ScreeningQuestion{choice([]string{"M"})}, ErrInvalidQuestions, nil, nil},
+		{"empty option", []entity.ScreeningQuestion{choice([]string{"M", " "})}, ErrInvalidQuestions, nil, nil},
+		{"option listed twice", []entity.ScreeningQuestion{choice([]string{"M", "m"})}, ErrInvalidQuestions, nil, nil},
+		{"knockout that isn't an answer", []entity.ScreeningQuestion{choice([]string{"S", "M"}, "XL")}, ErrInvalidQuestions, nil, nil},
+		{"every answer a knockout", []entity.ScreeningQuestion{yesNo("YES", "no")}, ErrInvalidQuestions, nil, nil},
+		{"free text with a knockout", []entity.ScreeningQuestion{{Kind: entity.QuestionFreeText, Prompt: "Why?", KnockoutAnswers: []string{"no"}}}, ErrInvalidQuestions, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeQuestions(tt.questions)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("normalizeQuestions() = %v, want %v", err, tt.want)
+			}
+			if err != nil || len(got) == 0 {
+				return
+			}
+			for i, q := range got {
+				if q.Position != i+1 || q.Prompt != strings.TrimSpace(q.Prompt) {
+					t.Errorf("question %d at position %d with prompt %q", i, q.Position, q.Prompt)
+				}
+			}
+			if !slices.Equal(got[0].KnockoutAnswers, tt.wantKnockouts) {
+				t.Errorf("KnockoutAnswers = %q, want %q", got[0].KnockoutAnswers, tt.wantKnockouts)
+			}
+			if !slices.Equal(got[0].Options, tt.wantOptions) {
+				t.Errorf("Options = %q, want %q", got[0].Options, tt.wantOptions)
+			}
+		})
+	}
+}
+
+func TestGradeAnswers(t *testing.T) {
+	questions := []entity.ScreeningQuestion{
+		{ID: 1, Kind: entity.QuestionYesNo, Prompt: "Do you have a motorbike?", Required: true, KnockoutAnswers: []string{entity.AnswerNo}},
+		{ID: 2, Kind: entity.QuestionMultipleChoice, Prompt: "Shirt size", Options: []string{"S", "M", "L"}, KnockoutAnswers: []string{"L"}},
+		{ID: 3, Kind: entity.QuestionFreeText, Prompt: "Anything else?"},
+	}
+	answer := func(id int64, text string) entity.ScreeningAnswer {
+		return entity.ScreeningAnswer{QuestionID: id, Answer: text}
+	}
+
+	tests := []struct {
+		name         string
+		answers      []entity.ScreeningAnswer
+		want         error
+		wantAnswers  []string // Stored answers, in question order
+		wantKnockout bool
+	}{
+		{"all answered", []entity.ScreeningAnswer{answer(1, "yes"), answer(2, " m "), answer(3, " Weekends only ")}, nil, []string{entity.AnswerYes, "M", "Weekends only"}, false},
+		{"optional ones skipped", []entity.ScreeningAnswer{answer(1, "YES"), answer(3, "  ")}, nil, []string{entity.AnswerYes}, false},
+		{"yes/no knockout", []entity.ScreeningAnswer{answer(1, "No")}, nil, []string{entity.AnswerNo}, true},
+		{"multiple choice knockout", []entity.ScreeningAnswer{answer(1, "yes"), answer(2, "l")}, nil, []string{entity.AnswerYes, "L"}, true},
+		{"required left out", []entity.ScreeningAnswer{answer(2, "M")}, ErrInvalidAnswers, nil, false},
+		{"required left blank", []entity.ScreeningAnswer{answer(1, " ")}, ErrInvalidAnswers, nil, false},
+		{"answered twice", []entity.ScreeningAnswer{answer(1, "yes"), answer(1, "no")}, ErrInvalidAnswers, nil, false},
+		{"not a yes/no answer", []entity.ScreeningAnswer{answer(1, "maybe")}, ErrInvalidAnswers, nil, false},
+		{"not one of the options", []entity.ScreeningAnswer{answer(1, "yes"), answer(2, "XL")}, ErrInvalidAnswers, nil, false},
+		{"free text too long", []entity.ScreeningAnswer{answer(1, "yes"), answer(3, strings.Repeat("a", maxFreeTextAnswer+1))}, ErrInvalidAnswers, nil, false},
+		{"question not asked", []entity.ScreeningAnswer{answer(1, "yes"), answer(9, "yes")}, ErrInvalidAnswers, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graded, knockout, err := gradeAnswers(questions, tt.answers)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("gradeAnswers() = %v, want %v", err, tt.want)
+			}
+			var got []string
+			for _, a := range graded {
+				got = append(got, a.Answer)
+				if a.Knockout != slices.Contains(questions[a.QuestionID-1].KnockoutAnswers, a.Answer) {
+					t.Errorf("answer %q to question %d marked knockout = %v", a.Answer, a.QuestionID, a.Knockout)
+				}
+			}
+			if !slices.Equal(got, tt.wantAnswers) {
+				t.Errorf("answers = %q, want %q", got, tt.wantAnswers)
+			}
+			if knockout != tt.wantKnockout {
+				t.Errorf("knocked out = %v, want %v", knockout, tt.wantKnockout)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrSeriesNotFound   = errors.New("series not found")
+	ErrTemplateInUse    = errors.New("template is used by an active series")
+	ErrInvalidTemplate  = errors.New("invalid template")
+)
+
+// DefaultMaterialiseHorizon is how far ahead recurring shifts are created
+const DefaultMaterialiseHorizon = 14 * 24 * time.Hour
+
+type SeriesService struct {
+	templateRepo port.TemplateRepository
+	shiftRepo    port.ShiftRepository
+	geoRepo      port.GeoRepository
+	taxonomyRepo port.TaxonomyRepository
+	locationRepo port.LocationRepository
+	wages        *WageRuleService
+	lifecycle    *LifecycleService
+	horizon      time.Duration
+}
+
+func NewSeriesService(
+	templateRepo port.TemplateRepository,
+	shiftRepo port.ShiftRepository,
+	geoRepo port.GeoRepository,
+	taxonomyRepo port.TaxonomyRepository,
+	locationRepo port.LocationRepository,
+	wages *WageRuleService,
+	lifecycle *LifecycleService,
+	horizon time.Duration,
+) *SeriesService {
+	if horizon <= 0 {
+		horizon = DefaultMaterialiseHorizon
+	}
+	return &SeriesService{
+		templateRepo: templateRepo,
+		shiftRepo:    shiftRepo,
+		geoRepo:      geoRepo,
+		taxonomyRepo: taxonomyRepo,
+		locationRepo: locationRepo,
+		wages:        wages,
+		lifecycle:    lifecycle,
+		horizon:      horizon,
+	}
+}
+
+// CreateTemplate validates and saves a new template
+func (s *SeriesService) CreateTemplate(ctx context.Context, t *entity.ShiftTemplate) error {
+	if err := s.validateTemplate(ctx, t); err != nil {
+		return err
+	}
+	if err := s.templateRepo.CreateTemplate(ctx, t); err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	return nil
+}
+
+// GetMyTemplates retrieves the templates of a business
+func (s *SeriesService) GetMyTemplates(ctx context.Context, ownerID int64) ([]entity.ShiftTemplate, error) {
+	return s.templateRepo.GetTemplatesByOwner(ctx, ownerID)
+}
+
+// UpdateTemplate changes a template. Shifts that were already materialised keep
+// their values; the change applies to occurrences created from now on.
+func (s *SeriesService) UpdateTemplate(ctx context.Context, t *entity.ShiftTemplate, requesterID int64) error {
+	existing, err := s.templateRepo.GetTemplateByID(ctx, t.ID)
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	if existing.OwnerID != requesterID {
+		return ErrUnauthorized
+	}
+	t.OwnerID = existing.OwnerID
+
+	if err := s.validateTemplate(ctx, t); err != nil {
+		return err
+	}
+	if err := s.templateRepo.UpdateTemplate(ctx, t); err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	t.CreatedAt = existing.CreatedAt
+	return nil
+}
+
+// DeleteTemplate removes a template that no series depends on
+func (s *SeriesService) DeleteTemplate(ctx context.Context, templateID, requesterID int64) error {
+	t, err := s.templateRepo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	if t.OwnerID != requesterID {
+		return ErrUnauthorized
+	}
+
+	series, err := s.templateRepo.GetSeriesByOwner(ctx, requesterID)
+	if err != nil {
+		return err
+	}
+	for _, sr := range series {
+		if sr.TemplateID == templateID {
+			return ErrTemplateInUse
+		}
+	}
+
+	if err := s.templateRepo.DeleteTemplate(ctx, templateID); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
+
+// CreateSeries starts repeating a template and materialises the first occurrences right away
+func (s *SeriesService) CreateSeries(ctx context.Context, series *entity.ShiftSeries) error {
+	// 1. Validate
+	t, err := s.templateRepo.GetTemplateByID(ctx, series.TemplateID)
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	if t.OwnerID != series.OwnerID {
+		return ErrUnauthorized
+	}
+	series.RRule = strings.ToUpper(strings.TrimSpace(series.RRule))
+	if _, err := ParseRRule(series.RRule); err != nil {
+		return err
+	}
+	if _, err := time.Parse("2006-01-02", series.StartsOn); err != nil {
+		return fmt.Errorf("%w: starts_on must be YYYY-MM-DD", ErrInvalidRRule)
+	}
+
+	// 2. Save
+	if err := s.templateRepo.CreateSeries(ctx, series); err != nil {
+		return fmt.Errorf("failed to create series: %w", err)
+	}
+
+	// 3. Materialise now so the business sees the first shifts immediately
+	if err := s.materialiseSeries(ctx, series, t, time.Now()); err != nil {
+		return fmt.Errorf("series created but materialising failed: %w", err)
+	}
+	return nil
+}
+
+// GetMySeries retrieves the series of a business
+func (s *SeriesService) GetMySeries(ctx context.Context, ownerID int64) ([]entity.ShiftSeries, error) {
+	return s.templateRepo.GetSeriesByOwner(ctx, ownerID)
+}
+
+// CancelSeries stops a series and cancels its upcoming OPEN occurrences.
+// Filled occurrences and individually edited ones are left for the business to handle.
+func (s *SeriesService) CancelSeries(ctx context.Context, seriesID, requesterID int64) error {
+	series, err := s.templateRepo.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return ErrSeriesNotFound
+	}
+	if series.OwnerID != requesterID {
+		return ErrUnauthorized
+	}
+
+	if err := s.templateRepo.UpdateSeriesProgress(ctx, seriesID, series.MaterialisedUntil, entity.SeriesCancelled); err != nil {
+		return err
+	}
+
+	upcoming, err := s.shiftRepo.GetUpcomingSeriesShifts(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+	for _, shift := range upcoming {
+		if shift.Status != entity.ShiftOpen || shift.IsException {
+			continue
+		}
+		if err := s.lifecycle.TransitionShift(ctx, &shift, entity.ShiftCancelled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaterialiseAll creates the upcoming shifts of every active series (run by the scheduler)
+func (s *SeriesService) MaterialiseAll(ctx context.Context) error {
+	active, err := s.templateRepo.GetActiveSeries(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range active {
+		t, err := s.templateRepo.GetTemplateByID(ctx, active[i].TemplateID)
+		if err != nil {
+			fmt.Printf("⚠️ Series %d: %v\n", active[i].ID, err)
+			continue
+		}
+		if err := s.materialiseSeries(ctx, &active[i], t, now); err != nil {
+			fmt.Printf("⚠️ Series %d: %v\n", active[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// materialiseSeries inserts the occurrences up to the horizon. Already created
+// dates are skipped by the database, so edited or cancelled occurrences are
+// never recreated or overwritten.
+func (s *SeriesService) materialiseSeries(ctx context.Context, series *entity.ShiftSeries, t *entity.ShiftTemplate, now time.Time) error {
+	rule, err := ParseRRule(series.RRule)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return fmt.Errorf("template timezone: %w", err)
+	}
+	startsOn, _ := time.Parse("2006-01-02", series.StartsOn)
+	through := now.Add(s.horizon).In(loc)
+
+	dates, exhausted := rule.Occurrences(startsOn, through)
+
+	skills, err := resolveSkills(ctx, s.taxonomyRepo, t.SkillIDs)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for _, date := range dates {
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, t.StartMinute, 0, 0, loc)
+		if start.Before(now) {
+			continue
+		}
+		end := start.Add(time.Duration(t.DurationMinutes) * time.Minute)
+		seriesID := series.ID
+
+		shift := &entity.Shift{
+			OwnerID:     t.OwnerID,
+			Title:       t.Title,
+			Description: t.Description,
+			PayRate:     t.PayRate,
+			PayUnit:     t.PayUnit,
+			Lat:         t.Lat,
+			Lng:         t.Lng,
+			LocationID:  t.LocationID,
+			Status:      entity.ShiftOpen,
+			CategoryID:  t.CategoryID,
+			StartsAt:    &start,
+			EndsAt:      &end,
+			SeriesID:    &seriesID,
+			Visibility:  t.Visibility,
+			Slots:       t.Slots,
+		}
+		if t.ReleaseAfterHours > 0 {
+			releaseAt := now.Add(time.Duration(t.ReleaseAfterHours * float64(time.Hour)))
+			shift.ReleaseAt = &releaseAt
+		}
+		ok, err := s.shiftRepo.CreateShiftOccurrence(ctx, shift, date.Format("2006-01-02"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue // Already materialised earlier
+		}
+		if err := s.taxonomyRepo.SetShiftSkills(ctx, shift.ID, t.SkillIDs); err != nil {
+			return err
+		}
+		shift.RequiredSkills = skills
+
+		if err := s.geoRepo.AddShift(ctx, *shift); err != nil {
+			fmt.Printf("⚠️ Redis sync warning: %v\n", err)
+		}
+		created++
+	}
+
+	status := entity.SeriesActive
+	if exhausted {
+		status = entity.SeriesEnded
+	}
+	series.MaterialisedUntil = civilDate(through).Format("2006-01-02")
+	series.Status = status
+	if err := s.templateRepo.UpdateSeriesProgress(ctx, series.ID, series.MaterialisedUntil, status); err != nil {
+		return err
+	}
+
+	if created > 0 {
+		fmt.Printf("🔁 Series %d: materialised %d shifts\n", series.ID, created)
+	}
+	return nil
+}
+
+func (s *SeriesService) validateTemplate(ctx context.Context, t *entity.ShiftTemplate) error {
+	t.Name = strings.TrimSpace(t.Name)
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	case t.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidTemplate)
+	case t.LocationID == nil:
+		return ErrLocationRequired
+	case t.StartMinute < 0 || t.StartMinute >= minutesPerDay:
+		return fmt.Errorf("%w: start_minute must be between 0 and 1439", ErrInvalidTemplate)
+	case t.DurationMinutes <= 0 || t.DurationMinutes > minutesPerDay:
+		return fmt.Errorf("%w: duration_minutes must be between 1 and 1440", ErrInvalidTemplate)
+	}
+
+	location, err := s.locationRepo.GetLocation(ctx, *t.LocationID)
+	if err != nil || location.OwnerID != t.OwnerID {
+		return ErrLocationNotFound
+	}
+	t.Lat, t.Lng = location.Lat, location.Lng
+
+	if err := validatePay(&t.PayRate, &t.PayUnit); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if t.Slots == 0 {
+		t.Slots = 1
+	}
+	if t.Slots < 1 || t.Slots > maxShiftSlots {
+		return fmt.Errorf("%w: a shift takes 1 to %d workers", ErrInvalidSlots, maxShiftSlots)
+	}
+	if err := checkTemplateVisibility(t); err != nil {
+		return err
+	}
+	sample := templateSample(t, time.Now())
+	if err := s.wages.CheckShift(&sample); err != nil {
+		return err
+	}
+	if t.Timezone == "" {
+		t.Timezone = defaultTimezone
+	}
+	if _, err := time.LoadLocation(t.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidTemplate, t.Timezone)
+	}
+	if t.CategoryID != nil {
+		if _, err := s.taxonomyRepo.GetCategoryByID(ctx, *t.CategoryID); err != nil {
+			return ErrCategoryNotFound
+		}
+	}
+	if t.SkillIDs == nil {
+		t.SkillIDs = []int64{}
+	}
+	if _, err := resolveSkills(ctx, s.taxonomyRepo, t.SkillIDs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// templateSample is a shift of the template starting at the given time, for
+// the minimum wage check as if the template were scheduled then
+func templateSample(t *entity.ShiftTemplate, start time.Time) entity.Shift {
+	end := start.Add(time.Duration(t.DurationMinutes) * time.Minute)
+	return entity.Shift{PayRate: t.PayRate, PayUnit: t.PayUnit, Lat: t.Lat, Lng: t.Lng, StartsAt: &start, EndsAt: &end}
+}
+
+// checkTemplateVisibility validates the visibility copied onto the occurrences.
+// Invites are made to one shift, so a template can only be PUBLIC or POOL.
+func checkTemplateVisibility(t *entity.ShiftTemplate) error {
+	if t.Visibility == "" {
+		t.Visibility = entity.VisibilityPublic
+	}
+	switch {
+	case t.Visibility == entity.VisibilityInvite:
+		return fmt.Errorf("%w: invites are made per shift, a template can only be PUBLIC or POOL", ErrInvalidVisibility)
+	case t.ReleaseAfterHours < 0:
+		return fmt.Errorf("%w: release_after_hours can't be negative", ErrInvalidVisibility)
+	case t.ReleaseAfterHours > 0 && t.Visibility == entity.VisibilityPublic:
+		return fmt.Errorf("%w: only restricted shifts can be released later", ErrInvalidVisibility)
+	}
+	return checkVisibility(t.Visibility, nil, time.Now())
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrNothingToReconfirm     = errors.New("this application has no change to re-confirm")
+	ErrAwaitingReconfirmation = errors.New("the worker hasn't re-confirmed the latest changes to this shift yet")
+)
+
+// RevisionConfig decides which edits are material and how long applicants
+// have to re-confirm them
+type RevisionConfig struct {
+	MaterialDistanceKm float64       // Moving the shift further than this is material
+	ReconfirmWindow    time.Duration // Capped at the shift's start
+}
+
+// DefaultRevisionConfig returns the settings used in production
+func DefaultRevisionConfig() RevisionConfig {
+	return RevisionConfig{
+		MaterialDistanceKm: 2,
+		ReconfirmWindow:    24 * time.Hour,
+	}
+}
+
+// ShiftRevisionService versions shift edits and makes applicants re-confirm
+// material ones (pay down, moved, rescheduled). Applicants who decline or let
+// the deadline pass lose their application; a FILLED shift is reopened.
+type ShiftRevisionService struct {
+	revisionRepo port.ShiftRevisionRepository
+	shiftRepo    port.ShiftRepository
+	lifecycle    *LifecycleService
+	config       RevisionConfig
+}
+
+func NewShiftRevisionService(revisionRepo port.ShiftRevisionRepository, shiftRepo port.ShiftRepository, lifecycle *LifecycleService, config RevisionConfig) *ShiftRevisionService {
+	return &ShiftRevisionService{revisionRepo: revisionRepo, shiftRepo: shiftRepo, lifecycle: lifecycle, config: config}
+}
+
+// Record stores an edit as the shift's next version. It returns nil if
+// nothing changed. Material edits ask the live applicants to re-confirm.
+func (s *ShiftRevisionService) Record(ctx context.Context, before, after *entity.Shift, editorID int64) (*entity.ShiftRevision, error) {
+	if sameDetails(before, after) {
+		return nil, nil
+	}
+
+	rev := entity.NewShiftRevision(after, editorID)
+	rev.MaterialChanges = s.MaterialChanges(before, after)
+	if len(rev.MaterialChanges) > 0 {
+		deadline := time.Now().Add(s.config.ReconfirmWindow)
+		if after.StartsAt != nil && after.StartsAt.After(time.Now()) && after.StartsAt.Before(deadline) {
+			deadline = *after.StartsAt
+		}
+		rev.ReconfirmBy = &deadline
+	}
+
+	workerIDs, err := s.revisionRepo.SaveRevision(ctx, before, rev)
+	if err != nil {
+		return nil, err
+	}
+	rev.ReconfirmWorkers = workerIDs
+	after.Version = rev.Version
+	return rev, nil
+}
+
+// MaterialChanges lists what about the edit affects the people who applied
+func (s *ShiftRevisionService) MaterialChanges(before, after *entity.Shift) []string {
+	changes := []string{}
+	if payDown(before, after) {
+		changes = append(changes, entity.ChangePayDown)
+	}
+	if distanceKm(before.Lat, before.Lng, after.Lat, after.Lng) > s.config.MaterialDistanceKm {
+		changes = append(changes, entity.ChangeMoved)
+	}
+	if !sameTime(before.StartsAt, after.StartsAt) || !sameTime(before.EndsAt, after.EndsAt) {
+		changes = append(changes, entity.ChangeRescheduled)
+	}
+	return changes
+}
+
+// GetHistory returns a shift's versions to its owner or to a worker who applied
+func (s *ShiftRevisionService) GetHistory(ctx context.Context, shiftID, userID int64) ([]entity.ShiftRevision, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != userID {
+		apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+		if err != nil {
+			return nil, err
+		}
+		applied := false
+		for _, app := range apps {
+			applied = applied || app.WorkerID == userID
+		}
+		if !applied {
+			return nil, ErrUnauthorized
+		}
+	}
+	return s.revisionRepo.GetRevisions(ctx, shiftID)
+}
+
+// Reconfirm records a worker's answer to a material change. Confirming keeps
+// the application as it is; declining cancels it without a reliability strike,
+// since the business changed the terms. It returns the released application
+// when the worker declined.
+func (s *ShiftRevisionService) Reconfirm(ctx context.Context, applicationID, workerID int64, confirm bool) (*entity.ReleasedApplication, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	if app.WorkerID != workerID {
+		return nil, ErrUnauthorized
+	}
+	if app.ReconfirmBy == nil {
+		return nil, ErrNothingToReconfirm
+	}
+
+	if confirm {
+		confirmed, err := s.revisionRepo.ConfirmApplication(ctx, applicationID)
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return nil, ErrNothingToReconfirm
+		}
+		return nil, nil
+	}
+
+	released, err := s.release(ctx, applicationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(released) == 0 {
+		return nil, ErrNothingToReconfirm
+	}
+	return &released[0], nil
+}
+
+// ExpireReconfirmations cancels applications whose deadline passed (background job)
+func (s *ShiftRevisionService) ExpireReconfirmations(ctx context.Context) error {
+	now := time.Now()
+	released, err := s.release(ctx, 0, &now)
+	if err != nil {
+		return err
+	}
+	for _, rel := range released {
+		fmt.Printf("⌛ Re-confirmation expired: Worker %d, shift %d\n", rel.WorkerID, rel.ShiftID)
+	}
+	return nil
+}
+
+// release cancels the applications and puts reopened shifts back on the map
+func (s *ShiftRevisionService) release(ctx context.Context, applicationID int64, deadlineBefore *time.Time) ([]entity.ReleasedApplication, error) {
+	released, err := s.revisionRepo.ReleaseUnconfirmed(ctx, applicationID, deadlineBefore)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range released {
+		if !rel.ShiftReopened {
+			continue
+		}
+		shift, err := s.shiftRepo.GetShiftByID(ctx, rel.ShiftID)
+		if err != nil {
+			fmt.Printf("⚠️ Redis sync warning: %v\n", err)
+			continue
+		}
+		s.lifecycle.SyncGeo(ctx, shift)
+	}
+	return released, nil
+}
+
+// payDown reports whether the pay got worse: a lower rate in the same unit,
+// a lower hourly equivalent across units, or a different currency
+func payDown(before, after *entity.Shift) bool {
+	if before.PayRate.Currency != after.PayRate.Currency {
+		return true
+	}
+	if before.PayUnit == after.PayUnit {
+		return after.PayRate.Minor < before.PayRate.Minor
+	}
+	oldRate, okOld := before.HourlyRate()
+	newRate, okNew := after.HourlyRate()
+	return !okOld || !okNew || newRate.Minor < oldRate.Minor
+}
+
+// sameDetails reports whether an edit left every versioned field unchanged
+func sameDetails(a, b *entity.Shift) bool {
+	return a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.PayRate == b.PayRate &&
+		a.PayUnit == b.PayUnit &&
+		a.Lat == b.Lat && a.Lng == b.Lng &&
+		sameID(a.CategoryID, b.CategoryID) &&
+		sameTime(a.StartsAt, b.StartsAt) &&
+		sameTime(a.EndsAt, b.EndsAt)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -4,87 +4,461 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"shiftkerja-backend/internal/core/entity"
 	"shiftkerja-backend/internal/core/port"
 )
 
 var (
-	ErrUnauthorized       = errors.New("unauthorized action")
-	ErrShiftNotFound      = errors.New("shift not found")
-	ErrApplicationExists  = errors.New("already applied to this shift")
-	ErrInvalidStatus      = errors.New("invalid status transition")
+	ErrUnauthorized        = errors.New("unauthorized action")
+	ErrShiftNotFound       = errors.New("shift not found")
+	ErrApplicationExists   = errors.New("already applied to this shift")
+	ErrInvalidStatus       = errors.New("invalid status transition")
+	ErrInvalidSchedule     = errors.New("shift needs both starts_at and ends_at, with ends_at at least a minute after starts_at")
+	ErrScheduleConflict    = errors.New("shift overlaps another shift you are accepted for")
+	ErrWorkerDoubleBooked  = errors.New("worker is already accepted for an overlapping shift")
+	ErrInvalidPay          = errors.New("invalid pay")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrShiftHasAccepted    = errors.New("shift has accepted workers, cancel it with a reason instead")
+	ErrShiftHasLedger      = errors.New("compensation was paid for this shift, it can't be deleted")
+	ErrInvalidReason       = errors.New("invalid reason")
+	ErrInvalidSlots        = errors.New("invalid number of slots")
+	ErrInvalidBatch        = errors.New("invalid batch")
+	ErrBatchRejected       = errors.New("some applications can't be updated, nothing was changed")
+)
+
+// maxReasonNoteLength caps the note on a rejection or withdrawal
+const maxReasonNoteLength = 500
+
+// minShiftDuration is the shortest scheduled shift
+const minShiftDuration = time.Minute
+
+// Limits of a shift's headcount and of a batch accept/reject
+const (
+	maxShiftSlots = 100
+	maxBatchSize  = 100
 )
 
 type ShiftService struct {
-	shiftRepo port.ShiftRepository
-	geoRepo   port.GeoRepository
+	shiftRepo    port.ShiftRepository
+	geoRepo      port.GeoRepository
+	taxonomyRepo port.TaxonomyRepository
+	profileRepo  port.WorkerProfileRepository
+	ratingRepo   port.RatingRepository
+	eventRepo    port.EventRepository
+	locationRepo port.LocationRepository
+	wages        *WageRuleService
+	reliability  *ReliabilityService
+	lifecycle    *LifecycleService
+	revisions    *ShiftRevisionService
+	pools        *PoolService
+	blocks       *BlockService
+	screening    *ScreeningService
+	negotiation  *NegotiationService
+}
+
+// NearbyFilter narrows down a nearby search
+type NearbyFilter struct {
+	CategoryID int64 // 0 = any category
+
+	// AvailableOnly hides shifts the worker can't take: outside their
+	// availability calendar or overlapping one of their ACCEPTED shifts
+	AvailableOnly bool
+	WorkerID      int64
+
+	// Only shifts this user may see are returned (see PoolService)
+	ViewerID   int64
+	ViewerRole string
 }
 
-func NewShiftService(shiftRepo port.ShiftRepository, geoRepo port.GeoRepository) *ShiftService {
+func NewShiftService(
+	shiftRepo port.ShiftRepository,
+	geoRepo port.GeoRepository,
+	taxonomyRepo port.TaxonomyRepository,
+	profileRepo port.WorkerProfileRepository,
+	ratingRepo port.RatingRepository,
+	eventRepo port.EventRepository,
+	locationRepo port.LocationRepository,
+	wages *WageRuleService,
+	reliability *ReliabilityService,
+	lifecycle *LifecycleService,
+	revisions *ShiftRevisionService,
+	pools *PoolService,
+	blocks *BlockService,
+	screening *ScreeningService,
+	negotiation *NegotiationService,
+) *ShiftService {
 	return &ShiftService{
-		shiftRepo: shiftRepo,
-		geoRepo:   geoRepo,
+		shiftRepo:    shiftRepo,
+		geoRepo:      geoRepo,
+		taxonomyRepo: taxonomyRepo,
+		profileRepo:  profileRepo,
+		ratingRepo:   ratingRepo,
+		eventRepo:    eventRepo,
+		locationRepo: locationRepo,
+		wages:        wages,
+		reliability:  reliability,
+		lifecycle:    lifecycle,
+		revisions:    revisions,
+		pools:        pools,
+		blocks:       blocks,
+		screening:    screening,
+		negotiation:  negotiation,
 	}
 }
 
+// shiftDraft holds what prepareShift resolved for saveShift
+type shiftDraft struct {
+	skills    []entity.Skill
+	questions []entity.ScreeningQuestion
+}
+
 // CreateShift handles shift creation with dual-write to Postgres and Redis
 func (s *ShiftService) CreateShift(ctx context.Context, shift *entity.Shift) error {
+	draft, err := s.prepareShift(ctx, shift)
+	if err != nil {
+		return err
+	}
+	return s.saveShift(ctx, shift, draft)
+}
+
+// prepareShift validates a new shift and fills in its defaults without
+// writing anything
+func (s *ShiftService) prepareShift(ctx context.Context, shift *entity.Shift) (*shiftDraft, error) {
 	// 1. Validate business rules
-	if shift.PayRate <= 0 {
-		return errors.New("pay rate must be positive")
+	if err := validatePay(&shift.PayRate, &shift.PayUnit); err != nil {
+		return nil, err
 	}
 	if shift.Title == "" {
-		return errors.New("title is required")
+		return nil, errors.New("title is required")
 	}
-	
+	if !validSchedule(shift) {
+		return nil, ErrInvalidSchedule
+	}
+	if err := s.placeAtLocation(ctx, shift); err != nil {
+		return nil, err
+	}
+	if shift.Slots == 0 {
+		shift.Slots = 1
+	}
+	if shift.Slots < 1 || shift.Slots > maxShiftSlots {
+		return nil, fmt.Errorf("%w: a shift takes 1 to %d workers", ErrInvalidSlots, maxShiftSlots)
+	}
+	if shift.Visibility == "" {
+		shift.Visibility = entity.VisibilityPublic
+	}
+	if err := checkVisibility(shift.Visibility, shift.ReleaseAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.wages.CheckShift(shift); err != nil {
+		return nil, err
+	}
+	skills, err := s.resolveTaxonomy(ctx, shift)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := normalizeQuestions(shift.ScreeningQuestions)
+	if err != nil {
+		return nil, err
+	}
+	shift.ScreeningQuestions = nil // Knockout answers stay out of the Redis cache
+	return &shiftDraft{skills: skills, questions: questions}, nil
+}
+
+// saveShift writes a shift checked by prepareShift
+func (s *ShiftService) saveShift(ctx context.Context, shift *entity.Shift, draft *shiftDraft) error {
 	// 2. Save to Postgres (source of truth)
 	if err := s.shiftRepo.CreateShift(ctx, shift); err != nil {
 		return fmt.Errorf("failed to create shift: %w", err)
 	}
+	if err := s.taxonomyRepo.SetShiftSkills(ctx, shift.ID, skillIDs(draft.skills)); err != nil {
+		return fmt.Errorf("failed to save required skills: %w", err)
+	}
+	if len(draft.questions) > 0 {
+		if err := s.screening.saveQuestions(ctx, shift.ID, draft.questions); err != nil {
+			return fmt.Errorf("failed to save screening questions: %w", err)
+		}
+	}
 	
 	// 3. Sync to Redis (geo index)
+	s.indexShift(ctx, shift, draft)
+	return nil
+}
+
+// indexShift completes a saved shift from its draft and adds it to the geo
+// index, without its screening questions
+func (s *ShiftService) indexShift(ctx context.Context, shift *entity.Shift, draft *shiftDraft) {
+	shift.RequiredSkills = draft.skills
+	shift.ScreeningQuestions = nil
 	if err := s.geoRepo.AddShift(ctx, *shift); err != nil {
 		// Log but don't fail - data is in Postgres
 		fmt.Printf("⚠️ Redis sync warning: %v\n", err)
 	}
+	shift.ScreeningQuestions = draft.questions
+}
+
+// GetNearbyShifts retrieves shifts within radius that match the filter
+func (s *ShiftService) GetNearbyShifts(ctx context.Context, lat, lng, radiusKm float64, filter NearbyFilter) ([]entity.Shift, error) {
+	shifts, err := s.geoRepo.FindNearby(ctx, lat, lng, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+	if shifts, err = s.pools.FilterVisible(ctx, filter.ViewerID, filter.ViewerRole, shifts); err != nil {
+		return nil, err
+	}
 	
-	return nil
+	if filter.CategoryID != 0 || filter.AvailableOnly {
+		if shifts, err = s.filterNearby(ctx, shifts, filter); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Attach each business's current rating (the cached shift doesn't carry it)
+	ownerIDs := make([]int64, 0, len(shifts))
+	for _, shift := range shifts {
+		ownerIDs = append(ownerIDs, shift.OwnerID)
+	}
+	summaries, err := s.ratingRepo.GetRatingSummaries(ctx, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shifts {
+		summary := summaries[shifts[i].OwnerID]
+		shifts[i].OwnerRatingAvg = summary.Avg
+		shifts[i].OwnerRatingCount = summary.Count
+	}
+	return s.groupEvents(ctx, shifts)
+}
+
+// groupEvents shows each event once in a nearby search: its first position
+// found stands for it, with the event and the availability of every position
+// in the results attached
+func (s *ShiftService) groupEvents(ctx context.Context, shifts []entity.Shift) ([]entity.Shift, error) {
+	var eventIDs, positionIDs []int64
+	for _, shift := range shifts {
+		if shift.EventID != nil {
+			eventIDs = append(eventIDs, *shift.EventID)
+			positionIDs = append(positionIDs, shift.ID)
+		}
+	}
+	if len(eventIDs) == 0 {
+		return shifts, nil
+	}
+	events, err := s.eventRepo.GetEvents(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	accepted, err := s.eventRepo.CountAccepted(ctx, positionIDs)
+	if err != nil {
+		return nil, err
+	}
+	
+	grouped := make([]entity.Shift, 0, len(shifts))
+	shown := map[int64]bool{}
+	for _, shift := range shifts {
+		var event *entity.Event
+		if shift.EventID != nil {
+			event = events[*shift.EventID]
+		}
+		if event == nil {
+			grouped = append(grouped, shift)
+			continue
+		}
+		event.Positions = append(event.Positions, entity.NewPositionAvailability(shift, accepted[shift.ID]))
+		if shown[event.ID] {
+			continue
+		}
+		shown[event.ID] = true
+		shift.Event = event
+		grouped = append(grouped, shift)
+	}
+	return grouped, nil
 }
 
-// GetNearbyShifts retrieves shifts within radius
-func (s *ShiftService) GetNearbyShifts(ctx context.Context, lat, lng, radiusKm float64) ([]entity.Shift, error) {
-	return s.geoRepo.FindNearby(ctx, lat, lng, radiusKm)
+// filterNearby applies the category and availability filters to a nearby search
+func (s *ShiftService) filterNearby(ctx context.Context, shifts []entity.Shift, filter NearbyFilter) ([]entity.Shift, error) {
+	var err error
+	var history []entity.Application
+	var availability *entity.WorkerAvailability
+	if filter.AvailableOnly {
+		if history, err = s.shiftRepo.GetApplicationsByWorker(ctx, filter.WorkerID); err != nil {
+			return nil, err
+		}
+		if availability, err = s.profileRepo.GetAvailability(ctx, filter.WorkerID); err != nil {
+			return nil, err
+		}
+	}
+	
+	var filtered []entity.Shift
+	for _, shift := range shifts {
+		if filter.CategoryID != 0 && (shift.CategoryID == nil || *shift.CategoryID != filter.CategoryID) {
+			continue
+		}
+		if filter.AvailableOnly && !shiftFitsCalendar(shift, history, availability) {
+			continue
+		}
+		filtered = append(filtered, shift)
+	}
+	return filtered, nil
 }
 
-// ApplyForShift handles worker application with validation
-func (s *ShiftService) ApplyForShift(ctx context.Context, shiftID, workerID int64) error {
+// ApplyForShift handles worker application with validation. The answers to
+// the shift's screening questions are stored with it; a knockout answer
+// rejects the application straight away. A worker asking for another rate
+// than the shift's opens a negotiation with the business (see
+// NegotiationService).
+func (s *ShiftService) ApplyForShift(ctx context.Context, shiftID, workerID int64, coverNote string, answers []entity.ScreeningAnswer, proposedRate *entity.Money) (*entity.Application, error) {
 	// 1. Check if shift exists
 	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
 	if err != nil {
-		return ErrShiftNotFound
+		return nil, ErrShiftNotFound
 	}
 	
-	// 2. Check if shift is still open
-	if shift.Status != "OPEN" {
-		return errors.New("shift is no longer available")
+	// 2. Restricted shifts only take the workers who may see them, and a
+	//    worker with a block with the business can see none of its shifts
+	if visible, err := s.pools.CanSee(ctx, workerID, "worker", shift); err != nil {
+		return nil, err
+	} else if !visible {
+		return nil, ErrShiftNotFound
 	}
 	
-	// 3. Apply
-	if err := s.shiftRepo.ApplyForShift(ctx, shiftID, workerID); err != nil {
-		return fmt.Errorf("failed to apply: %w", err)
+	// 3. Check if shift is still open
+	if shift.Status != entity.ShiftOpen {
+		return nil, errors.New("shift is no longer available")
 	}
 	
-	return nil
+	// 4. Refuse shifts that clash with one the worker is already booked for
+	if conflict, err := s.findConflict(ctx, workerID, shift); err != nil {
+		return nil, err
+	} else if conflict {
+		return nil, ErrScheduleConflict
+	}
+	
+	// 5. Suspended workers (too many no-shows / late withdrawals) can't apply
+	if err := s.reliability.CheckCanApply(ctx, workerID); err != nil {
+		return nil, err
+	}
+	
+	// 6. Screening answers and cover note
+	coverNote = strings.TrimSpace(coverNote)
+	if len(coverNote) > maxCoverNoteLength {
+		return nil, fmt.Errorf("%w: the cover note is longer than %d characters", ErrInvalidAnswers, maxCoverNoteLength)
+	}
+	graded, knockedOut, err := s.screening.Screen(ctx, shiftID, answers)
+	if err != nil {
+		return nil, err
+	}
+	app := &entity.Application{
+		ShiftID:   shiftID,
+		WorkerID:  workerID,
+		Status:    entity.ApplicationPending,
+		CoverNote: coverNote,
+		Answers:   graded,
+	}
+	if knockedOut {
+		app.Status = entity.ApplicationRejected
+		app.ReasonCode = entity.ReasonScreeningKnockout
+	}
+	
+	// 7. Proposed rate (asking for the shift's own rate is no proposal)
+	if proposedRate != nil && !knockedOut {
+		if err := s.negotiation.checkRate(shift, proposedRate); err != nil {
+			return nil, err
+		}
+		if *proposedRate != shift.PayRate {
+			app.NegotiatedRate, app.RateTurn = proposedRate, entity.SideBusiness
+			app.RateOffers = []entity.RateOffer{{
+				AuthorID: workerID,
+				Side:     entity.SideWorker,
+				Action:   entity.RatePropose,
+				Rate:     proposedRate,
+			}}
+		}
+	}
+	
+	// 8. Apply
+	if err := s.shiftRepo.ApplyForShift(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to apply: %w", err)
+	}
+	
+	return app, nil
+}
+
+// GetShift returns one shift with its required skills, if the user may see it.
+// Shifts hidden from the user are reported as not found.
+func (s *ShiftService) GetShift(ctx context.Context, shiftID, userID int64, role string) (*entity.Shift, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if visible, err := s.pools.CanSee(ctx, userID, role, shift); err != nil {
+		return nil, err
+	} else if !visible {
+		return nil, ErrShiftNotFound
+	}
+	
+	skills, err := s.taxonomyRepo.GetShiftSkills(ctx, []int64{shift.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load required skills: %w", err)
+	}
+	shift.RequiredSkills = skills[shift.ID]
+	
+	// Applicants don't get to see which answers are knockouts
+	showKnockouts := shift.OwnerID == userID || role == "admin"
+	if shift.ScreeningQuestions, err = s.screening.GetQuestions(ctx, shift.ID, showKnockouts); err != nil {
+		return nil, fmt.Errorf("failed to load screening questions: %w", err)
+	}
+	
+	if shift.LocationID != nil {
+		if shift.Location, err = s.locationFor(ctx, shift, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	
+	summaries, err := s.ratingRepo.GetRatingSummaries(ctx, []int64{shift.OwnerID})
+	if err != nil {
+		return nil, err
+	}
+	shift.OwnerRatingAvg = summaries[shift.OwnerID].Avg
+	shift.OwnerRatingCount = summaries[shift.OwnerID].Count
+	return shift, nil
 }
 
-// GetMyShifts retrieves shifts posted by a business owner
+// GetMyShifts retrieves shifts posted by a business owner, with their required skills
 func (s *ShiftService) GetMyShifts(ctx context.Context, ownerID int64) ([]entity.Shift, error) {
-	return s.shiftRepo.GetShiftsByOwner(ctx, ownerID)
+	shifts, err := s.shiftRepo.GetShiftsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	ids := make([]int64, len(shifts))
+	for i := range shifts {
+		ids[i] = shifts[i].ID
+	}
+	skills, err := s.taxonomyRepo.GetShiftSkills(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shifts {
+		shifts[i].RequiredSkills = skills[shifts[i].ID]
+	}
+	
+	return shifts, nil
 }
 
-// GetMyApplications retrieves applications for a worker
+// GetMyApplications retrieves applications for a worker with their rate negotiations
 func (s *ShiftService) GetMyApplications(ctx context.Context, workerID int64) ([]entity.Application, error) {
-	return s.shiftRepo.GetApplicationsByWorker(ctx, workerID)
+	apps, err := s.shiftRepo.GetApplicationsByWorker(ctx, workerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.negotiation.AttachOffers(ctx, apps); err != nil {
+		return nil, fmt.Errorf("failed to load rate offers: %w", err)
+	}
+	return apps, nil
 }
 
 // GetShiftApplications retrieves all applications for a shift (business owner only)
@@ -99,90 +473,298 @@ func (s *ShiftService) GetShiftApplications(ctx context.Context, shiftID, reques
 		return nil, ErrUnauthorized
 	}
 	
-	return s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.screening.AttachAnswers(ctx, apps); err != nil {
+		return nil, fmt.Errorf("failed to load screening answers: %w", err)
+	}
+	if err := s.negotiation.AttachOffers(ctx, apps); err != nil {
+		return nil, fmt.Errorf("failed to load rate offers: %w", err)
+	}
+	return apps, nil
 }
 
-// UpdateApplicationStatus handles accepting/rejecting applications
-func (s *ShiftService) UpdateApplicationStatus(ctx context.Context, applicationID, businessID int64, newStatus string) error {
-	// 1. Validate status
-	if newStatus != "ACCEPTED" && newStatus != "REJECTED" {
-		return ErrInvalidStatus
+// UpdateApplicationStatus handles accepting/rejecting applications. A
+// rejection may say why (a reason code and a note the worker gets to see).
+func (s *ShiftService) UpdateApplicationStatus(ctx context.Context, applicationID, businessID int64, newStatus entity.ApplicationStatus, reasonCode, reasonNote string) (*entity.Application, error) {
+	// 1. Validate status and reason
+	if newStatus != entity.ApplicationAccepted && newStatus != entity.ApplicationRejected {
+		return nil, ErrInvalidStatus
+	}
+	reasonCode, reasonNote, err := checkReason(newStatus, reasonCode, reasonNote)
+	if err != nil {
+		return nil, err
 	}
 	
 	// 2. Get application details
 	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
 	if err != nil {
-		return errors.New("application not found")
+		return nil, ErrApplicationNotFound
 	}
 	
 	// 3. Verify the requester owns the shift
 	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
 	if err != nil {
-		return ErrShiftNotFound
+		return nil, ErrShiftNotFound
 	}
 	
 	if shift.OwnerID != businessID {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 	
-	// 4. Update application status
-	if err := s.shiftRepo.UpdateApplicationStatus(ctx, applicationID, newStatus); err != nil {
-		return err
+	// 4. A worker can't be accepted for two overlapping shifts, nor before
+	//    they have re-confirmed a material change, nor across a block
+	if newStatus == entity.ApplicationAccepted {
+		if app.ReconfirmBy != nil {
+			return nil, ErrAwaitingReconfirmation
+		}
+		if err := s.blocks.CheckNotBlocked(ctx, businessID, app.WorkerID); err != nil {
+			return nil, err
+		}
+		if conflict, err := s.findConflict(ctx, app.WorkerID, shift); err != nil {
+			return nil, err
+		} else if conflict {
+			return nil, ErrWorkerDoubleBooked
+		}
 	}
 	
-	// 5. If accepted, update shift status to FILLED
-	if newStatus == "ACCEPTED" {
-		if err := s.shiftRepo.UpdateShiftStatus(ctx, app.ShiftID, "FILLED"); err != nil {
-			return err
+	// 5. Move the application (accepting the last slot also fills the shift and takes it off the map)
+	if err := s.lifecycle.TransitionApplicationWithReason(ctx, app, shift, newStatus, reasonCode, reasonNote); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// BatchUpdateApplicationStatus accepts or rejects several applications of the
+// business's shifts at once, all or nothing. Every item is checked like a
+// single update, and accepts also against the slots left on their shift and
+// against each other (one worker can't take two overlapping shifts). If any
+// item fails, nothing changes and ErrBatchRejected comes back with the
+// per-item results saying why.
+func (s *ShiftService) BatchUpdateApplicationStatus(ctx context.Context, applicationIDs []int64, businessID int64, newStatus entity.ApplicationStatus, reasonCode, reasonNote string) ([]entity.BatchItemResult, error) {
+	// 1. Validate the batch, status and reason
+	if newStatus != entity.ApplicationAccepted && newStatus != entity.ApplicationRejected {
+		return nil, ErrInvalidStatus
+	}
+	reasonCode, reasonNote, err := checkReason(newStatus, reasonCode, reasonNote)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	for _, id := range applicationIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
 		}
-		
-		// Remove from Redis geo index
-		if err := s.geoRepo.RemoveShift(ctx, app.ShiftID); err != nil {
-			fmt.Printf("⚠️ Redis remove warning: %v\n", err)
+	}
+	if len(ids) == 0 || len(ids) > maxBatchSize {
+		return nil, fmt.Errorf("%w: send 1 to %d application IDs", ErrInvalidBatch, maxBatchSize)
+	}
+	
+	// 2. Check every item; shifts and schedules are loaded once
+	results := make([]entity.BatchItemResult, len(ids))
+	apps := make([]*entity.Application, 0, len(ids))
+	shifts := map[int64]*entity.Shift{}
+	freeSlots := map[int64]int{}
+	booked := map[int64][]entity.Application{} // ACCEPTED shifts per worker, including this batch
+	now := time.Now()
+	failed := false
+	
+	for i, id := range ids {
+		results[i].ApplicationID = id
+		err := func() error {
+			app, err := s.shiftRepo.GetApplicationByID(ctx, id)
+			if err != nil {
+				return ErrApplicationNotFound
+			}
+			results[i].ShiftID, results[i].WorkerID, results[i].Status = app.ShiftID, app.WorkerID, app.Status
+			
+			shift, ok := shifts[app.ShiftID]
+			if !ok {
+				if shift, err = s.shiftRepo.GetShiftByID(ctx, app.ShiftID); err != nil {
+					return ErrShiftNotFound
+				}
+				shifts[shift.ID] = shift
+			}
+			if shift.OwnerID != businessID {
+				// Other businesses' applications are reported like missing ones
+				results[i].ShiftID, results[i].WorkerID, results[i].Status = 0, 0, ""
+				return ErrApplicationNotFound
+			}
+			if err := CheckApplicationTransition(app.Status, newStatus); err != nil {
+				return err
+			}
+			
+			if newStatus == entity.ApplicationAccepted {
+				if shift.Status != entity.ShiftOpen {
+					return fmt.Errorf("%w: the shift is %s, only OPEN shifts accept workers", ErrIllegalTransition, shift.Status)
+				}
+				if started(shift, now) {
+					return fmt.Errorf("%w: the shift has already started", ErrIllegalTransition)
+				}
+				if app.ReconfirmBy != nil {
+					return ErrAwaitingReconfirmation
+				}
+				if app.RateTurn != "" {
+					return rateWaiting(app)
+				}
+				if err := s.blocks.CheckNotBlocked(ctx, businessID, app.WorkerID); err != nil {
+					return err
+				}
+				
+				if _, ok := freeSlots[shift.ID]; !ok {
+					accepted, err := s.lifecycle.CountAccepted(ctx, shift.ID)
+					if err != nil {
+						return err
+					}
+					freeSlots[shift.ID] = shift.Slots - accepted
+				}
+				if freeSlots[shift.ID] < 1 {
+					return fmt.Errorf("%w: all %d slots of shift %d are taken", ErrIllegalTransition, shift.Slots, shift.ID)
+				}
+				
+				history, ok := booked[app.WorkerID]
+				if !ok {
+					if history, err = s.shiftRepo.GetApplicationsByWorker(ctx, app.WorkerID); err != nil {
+						return fmt.Errorf("failed to check schedule: %w", err)
+					}
+				}
+				if shift.StartsAt != nil && shift.EndsAt != nil {
+					if conflictingShift(history, shift.ID, *shift.StartsAt, *shift.EndsAt) != nil {
+						return ErrWorkerDoubleBooked
+					}
+				}
+				freeSlots[shift.ID]--
+				booked[app.WorkerID] = append(history, entity.Application{
+					ShiftID:       shift.ID,
+					Status:        entity.ApplicationAccepted,
+					ShiftStartsAt: shift.StartsAt,
+					ShiftEndsAt:   shift.EndsAt,
+				})
+			}
+			apps = append(apps, app)
+			return nil
+		}()
+		if err != nil {
+			results[i].Error = err.Error()
+			failed = true
 		}
 	}
+	if failed {
+		return results, ErrBatchRejected
+	}
 	
-	return nil
+	// 3. Move them all in one write (accepting also fills the shifts that
+	//    run out of slots and takes them off the map)
+	if err := s.lifecycle.TransitionApplications(ctx, apps, shifts, newStatus, reasonCode, reasonNote); err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].OK, results[i].Status = true, newStatus
+	}
+	return results, nil
 }
 
 // UpdateShift handles shift updates with authorization
-func (s *ShiftService) UpdateShift(ctx context.Context, shift *entity.Shift, requesterID int64) error {
+func (s *ShiftService) UpdateShift(ctx context.Context, shift *entity.Shift, requesterID int64) (*entity.ShiftRevision, error) {
 	// 1. Verify ownership
 	existing, err := s.shiftRepo.GetShiftByID(ctx, shift.ID)
 	if err != nil {
-		return ErrShiftNotFound
+		return nil, ErrShiftNotFound
 	}
 	
 	if existing.OwnerID != requesterID {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 	
-	// 2. Validate
-	if shift.PayRate <= 0 {
-		return errors.New("pay rate must be positive")
+	// 2. Check the status change up front; it is applied after the details are saved
+	target := shift.Status
+	if target == "" {
+		target = existing.Status
 	}
-	if shift.Title == "" {
-		return errors.New("title is required")
+	if target != existing.Status {
+		if err := CheckShiftTransition(existing.Status, target); err != nil {
+			return nil, err
+		}
 	}
+	shift.Status = existing.Status
+	shift.Visibility, shift.ReleaseAt = existing.Visibility, existing.ReleaseAt // Changed through /shifts/visibility
 	
-	// 3. Update in Postgres
-	if err := s.shiftRepo.UpdateShift(ctx, shift); err != nil {
-		return fmt.Errorf("failed to update shift: %w", err)
+	// 3. Validate (an update that leaves out the unit keeps the current one)
+	if shift.PayUnit == "" {
+		shift.PayUnit = existing.PayUnit
+	}
+	if err := validatePay(&shift.PayRate, &shift.PayUnit); err != nil {
+		return nil, err
+	}
+	if shift.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if !validSchedule(shift) {
+		return nil, ErrInvalidSchedule
+	}
+	if shift.LocationID == nil {
+		shift.LocationID = existing.LocationID
+	}
+	if shift.LocationID == nil {
+		// Posted before saved locations; it stays put until moved to one
+		shift.Lat, shift.Lng = existing.Lat, existing.Lng
+	}
+	if err := s.placeAtLocation(ctx, shift); err != nil {
+		return nil, err
+	}
+	if err := s.wages.CheckShift(shift); err != nil {
+		return nil, err
 	}
 	
-	// 4. Update in Redis if still OPEN
-	if shift.Status == "OPEN" {
-		if err := s.geoRepo.AddShift(ctx, *shift); err != nil {
-			fmt.Printf("⚠️ Redis update warning: %v\n", err)
+	// 4. Slots (leave out to keep them) can't drop below the workers already
+	//    accepted; an OPEN shift whose slots are all taken is filled
+	if shift.Slots == 0 {
+		shift.Slots = existing.Slots
+	}
+	if shift.Slots < 1 || shift.Slots > maxShiftSlots {
+		return nil, fmt.Errorf("%w: a shift takes 1 to %d workers", ErrInvalidSlots, maxShiftSlots)
+	}
+	if shift.Slots != existing.Slots {
+		accepted, err := s.lifecycle.CountAccepted(ctx, shift.ID)
+		if err != nil {
+			return nil, err
+		}
+		if shift.Slots < accepted {
+			return nil, fmt.Errorf("%w: %d workers are already accepted", ErrInvalidSlots, accepted)
 		}
-	} else {
-		// Remove from Redis if not OPEN
-		if err := s.geoRepo.RemoveShift(ctx, shift.ID); err != nil {
-			fmt.Printf("⚠️ Redis remove warning: %v\n", err)
+		if target == entity.ShiftOpen && accepted >= shift.Slots {
+			target = entity.ShiftFilled
 		}
 	}
+	skills, err := s.resolveTaxonomy(ctx, shift)
+	if err != nil {
+		return nil, err
+	}
 	
-	return nil
+	// 5. Update in Postgres
+	if err := s.shiftRepo.UpdateShift(ctx, shift); err != nil {
+		return nil, fmt.Errorf("failed to update shift: %w", err)
+	}
+	if err := s.taxonomyRepo.SetShiftSkills(ctx, shift.ID, skillIDs(skills)); err != nil {
+		return nil, fmt.Errorf("failed to save required skills: %w", err)
+	}
+	shift.RequiredSkills = skills
+	
+	// 6. Version the edit; material changes ask the applicants to re-confirm
+	revision, err := s.revisions.Record(ctx, existing, shift, requesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record shift history: %w", err)
+	}
+	
+	// 7. Apply the status change (which syncs Redis) or just refresh Redis
+	if target != existing.Status {
+		return revision, s.lifecycle.TransitionShift(ctx, shift, target)
+	}
+	s.lifecycle.SyncGeo(ctx, shift)
+	
+	return revision, nil
 }
 
 // DeleteShift handles shift deletion with authorization
@@ -197,12 +779,44 @@ func (s *ShiftService) DeleteShift(ctx context.Context, shiftID, requesterID int
 		return ErrUnauthorized
 	}
 	
-	// 2. Delete from Postgres (cascades to applications)
-	if err := s.shiftRepo.DeleteShift(ctx, shiftID); err != nil {
+	// 2. Once someone has been accepted the shift must go through the
+	//    cancellation flow so the worker is told and compensated
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return fmt.Errorf("failed to check applications: %w", err)
+	}
+	for _, app := range apps {
+		if app.Status == entity.ApplicationAccepted {
+			return ErrShiftHasAccepted
+		}
+	}
+	
+	// Compensation posted for a late cancellation references the shift's
+	// cancellations, which a delete would remove with it
+	if shift.SeriesID == nil {
+		paid, err := s.shiftRepo.HasLedgerHistory(ctx, shiftID)
+		if err != nil {
+			return err
+		}
+		if paid {
+			return ErrShiftHasLedger
+		}
+	}
+	
+	// 3. Occurrences of a recurring series are kept as CANCELLED so the
+	//    scheduler doesn't materialise them again; other shifts are deleted
+	//    from Postgres (cascades to applications)
+	if shift.SeriesID != nil {
+		if shift.Status != entity.ShiftCancelled {
+			if err := s.lifecycle.TransitionShift(ctx, shift, entity.ShiftCancelled); err != nil {
+				return fmt.Errorf("failed to cancel occurrence: %w", err)
+			}
+		}
+	} else if err := s.shiftRepo.DeleteShift(ctx, shiftID); err != nil {
 		return fmt.Errorf("failed to delete shift: %w", err)
 	}
 	
-	// 3. Remove from Redis
+	// 4. Remove from Redis
 	if err := s.geoRepo.RemoveShift(ctx, shiftID); err != nil {
 		fmt.Printf("⚠️ Redis remove warning: %v\n", err)
 	}
@@ -210,28 +824,176 @@ func (s *ShiftService) DeleteShift(ctx context.Context, shiftID, requesterID int
 	return nil
 }
 
-// DeleteApplication handles application withdrawal (worker only)
-func (s *ShiftService) DeleteApplication(ctx context.Context, applicationID, workerID int64) error {
+// WithdrawApplication lets a worker withdraw a pending or standby application
+// (offers are declined instead), telling the business why. The application is
+// kept as WITHDRAWN; applying again reuses it.
+func (s *ShiftService) WithdrawApplication(ctx context.Context, applicationID, workerID int64, reasonCode, reasonNote string) (*entity.Application, error) {
 	// 1. Get application
 	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
 	if err != nil {
-		return errors.New("application not found")
+		return nil, ErrApplicationNotFound
 	}
 	
 	// 2. Verify ownership
 	if app.WorkerID != workerID {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
+	}
+	
+	// 3. Only PENDING and STANDBY applications can be withdrawn
+	if app.Status != entity.ApplicationPending && app.Status != entity.ApplicationStandby {
+		return nil, errors.New("can only withdraw pending or standby applications")
+	}
+	reasonCode, reasonNote, err = checkReason(entity.ApplicationWithdrawn, reasonCode, reasonNote)
+	if err != nil {
+		return nil, err
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
 	}
 	
-	// 3. Only allow deletion of PENDING applications
-	if app.Status != "PENDING" {
-		return errors.New("can only withdraw pending applications")
+	// 4. Withdraw
+	if err := s.lifecycle.TransitionApplicationWithReason(ctx, app, shift, entity.ApplicationWithdrawn, reasonCode, reasonNote); err != nil {
+		return nil, err
 	}
 	
-	// 4. Delete
-	if err := s.shiftRepo.DeleteApplication(ctx, applicationID); err != nil {
-		return fmt.Errorf("failed to delete application: %w", err)
+	// 5. Withdrawing shortly before the start counts against reliability
+	if err := s.reliability.RecordWithdrawal(ctx, app, shift, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to record withdrawal: %w", err)
 	}
 	
+	app.ShiftOwnerID = shift.OwnerID
+	return app, nil
+}
+
+// resolveTaxonomy checks the shift's category and loads its required skills
+// (the handler only fills in skill IDs)
+func (s *ShiftService) resolveTaxonomy(ctx context.Context, shift *entity.Shift) ([]entity.Skill, error) {
+	if shift.CategoryID != nil {
+		if _, err := s.taxonomyRepo.GetCategoryByID(ctx, *shift.CategoryID); err != nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+	return resolveSkills(ctx, s.taxonomyRepo, skillIDs(shift.RequiredSkills))
+}
+
+func skillIDs(skills []entity.Skill) []int64 {
+	ids := make([]int64, len(skills))
+	for i := range skills {
+		ids[i] = skills[i].ID
+	}
+	return ids
+}
+
+// checkReason validates the reason given for a rejection or withdrawal: a
+// code from entity.ApplicationReasons (or none) and a short note. Other
+// statuses take no reason.
+func checkReason(status entity.ApplicationStatus, code, note string) (string, string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	note = strings.TrimSpace(note)
+	codes, ok := entity.ApplicationReasons[status]
+	if !ok {
+		if code != "" || note != "" {
+			return "", "", fmt.Errorf("%w: only rejections and withdrawals take a reason", ErrInvalidReason)
+		}
+		return "", "", nil
+	}
+	if code != "" && !slices.Contains(codes, code) {
+		return "", "", fmt.Errorf("%w: %q is not a %s reason", ErrInvalidReason, code, status)
+	}
+	if len(note) > maxReasonNoteLength {
+		return "", "", fmt.Errorf("%w: the note is longer than %d characters", ErrInvalidReason, maxReasonNoteLength)
+	}
+	return code, note, nil
+}
+
+// validSchedule accepts unscheduled shifts, or an end at least
+// minShiftDuration after the start
+func validSchedule(shift *entity.Shift) bool {
+	if shift.StartsAt == nil && shift.EndsAt == nil {
+		return true
+	}
+	if shift.StartsAt == nil || shift.EndsAt == nil {
+		return false
+	}
+	return shift.EndsAt.Sub(*shift.StartsAt) >= minShiftDuration
+}
+
+// findLocation returns one of the business's saved work sites
+func (s *ShiftService) findLocation(ctx context.Context, locationID, ownerID int64) (*entity.Location, error) {
+	location, err := s.locationRepo.GetLocation(ctx, locationID)
+	if err != nil || location.OwnerID != ownerID {
+		return nil, ErrLocationNotFound
+	}
+	return location, nil
+}
+
+// placeAtLocation gives a shift posted at a saved work site its coordinates
+func (s *ShiftService) placeAtLocation(ctx context.Context, shift *entity.Shift) error {
+	if shift.LocationID == nil {
+		return nil
+	}
+	location, err := s.findLocation(ctx, *shift.LocationID, shift.OwnerID)
+	if err != nil {
+		return err
+	}
+	shift.Lat, shift.Lng = location.Lat, location.Lng
+	return nil
+}
+
+// locationFor returns the shift's work site as the user may see it: the
+// on-site instructions and contact are for the business and the workers it
+// accepted
+func (s *ShiftService) locationFor(ctx context.Context, shift *entity.Shift, userID int64, role string) (*entity.Location, error) {
+	location, err := s.locationRepo.GetLocation(ctx, *shift.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %w", err)
+	}
+	if shift.OwnerID == userID || role == "admin" {
+		return location, nil
+	}
+	
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps {
+		if app.WorkerID == userID && app.Status == entity.ApplicationAccepted {
+			return location, nil
+		}
+	}
+	public := location.Public()
+	return &public, nil
+}
+
+// findConflict reports whether the worker is ACCEPTED for another shift that
+// overlaps this one. Unscheduled shifts never conflict.
+func (s *ShiftService) findConflict(ctx context.Context, workerID int64, shift *entity.Shift) (bool, error) {
+	if shift.StartsAt == nil || shift.EndsAt == nil {
+		return false, nil
+	}
+	history, err := s.shiftRepo.GetApplicationsByWorker(ctx, workerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check schedule: %w", err)
+	}
+	return conflictingShift(history, shift.ID, *shift.StartsAt, *shift.EndsAt) != nil, nil
+}
+
+// validatePay checks the rate and fills in the default currency and unit
+func validatePay(rate *entity.Money, unit *string) error {
+	if rate.Currency == "" {
+		rate.Currency = entity.DefaultCurrency
+	}
+	if *unit == "" {
+		*unit = entity.PayHourly
+	}
+	switch {
+	case !entity.ValidCurrency(rate.Currency):
+		return fmt.Errorf("%w: unknown currency %q", ErrInvalidPay, rate.Currency)
+	case !rate.IsPositive():
+		return fmt.Errorf("%w: pay rate must be positive", ErrInvalidPay)
+	case *unit != entity.PayHourly && *unit != entity.PayPerShift:
+		return fmt.Errorf("%w: pay_unit must be HOURLY or PER_SHIFT", ErrInvalidPay)
+	}
 	return nil
 }
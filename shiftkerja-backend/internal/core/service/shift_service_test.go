@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+func (r *fakeShiftRepo) GetApplicationsByWorker(_ context.Context, workerID int64) ([]entity.Application, error) {
+	var apps []entity.Application
+	for _, app := range r.apps {
+		if app.WorkerID != workerID {
+			continue
+		}
+		found := *app
+		if shift := r.shifts[app.ShiftID]; shift != nil {
+			found.ShiftStartsAt, found.ShiftEndsAt = shift.StartsAt, shift.EndsAt
+		}
+		apps = append(apps, found)
+	}
+	return apps, nil
+}
+
+// BatchTransitionApplications writes every item or none, and fills the shifts
+// whose slots run out
+func (r *fakeShiftRepo) BatchTransitionApplications(_ context.Context, items []entity.ApplicationTransition, to entity.ApplicationStatus, reasonCode, reasonNote string) ([]int64, bool, error) {
+	for _, item := range items {
+		if app := r.app(item.ApplicationID); r.stale || app == nil || app.Status != item.From {
+			return nil, false, nil
+		}
+	}
+	var filled []int64
+	for _, item := range items {
+		app := r.app(item.ApplicationID)
+		app.Status, app.ReasonCode, app.ReasonNote = to, reasonCode, reasonNote
+		shift := r.shifts[item.ShiftID]
+		if to == entity.ApplicationAccepted && shift.Status == entity.ShiftOpen && r.accepted(shift.ID) >= shift.Slots {
+			shift.Status = entity.ShiftFilled
+			filled = append(filled, shift.ID)
+		}
+	}
+	return filled, true, nil
+}
+
+// fakeBlockRepo holds blocks as [blocker, blocked] pairs
+type fakeBlockRepo struct {
+	port.BlockRepository
+	blocks [][2]int64
+}
+
+func (r *fakeBlockRepo) GetBlockedWith(_ context.Context, userID int64) (map[int64]bool, error) {
+	blocked := map[int64]bool{}
+	for _, b := range r.blocks {
+		switch userID {
+		case b[0]:
+			blocked[b[1]] = true
+		case b[1]:
+			blocked[b[0]] = true
+		}
+	}
+	return blocked, nil
+}
+
+func TestBatchUpdateApplicationStatus(t *testing.T) {
+	const business = 100
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	at := func(hours int) *time.Time {
+		when := tomorrow.Add(time.Duration(hours) * time.Hour)
+		return &when
+	}
+	reconfirmBy := time.Now().Add(time.Hour)
+
+	// Shift 2 overlaps shift 1; shift 3 belongs to another business
+	newFixture := func() *fakeShiftRepo {
+		return &fakeShiftRepo{
+			shifts: map[int64]*entity.Shift{
+				1: {ID: 1, OwnerID: business, Status: entity.ShiftOpen, Slots: 2, StartsAt: at(0), EndsAt: at(4)},
+				2: {ID: 2, OwnerID: business, Status: entity.ShiftOpen, Slots: 1, StartsAt: at(2), EndsAt: at(6)},
+				3: {ID: 3, OwnerID: 200, Status: entity.ShiftOpen, Slots: 1},
+				4: {ID: 4, OwnerID: business, Status: entity.ShiftFilled, Slots: 1},
+			},
+			apps: []*entity.Application{
+				{ID: 10, ShiftID: 1, WorkerID: 1, Status: entity.ApplicationPending},
+				{ID: 11, ShiftID: 1, WorkerID: 2, Status: entity.ApplicationPending},
+				{ID: 12, ShiftID: 1, WorkerID: 3, Status: entity.ApplicationPending},
+				{ID: 13, ShiftID: 2, WorkerID: 1, Status: entity.ApplicationPending},
+				{ID: 14, ShiftID: 3, WorkerID: 4, Status: entity.ApplicationPending},
+				{ID: 15, ShiftID: 4, WorkerID: 5, Status: entity.ApplicationPending},
+				{ID: 16, ShiftID: 1, WorkerID: 6, Status: entity.ApplicationPending},
+				{ID: 17, ShiftID: 1, WorkerID: 7, Status: entity.ApplicationRejected},
+				{ID: 18, ShiftID: 2, WorkerID: 8, Status: entity.ApplicationPending, ReconfirmBy: &reconfirmBy},
+				{ID: 19, ShiftID: 4, WorkerID: 9, Status: entity.ApplicationAccepted},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		ids        []int64
+		to         entity.ApplicationStatus
+		reasonCode string
+		want       error
+		wantFailed []int64 // Items reported with an error
+		wantFilled bool    // Shift 1 ends up FILLED
+	}{
+		{"accept up to the slots", []int64{10, 11}, entity.ApplicationAccepted, "", nil, nil, true},
+		{"duplicates count once", []int64{10, 10}, entity.ApplicationAccepted, "", nil, nil, false},
+		{"more accepts than slots", []int64{10, 11, 12}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{12}, false},
+		{"overlapping shifts in one batch", []int64{10, 13}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{13}, false},
+		{"another business's application", []int64{10, 14}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{14}, false},
+		{"shift already filled", []int64{15}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{15}, false},
+		{"blocked worker", []int64{10, 16}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{16}, false},
+		{"final status", []int64{17}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{17}, false},
+		{"awaiting re-confirmation", []int64{18}, entity.ApplicationAccepted, "", ErrBatchRejected, []int64{18}, false},
+		{"reject with a reason", []int64{10, 12}, entity.ApplicationRejected, "POSITION_FILLED", nil, nil, false},
+		{"reject an accepted worker", []int64{10, 19}, entity.ApplicationRejected, "", ErrBatchRejected, []int64{19}, false},
+		{"unknown reason", []int64{10}, entity.ApplicationRejected, "BAD_VIBES", ErrInvalidReason, nil, false},
+		{"other target status", []int64{10}, entity.ApplicationWithdrawn, "", ErrInvalidStatus, nil, false},
+		{"empty batch", nil, entity.ApplicationAccepted, "", ErrInvalidBatch, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFixture()
+			before := map[int64]entity.ApplicationStatus{}
+			for _, app := range repo.apps {
+				before[app.ID] = app.Status
+			}
+			lifecycle, _ := newFakeLifecycle(repo)
+			blocks := NewBlockService(&fakeBlockRepo{blocks: [][2]int64{{6, business}}}, nil)
+			s := &ShiftService{shiftRepo: repo, lifecycle: lifecycle, blocks: blocks}
+
+			results, err := s.BatchUpdateApplicationStatus(context.Background(), tt.ids, business, tt.to, tt.reasonCode, "")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("BatchUpdateApplicationStatus() = %v, want %v", err, tt.want)
+			}
+
+			var failed []int64
+			for _, r := range results {
+				if r.Error != "" {
+					failed = append(failed, r.ApplicationID)
+				}
+			}
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed items = %v, want %v", failed, tt.wantFailed)
+			}
+
+			// All of the batch changes or none of it
+			for _, app := range repo.apps {
+				want := before[app.ID]
+				if err == nil && slices.Contains(tt.ids, app.ID) {
+					want = tt.to
+				}
+				if app.Status != want {
+					t.Errorf("application %d is %s, want %s", app.ID, app.Status, want)
+				}
+			}
+			if filled := repo.shifts[1].Status == entity.ShiftFilled; filled != tt.wantFilled {
+				t.Errorf("shift 1 filled = %v, want %v", filled, tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestAcceptTakesTheLastSlot(t *testing.T) {
+	repo := &fakeShiftRepo{
+		shifts: map[int64]*entity.Shift{1: {ID: 1, Status: entity.ShiftOpen, Slots: 2}},
+		apps: []*entity.Application{
+			{ID: 10, ShiftID: 1, Status: entity.ApplicationPending},
+			{ID: 11, ShiftID: 1, Status: entity.ApplicationPending},
+			{ID: 12, ShiftID: 1, Status: entity.ApplicationPending},
+		},
+	}
+	lifecycle, geo := newFakeLifecycle(repo)
+	geo.indexed[1] = true
+	shift, _ := repo.GetShiftByID(context.Background(), 1)
+
+	for i, want := range []entity.ShiftStatus{entity.ShiftOpen, entity.ShiftFilled} {
+		app, _ := repo.GetApplicationByID(context.Background(), int64(10+i))
+		if err := lifecycle.TransitionApplication(context.Background(), app, shift, entity.ApplicationAccepted); err != nil {
+			t.Fatalf("accepting application %d: %v", app.ID, err)
+		}
+		if shift.Status != want {
+			t.Errorf("after %d accepted the shift is %s, want %s", i+1, shift.Status, want)
+		}
+	}
+	if geo.indexed[1] {
+		t.Error("a filled shift is still on the map")
+	}
+
+	// A business holding the shift as OPEN from before gets no third spot
+	stale := &entity.Shift{ID: 1, Status: entity.ShiftOpen, Slots: 2}
+	app, _ := repo.GetApplicationByID(context.Background(), 12)
+	if err := lifecycle.TransitionApplication(context.Background(), app, stale, entity.ApplicationAccepted); !errors.Is(err, ErrStatusChanged) {
+		t.Errorf("accepting past the slots = %v, want %v", err, ErrStatusChanged)
+	}
+}
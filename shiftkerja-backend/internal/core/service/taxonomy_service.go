@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrCategoryNotFound   = errors.New("category not found")
+	ErrSkillNotFound      = errors.New("one or more skills do not exist")
+	ErrInvalidSkillLevel  = errors.New("skill level must be BEGINNER, INTERMEDIATE or EXPERT")
+	ErrDuplicateSkill     = errors.New("each skill can only be listed once")
+	ErrTaxonomyNameNeeded = errors.New("name is required")
+)
+
+type TaxonomyService struct {
+	taxonomyRepo port.TaxonomyRepository
+}
+
+func NewTaxonomyService(taxonomyRepo port.TaxonomyRepository) *TaxonomyService {
+	return &TaxonomyService{taxonomyRepo: taxonomyRepo}
+}
+
+// CreateCategory adds a job category to the managed taxonomy
+func (s *TaxonomyService) CreateCategory(ctx context.Context, category *entity.Category) error {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return ErrTaxonomyNameNeeded
+	}
+	if err := s.taxonomyRepo.CreateCategory(ctx, category); err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	return nil
+}
+
+// ListCategories returns every job category
+func (s *TaxonomyService) ListCategories(ctx context.Context) ([]entity.Category, error) {
+	return s.taxonomyRepo.ListCategories(ctx)
+}
+
+// CreateSkill adds a skill, optionally grouped under an existing category
+func (s *TaxonomyService) CreateSkill(ctx context.Context, skill *entity.Skill) error {
+	skill.Name = strings.TrimSpace(skill.Name)
+	if skill.Name == "" {
+		return ErrTaxonomyNameNeeded
+	}
+	if skill.CategoryID != nil {
+		if _, err := s.taxonomyRepo.GetCategoryByID(ctx, *skill.CategoryID); err != nil {
+			return ErrCategoryNotFound
+		}
+	}
+	if err := s.taxonomyRepo.CreateSkill(ctx, skill); err != nil {
+		return fmt.Errorf("failed to create skill: %w", err)
+	}
+	return nil
+}
+
+// ListSkills returns all skills, or only those of one category when categoryID > 0
+func (s *TaxonomyService) ListSkills(ctx context.Context, categoryID int64) ([]entity.Skill, error) {
+	return s.taxonomyRepo.ListSkills(ctx, categoryID)
+}
+
+// GetWorkerSkills returns the declared skills of a worker
+func (s *TaxonomyService) GetWorkerSkills(ctx context.Context, workerID int64) ([]entity.WorkerSkill, error) {
+	return s.taxonomyRepo.GetWorkerSkills(ctx, workerID)
+}
+
+// UpdateWorkerSkills replaces the skill profile of a worker
+func (s *TaxonomyService) UpdateWorkerSkills(ctx context.Context, workerID int64, skills []entity.WorkerSkill) error {
+	// 1. Validate levels and duplicates
+	ids := make([]int64, 0, len(skills))
+	seen := make(map[int64]bool)
+	for i := range skills {
+		if !isValidSkillLevel(skills[i].Level) {
+			return ErrInvalidSkillLevel
+		}
+		if skills[i].YearsExperience < 0 {
+			return errors.New("years of experience cannot be negative")
+		}
+		if seen[skills[i].SkillID] {
+			return ErrDuplicateSkill
+		}
+		seen[skills[i].SkillID] = true
+		skills[i].WorkerID = workerID
+		ids = append(ids, skills[i].SkillID)
+	}
+
+	// 2. Every skill must exist in the taxonomy
+	if _, err := resolveSkills(ctx, s.taxonomyRepo, ids); err != nil {
+		return err
+	}
+
+	// 3. Replace
+	if err := s.taxonomyRepo.SetWorkerSkills(ctx, workerID, skills); err != nil {
+		return fmt.Errorf("failed to update worker skills: %w", err)
+	}
+	return nil
+}
+
+// resolveSkills loads the skills for the given IDs and fails if any are unknown
+func resolveSkills(ctx context.Context, repo port.TaxonomyRepository, ids []int64) ([]entity.Skill, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	skills, err := repo.GetSkillsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	unique := make(map[int64]bool)
+	for _, id := range ids {
+		unique[id] = true
+	}
+	if len(skills) != len(unique) {
+		return nil, ErrSkillNotFound
+	}
+	return skills, nil
+}
+
+func isValidSkillLevel(level string) bool {
+	switch level {
+	case entity.SkillLevelBeginner, entity.SkillLevelIntermediate, entity.SkillLevelExpert:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrTimesheetNotFound      = errors.New("timesheet not found")
+	ErrInvalidTimesheet       = errors.New("invalid timesheet")
+	ErrTimesheetAction        = errors.New("action not allowed for this timesheet")
+	ErrTimesheetReasonMissing = errors.New("a reason is required for this action")
+	ErrTimesheetChanged       = errors.New("timesheet was changed by someone else, reload and try again")
+)
+
+// timesheetTransitions lists, per role, which action moves a timesheet from which status to which
+var timesheetTransitions = map[string]map[string]map[string]string{
+	"business": {
+		entity.TimesheetPending:   {entity.TimesheetActionApprove: entity.TimesheetApproved, entity.TimesheetActionEdit: entity.TimesheetEdited, entity.TimesheetActionDispute: entity.TimesheetDisputed},
+		entity.TimesheetContested: {entity.TimesheetActionApprove: entity.TimesheetApproved, entity.TimesheetActionEdit: entity.TimesheetEdited, entity.TimesheetActionDispute: entity.TimesheetDisputed},
+		entity.TimesheetDisputed:  {entity.TimesheetActionApprove: entity.TimesheetApproved, entity.TimesheetActionEdit: entity.TimesheetEdited},
+	},
+	"worker": {
+		entity.TimesheetEdited: {entity.TimesheetActionAccept: entity.TimesheetApproved, entity.TimesheetActionContest: entity.TimesheetContested},
+	},
+}
+
+// TimesheetReview is a business's decision on a timesheet. Times and break are only used by EDIT.
+type TimesheetReview struct {
+	Action       string
+	StartedAt    *time.Time
+	EndedAt      *time.Time
+	BreakMinutes *int
+	Reason       string
+}
+
+type TimesheetService struct {
+	timesheetRepo port.TimesheetRepository
+	ledger        *LedgerService
+	wages         *WageRuleService
+}
+
+func NewTimesheetService(timesheetRepo port.TimesheetRepository, ledger *LedgerService, wages *WageRuleService) *TimesheetService {
+	return &TimesheetService{timesheetRepo: timesheetRepo, ledger: ledger, wages: wages}
+}
+
+// GetTimesheets lists the caller's timesheets: their own for workers, their shifts' for businesses
+func (s *TimesheetService) GetTimesheets(ctx context.Context, userID int64, role, status string) ([]entity.Timesheet, error) {
+	if role == "business" {
+		return s.timesheetRepo.GetTimesheetsByBusiness(ctx, userID, status)
+	}
+	return s.timesheetRepo.GetTimesheetsByWorker(ctx, userID, status)
+}
+
+// GetTimesheet returns a timesheet and its full history to the worker or the business
+func (s *TimesheetService) GetTimesheet(ctx context.Context, timesheetID, userID int64) (*entity.Timesheet, []entity.TimesheetHistoryEntry, error) {
+	ts, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, nil, ErrTimesheetNotFound
+	}
+	if ts.WorkerID != userID && ts.BusinessID != userID {
+		return nil, nil, ErrUnauthorized
+	}
+
+	history, err := s.timesheetRepo.GetTimesheetHistory(ctx, timesheetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ts, history, nil
+}
+
+// Review lets the business approve, edit (with a reason) or dispute a timesheet
+func (s *TimesheetService) Review(ctx context.Context, timesheetID, businessID int64, review TimesheetReview) (*entity.Timesheet, error) {
+	ts, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+	if ts.BusinessID != businessID {
+		return nil, ErrUnauthorized
+	}
+
+	if review.Action == entity.TimesheetActionEdit {
+		if review.StartedAt != nil {
+			ts.StartedAt = *review.StartedAt
+		}
+		if review.EndedAt != nil {
+			ts.EndedAt = *review.EndedAt
+		}
+		if review.BreakMinutes != nil {
+			ts.BreakMinutes = *review.BreakMinutes
+		}
+		if err := computeWorked(ts, s.wages); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.transition(ctx, ts, businessID, "business", review.Action, review.Reason); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// Respond lets the worker accept or contest the business's edit
+func (s *TimesheetService) Respond(ctx context.Context, timesheetID, workerID int64, action, reason string) (*entity.Timesheet, error) {
+	ts, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+	if ts.WorkerID != workerID {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.transition(ctx, ts, workerID, "worker", action, reason); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// transition validates the action against the current status and saves it with a history entry
+func (s *TimesheetService) transition(ctx context.Context, ts *entity.Timesheet, actorID int64, role, action, reason string) error {
+	action = strings.ToUpper(strings.TrimSpace(action))
+	reason = strings.TrimSpace(reason)
+
+	next, ok := timesheetTransitions[role][ts.Status][action]
+	if !ok {
+		return fmt.Errorf("%w: cannot %s a %s timesheet", ErrTimesheetAction, strings.ToLower(action), ts.Status)
+	}
+	needsReason := action == entity.TimesheetActionEdit || action == entity.TimesheetActionDispute || action == entity.TimesheetActionContest
+	if needsReason && reason == "" {
+		return ErrTimesheetReasonMissing
+	}
+
+	from := ts.Status
+	ts.Status = next
+	if reason != "" {
+		ts.Note = reason
+	}
+
+	entry := timesheetEntry(ts, actorID, action, from, reason)
+	updated, err := s.timesheetRepo.UpdateTimesheet(ctx, ts, from, entry)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrTimesheetChanged
+	}
+
+	// Approved hours become earnings; the payout job retries if this fails
+	if ts.Status == entity.TimesheetApproved {
+		if err := s.ledger.RecordEarnings(ctx, ts); err != nil {
+			fmt.Printf("⚠️ Ledger posting warning: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// newTimesheet computes the timesheet of a finished attendance record at the
+// worker's rate (the shift's, or the one they negotiated). Time before the
+// scheduled start isn't paid; breaks are unpaid.
+func newTimesheet(a *entity.Attendance, shift *entity.Shift, rate entity.Money, breakMinutes int, wages *WageRuleService) (*entity.Timesheet, error) {
+	started := a.ClockInAt
+	if shift.StartsAt != nil && started.Before(*shift.StartsAt) {
+		started = *shift.StartsAt
+	}
+	ended := *a.ClockOutAt
+	if ended.Before(started) {
+		ended = started // Clocked out before the shift even started
+	}
+
+	ts := &entity.Timesheet{
+		ApplicationID: a.ApplicationID,
+		AttendanceID:  a.ID,
+		ShiftID:       a.ShiftID,
+		WorkerID:      a.WorkerID,
+		BusinessID:    shift.OwnerID,
+		StartedAt:     started,
+		EndedAt:       ended,
+		BreakMinutes:  breakMinutes,
+		PayRate:       rate,
+		PayUnit:       shift.PayUnit,
+		RegionCode:    wages.RegionCode(shift.Lat, shift.Lng),
+		Status:        entity.TimesheetPending,
+	}
+	if err := computeWorked(ts, wages); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// computeWorked derives worked minutes from the times and break, and pay from the wage rules
+func computeWorked(ts *entity.Timesheet, wages *WageRuleService) error {
+	if ts.EndedAt.Before(ts.StartedAt) {
+		return fmt.Errorf("%w: ended_at must be after started_at", ErrInvalidTimesheet)
+	}
+	if ts.BreakMinutes < 0 {
+		return fmt.Errorf("%w: break_minutes cannot be negative", ErrInvalidTimesheet)
+	}
+
+	worked := int(ts.EndedAt.Sub(ts.StartedAt).Minutes()) - ts.BreakMinutes
+	if worked < 0 {
+		return fmt.Errorf("%w: break is longer than the time worked", ErrInvalidTimesheet)
+	}
+	ts.WorkedMinutes = worked
+	wages.Pay(ts)
+	return nil
+}
+
+func timesheetEntry(ts *entity.Timesheet, actorID int64, action, from, reason string) *entity.TimesheetHistoryEntry {
+	return &entity.TimesheetHistoryEntry{
+		TimesheetID:   ts.ID,
+		ActorID:       actorID,
+		Action:        action,
+		FromStatus:    from,
+		ToStatus:      ts.Status,
+		StartedAt:     ts.StartedAt,
+		EndedAt:       ts.EndedAt,
+		BreakMinutes:  ts.BreakMinutes,
+		WorkedMinutes: ts.WorkedMinutes,
+		Reason:        reason,
+	}
+}
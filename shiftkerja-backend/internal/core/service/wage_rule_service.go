@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var ErrBelowMinimumWage = errors.New("pay rate is below the regional minimum wage")
+
+// WageRuleConfig tunes how the wage rules are applied
+type WageRuleConfig struct {
+	EnforceMinimum bool // Reject shifts and templates paying less than the regional minimum
+}
+
+// DefaultWageRuleConfig returns the settings used in production
+func DefaultWageRuleConfig() WageRuleConfig {
+	return WageRuleConfig{EnforceMinimum: true}
+}
+
+// WageRuleService applies regional minimum wages and public holiday
+// multipliers to posted rates and to timesheet pay
+type WageRuleService struct {
+	rules  port.WageRules
+	config WageRuleConfig
+}
+
+func NewWageRuleService(rules port.WageRules, config WageRuleConfig) *WageRuleService {
+	return &WageRuleService{rules: rules, config: config}
+}
+
+// Check returns the region of a point and the holiday on the given day there
+func (s *WageRuleService) Check(lat, lng float64, day time.Time) entity.WageCheck {
+	check := entity.WageCheck{Region: s.rules.RegionAt(lat, lng)}
+	code := ""
+	if check.Region != nil {
+		code = check.Region.Code
+	}
+	check.Holiday = s.rules.Holiday(day.In(s.location(code)).Format("2006-01-02"), code)
+	return check
+}
+
+// CheckShift refuses a rate below the minimum wage of the region the shift is in.
+// Shifts outside every known region, or paid in another currency, are not checked.
+func (s *WageRuleService) CheckShift(shift *entity.Shift) error {
+	if !s.config.EnforceMinimum {
+		return nil
+	}
+	region := s.rules.RegionAt(shift.Lat, shift.Lng)
+	if region == nil {
+		return nil
+	}
+	hourly, ok := shift.HourlyRate()
+	if !ok || hourly.Currency != region.MinHourlyRate.Currency {
+		return nil
+	}
+	if hourly.Minor < region.MinHourlyRate.Minor {
+		return fmt.Errorf("%w: %s requires at least %s per hour, this shift pays %s per hour",
+			ErrBelowMinimumWage, region.Name, region.MinHourlyRate, hourly)
+	}
+	return nil
+}
+
+// RegionCode returns the code of the region a point is in ("" if none)
+func (s *WageRuleService) RegionCode(lat, lng float64) string {
+	if region := s.rules.RegionAt(lat, lng); region != nil {
+		return region.Code
+	}
+	return ""
+}
+
+// Pay computes a timesheet's pay from its worked minutes: the rate is raised
+// to the regional minimum if needed and time worked on a public holiday is
+// multiplied. The break is spread evenly over the worked period.
+func (s *WageRuleService) Pay(ts *entity.Timesheet) {
+	ts.HolidayMinutes = 0
+	ts.MinimumWageApplied = false
+
+	rate := ts.PayRate
+	region := s.rules.RegionByCode(ts.RegionCode)
+	if region != nil && region.MinHourlyRate.Currency == rate.Currency {
+		floor := region.MinHourlyRate
+		if ts.PayUnit == entity.PayPerShift {
+			floor = floor.MulDiv(int64(ts.WorkedMinutes), 60)
+		}
+		if rate.Minor < floor.Minor {
+			rate = floor
+			ts.MinimumWageApplied = true
+		}
+	}
+
+	// Weighted minutes in hundredths: a holiday minute at 2x counts 200
+	span := int64(ts.EndedAt.Sub(ts.StartedAt) / time.Minute)
+	worked := int64(ts.WorkedMinutes)
+	weighted := worked * 100
+	if span > 0 && worked > 0 {
+		for _, part := range s.holidayParts(ts.StartedAt, ts.EndedAt, ts.RegionCode) {
+			minutes := part.minutes * worked / span
+			ts.HolidayMinutes += int(minutes)
+			weighted += minutes * (part.percent - 100)
+		}
+	}
+
+	switch {
+	case ts.PayUnit != entity.PayPerShift:
+		ts.PayAmount = rate.MulDiv(weighted, 60*100)
+	case worked > 0:
+		ts.PayAmount = rate.MulDiv(weighted, worked*100)
+	default:
+		ts.PayAmount = rate
+	}
+}
+
+type holidayPart struct {
+	minutes int64
+	percent int64 // Multiplier in percent, e.g. 200
+}
+
+// holidayParts splits [from, to) at local midnights and returns the parts that fall on holidays
+func (s *WageRuleService) holidayParts(from, to time.Time, regionCode string) []holidayPart {
+	loc := s.location(regionCode)
+	var parts []holidayPart
+	for start := from.In(loc); start.Before(to); {
+		y, m, d := start.Date()
+		next := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		end := next
+		if to.Before(end) {
+			end = to
+		}
+		if h := s.rules.Holiday(start.Format("2006-01-02"), regionCode); h != nil {
+			parts = append(parts, holidayPart{
+				minutes: int64(end.Sub(start) / time.Minute),
+				percent: int64(math.Round(h.Multiplier * 100)),
+			})
+		}
+		start = next
+	}
+	return parts
+}
+
+func (s *WageRuleService) location(regionCode string) *time.Location {
+	name := defaultTimezone
+	if region := s.rules.RegionByCode(regionCode); region != nil {
+		name = region.Timezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
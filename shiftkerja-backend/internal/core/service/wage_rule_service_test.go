@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+)
+
+// fakeWageRules puts every point north of the equator in one region and keeps
+// nationwide holidays by date
+type fakeWageRules struct {
+	region   entity.WageRegion
+	holidays map[string]float64 // Date → multiplier
+}
+
+func (r fakeWageRules) RegionAt(lat, lng float64) *entity.WageRegion {
+	if lat < 0 {
+		return nil
+	}
+	return &r.region
+}
+
+func (r fakeWageRules) RegionByCode(code string) *entity.WageRegion {
+	if code != r.region.Code {
+		return nil
+	}
+	return &r.region
+}
+
+func (r fakeWageRules) Holiday(date, regionCode string) *entity.PublicHoliday {
+	multiplier, ok := r.holidays[date]
+	if !ok {
+		return nil
+	}
+	return &entity.PublicHoliday{Date: date, Name: "Holiday", Multiplier: multiplier}
+}
+
+// idr is a whole rupiah amount
+func idr(rupiah int64) entity.Money {
+	return entity.NewMoney(rupiah*100, "IDR")
+}
+
+func newFakeWages(enforce bool) *WageRuleService {
+	rules := fakeWageRules{
+		region:   entity.WageRegion{Code: "ID-XX", Name: "Testland", MinHourlyRate: idr(20000), Timezone: "Asia/Jakarta"},
+		holidays: map[string]float64{"2030-08-17": 2},
+	}
+	return NewWageRuleService(rules, WageRuleConfig{EnforceMinimum: enforce})
+}
+
+func TestWageRuleServicePay(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2030, time.August, day, hour, 0, 0, 0, jakarta)
+	}
+
+	tests := []struct {
+		name               string
+		rate               entity.Money
+		unit               string
+		region             string
+		start, end         time.Time
+		breakMinutes       int
+		wantPay            entity.Money
+		wantHolidayMinutes int
+		wantMinimumApplied bool
+	}{
+		{"hourly above the minimum", idr(25000), entity.PayHourly, "ID-XX", at(10, 9), at(10, 17), 0, idr(200000), 0, false},
+		{"hourly raised to the minimum", idr(15000), entity.PayHourly, "ID-XX", at(10, 9), at(10, 13), 0, idr(80000), 0, true},
+		{"per shift raised to the minimum for the hours worked", idr(50000), entity.PayPerShift, "ID-XX", at(10, 9), at(10, 13), 0, idr(80000), 0, true},
+		{"per shift above the minimum", idr(100000), entity.PayPerShift, "ID-XX", at(10, 9), at(10, 13), 0, idr(100000), 0, false},
+		{"outside every region", idr(15000), entity.PayHourly, "", at(10, 9), at(10, 13), 0, idr(60000), 0, false},
+		{"another currency", entity.NewMoney(500, "USD"), entity.PayHourly, "ID-XX", at(10, 9), at(10, 11), 0, entity.NewMoney(1000, "USD"), 0, false},
+		{"on a holiday", idr(25000), entity.PayHourly, "ID-XX", at(17, 9), at(17, 13), 0, idr(200000), 240, false},
+		{"into a holiday at local midnight", idr(25000), entity.PayHourly, "ID-XX", at(16, 22), at(17, 2), 0, idr(150000), 120, false},
+		{"break spread over a holiday", idr(25000), entity.PayHourly, "ID-XX", at(17, 9), at(17, 13), 60, idr(150000), 180, false},
+		{"per shift on a holiday", idr(100000), entity.PayPerShift, "ID-XX", at(17, 9), at(17, 13), 0, idr(200000), 240, false},
+	}
+	wages := newFakeWages(true)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &entity.Timesheet{
+				PayRate:       tt.rate,
+				PayUnit:       tt.unit,
+				RegionCode:    tt.region,
+				StartedAt:     tt.start,
+				EndedAt:       tt.end,
+				BreakMinutes:  tt.breakMinutes,
+				WorkedMinutes: int(tt.end.Sub(tt.start)/time.Minute) - tt.breakMinutes,
+			}
+			wages.Pay(ts)
+			if ts.PayAmount != tt.wantPay {
+				t.Errorf("PayAmount = %v, want %v", ts.PayAmount, tt.wantPay)
+			}
+			if ts.HolidayMinutes != tt.wantHolidayMinutes {
+				t.Errorf("HolidayMinutes = %d, want %d", ts.HolidayMinutes, tt.wantHolidayMinutes)
+			}
+			if ts.MinimumWageApplied != tt.wantMinimumApplied {
+				t.Errorf("MinimumWageApplied = %v, want %v", ts.MinimumWageApplied, tt.wantMinimumApplied)
+			}
+		})
+	}
+}
+
+func TestWageRuleServiceCheckShift(t *testing.T) {
+	start := time.Date(2030, time.August, 10, 9, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	tests := []struct {
+		name    string
+		shift   entity.Shift
+		enforce bool
+		want    error
+	}{
+		{"hourly at the minimum", entity.Shift{Lat: 1, PayRate: idr(20000), PayUnit: entity.PayHourly}, true, nil},
+		{"hourly below the minimum", entity.Shift{Lat: 1, PayRate: idr(19999), PayUnit: entity.PayHourly}, true, ErrBelowMinimumWage},
+		{"per shift below the minimum for its hours", entity.Shift{Lat: 1, PayRate: idr(79000), PayUnit: entity.PayPerShift, StartsAt: &start, EndsAt: &end}, true, ErrBelowMinimumWage},
+		{"per shift above the minimum for its hours", entity.Shift{Lat: 1, PayRate: idr(80000), PayUnit: entity.PayPerShift, StartsAt: &start, EndsAt: &end}, true, nil},
+		{"per shift without a schedule", entity.Shift{Lat: 1, PayRate: idr(1000), PayUnit: entity.PayPerShift}, true, nil},
+		{"outside every region", entity.Shift{Lat: -1, PayRate: idr(1000), PayUnit: entity.PayHourly}, true, nil},
+		{"another currency", entity.Shift{Lat: 1, PayRate: entity.NewMoney(100, "USD"), PayUnit: entity.PayHourly}, true, nil},
+		{"not enforced", entity.Shift{Lat: 1, PayRate: idr(1000), PayUnit: entity.PayHourly}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newFakeWages(tt.enforce).CheckShift(&tt.shift); !errors.Is(err, tt.want) {
+				t.Errorf("CheckShift() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrNotOnStandby = errors.New("only pending or standby applicants can be put on standby")
+	ErrNoOpenOffer  = errors.New("this application has no open offer")
+)
+
+// WaitlistConfig tunes standby backfill
+type WaitlistConfig struct {
+	OfferWindow time.Duration // How long a standby applicant has to take a spot, capped at the start
+}
+
+// DefaultWaitlistConfig returns the settings used in production
+func DefaultWaitlistConfig() WaitlistConfig {
+	return WaitlistConfig{OfferWindow: 30 * time.Minute}
+}
+
+// WaitlistService keeps a ranked standby list per shift. Whenever an OPEN shift
+// has slots nobody is accepted for or offered (e.g. an accepted worker
+// cancelled), the backfill job offers them to the best ranked standby
+// applicants; an
+// offer that isn't taken in time lapses and the next one is asked.
+type WaitlistService struct {
+	waitlistRepo port.WaitlistRepository
+	shiftRepo    port.ShiftRepository
+	lifecycle    *LifecycleService
+	ranking      *ApplicantRankingService
+	reliability  *ReliabilityService
+	blocks       *BlockService
+	broadcaster  port.Broadcaster
+	config       WaitlistConfig
+}
+
+func NewWaitlistService(
+	waitlistRepo port.WaitlistRepository,
+	shiftRepo port.ShiftRepository,
+	lifecycle *LifecycleService,
+	ranking *ApplicantRankingService,
+	reliability *ReliabilityService,
+	blocks *BlockService,
+	broadcaster port.Broadcaster,
+	config WaitlistConfig,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo: waitlistRepo,
+		shiftRepo:    shiftRepo,
+		lifecycle:    lifecycle,
+		ranking:      ranking,
+		reliability:  reliability,
+		blocks:       blocks,
+		broadcaster:  broadcaster,
+		config:       config,
+	}
+}
+
+// SetStandby makes the given applications the shift's standby list, best
+// first. With autoRank the shift's pending and standby applicants are ranked
+// by applicant score instead. An empty list clears it.
+func (s *WaitlistService) SetStandby(ctx context.Context, shiftID, businessID int64, applicationIDs []int64, autoRank bool) ([]entity.Application, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+	if shift.Status == entity.ShiftCancelled {
+		return nil, fmt.Errorf("%w: the shift is cancelled", ErrIllegalTransition)
+	}
+
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	eligible := map[int64]bool{}
+	var candidates []entity.Application
+	for _, app := range apps {
+		if app.Status == entity.ApplicationPending || app.Status == entity.ApplicationStandby {
+			eligible[app.ID] = true
+			candidates = append(candidates, app)
+		}
+	}
+
+	ids := []int64{}
+	if autoRank {
+		ranked, err := s.ranking.RankApplicants(ctx, shiftID, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ranked {
+			ids = append(ids, r.ID)
+		}
+	} else {
+		seen := map[int64]bool{}
+		for _, id := range applicationIDs {
+			if !eligible[id] {
+				return nil, fmt.Errorf("%w (application %d)", ErrNotOnStandby, id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	updated, err := s.waitlistRepo.SetStandby(ctx, shiftID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrStatusChanged
+	}
+	return s.GetStandby(ctx, shiftID, businessID)
+}
+
+// GetStandby returns the shift's standby and offered applicants in rank order (owner only)
+func (s *WaitlistService) GetStandby(ctx context.Context, shiftID, businessID int64) ([]entity.Application, error) {
+	shift, err := s.shiftRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	if shift.OwnerID != businessID {
+		return nil, ErrUnauthorized
+	}
+
+	apps, err := s.shiftRepo.GetApplicationsByShift(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	standby := []entity.Application{}
+	for _, app := range apps {
+		if app.Status == entity.ApplicationStandby || app.Status == entity.ApplicationOffered {
+			standby = append(standby, app)
+		}
+	}
+	// The open offer first, then the queue
+	sort.SliceStable(standby, func(i, j int) bool {
+		if standby[i].StandbyRank == nil || standby[j].StandbyRank == nil {
+			return standby[j].StandbyRank != nil
+		}
+		return *standby[i].StandbyRank < *standby[j].StandbyRank
+	})
+	return standby, nil
+}
+
+// RespondToOffer lets a standby worker take or turn down the spot offered to
+// them. Turning it down (or letting it lapse) costs no strike.
+func (s *WaitlistService) RespondToOffer(ctx context.Context, applicationID, workerID int64, accept bool) (*entity.Application, error) {
+	app, err := s.shiftRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, ErrApplicationNotFound
+	}
+	if app.WorkerID != workerID {
+		return nil, ErrUnauthorized
+	}
+	if app.Status != entity.ApplicationOffered || (app.OfferExpiresAt != nil && !time.Now().Before(*app.OfferExpiresAt)) {
+		return nil, ErrNoOpenOffer
+	}
+	shift, err := s.shiftRepo.GetShiftByID(ctx, app.ShiftID)
+	if err != nil {
+		return nil, ErrShiftNotFound
+	}
+	app.ShiftOwnerID = shift.OwnerID
+
+	if !accept {
+		return app, s.lifecycle.TransitionApplication(ctx, app, shift, entity.ApplicationCancelled)
+	}
+
+	// Same checks as a business accepting the worker
+	if shift.StartsAt != nil && shift.EndsAt != nil {
+		history, err := s.shiftRepo.GetApplicationsByWorker(ctx, workerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check schedule: %w", err)
+		}
+		if conflictingShift(history, shift.ID, *shift.StartsAt, *shift.EndsAt) != nil {
+			return nil, ErrScheduleConflict
+		}
+	}
+	if err := s.reliability.CheckCanApply(ctx, workerID); err != nil {
+		return nil, err
+	}
+	if err := s.blocks.CheckNotBlocked(ctx, workerID, shift.OwnerID); err != nil {
+		return nil, err
+	}
+	return app, s.lifecycle.TransitionApplication(ctx, app, shift, entity.ApplicationAccepted)
+}
+
+// RunBackfill lets lapsed offers go and offers free spots to the next standby
+// applicant (background job)
+func (s *WaitlistService) RunBackfill(ctx context.Context) error {
+	now := time.Now()
+	expired, err := s.waitlistRepo.ExpireOffers(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, o := range expired {
+		fmt.Printf("⌛ Standby offer expired: Worker %d, shift %d\n", o.WorkerID, o.ShiftID)
+		s.notify("standby_offer_expired", o)
+	}
+
+	offers, err := s.waitlistRepo.OfferNext(ctx, now, now.Add(s.config.OfferWindow))
+	if err != nil {
+		return err
+	}
+	for _, o := range offers {
+		fmt.Printf("🙋 Standby offer made: Worker %d, shift %d\n", o.WorkerID, o.ShiftID)
+		s.notify("standby_offer", o)
+	}
+	return nil
+}
+
+// notify tells the worker about their standby offer
+func (s *WaitlistService) notify(kind string, o entity.WaitlistOffer) {
+	if s.broadcaster == nil {
+		return
+	}
+	s.broadcaster.SendToUser(o.WorkerID, map[string]interface{}{
+		"type":           kind,
+		"application_id": o.ApplicationID,
+		"shift_id":       o.ShiftID,
+		"shift_title":    o.ShiftTitle,
+		"expires_at":     o.ExpiresAt,
+	})
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+// fakeWaitlistRepo expires and makes offers on the applications of a
+// fakeShiftRepo by the rules of the Postgres queries
+type fakeWaitlistRepo struct {
+	port.WaitlistRepository
+	shifts *fakeShiftRepo
+	blocks *fakeBlockRepo
+}
+
+func (r *fakeWaitlistRepo) ExpireOffers(_ context.Context, now time.Time) ([]entity.WaitlistOffer, error) {
+	var expired []entity.WaitlistOffer
+	for _, app := range r.shifts.apps {
+		if app.Status != entity.ApplicationOffered {
+			continue
+		}
+		shift := r.shifts.shifts[app.ShiftID]
+		switch {
+		case shift.Status != entity.ShiftOpen:
+			app.Status, app.OfferExpiresAt = entity.ApplicationStandby, nil
+		case !app.OfferExpiresAt.After(now):
+			app.Status, app.StandbyRank = entity.ApplicationCancelled, nil
+			expired = append(expired, offerOf(app, shift))
+		}
+	}
+	return expired, nil
+}
+
+func (r *fakeWaitlistRepo) OfferNext(ctx context.Context, now, deadline time.Time) ([]entity.WaitlistOffer, error) {
+	var offers []entity.WaitlistOffer
+	for _, shift := range r.shifts.shifts {
+		if shift.Status != entity.ShiftOpen || (shift.StartsAt != nil && !shift.StartsAt.After(now)) {
+			continue
+		}
+		blocked, _ := r.blocks.GetBlockedWith(ctx, shift.OwnerID)
+		free := shift.Slots
+		var standby []*entity.Application
+		for _, app := range r.shifts.apps {
+			if app.ShiftID != shift.ID {
+				continue
+			}
+			switch {
+			case app.Status == entity.ApplicationOffered || app.Status == entity.ApplicationAccepted:
+				free--
+			case app.Status == entity.ApplicationStandby && app.ReconfirmBy == nil && app.RateTurn == "" && !blocked[app.WorkerID]:
+				standby = append(standby, app)
+			}
+		}
+		sort.SliceStable(standby, func(i, j int) bool { return *standby[i].StandbyRank < *standby[j].StandbyRank })
+
+		expires := deadline
+		if shift.StartsAt != nil && shift.StartsAt.Before(deadline) {
+			expires = *shift.StartsAt
+		}
+		for _, app := range standby[:max(0, min(free, len(standby)))] {
+			app.Status, app.OfferExpiresAt = entity.ApplicationOffered, &expires
+			offers = append(offers, offerOf(app, shift))
+		}
+	}
+	return offers, nil
+}
+
+func offerOf(app *entity.Application, shift *entity.Shift) entity.WaitlistOffer {
+	return entity.WaitlistOffer{ApplicationID: app.ID, ShiftID: shift.ID, WorkerID: app.WorkerID, ShiftTitle: shift.Title, ExpiresAt: *app.OfferExpiresAt}
+}
+
+// fakeBroadcaster records who was told what; a broadcast is recorded as sent
+// to user 0
+type fakeBroadcaster struct {
+	sent []sentMessage
+}
+
+type sentMessage struct {
+	userID int64
+	kind   string
+}
+
+func (b *fakeBroadcaster) Broadcast(message interface{}) {
+	b.SendToUser(0, message)
+}
+
+func (b *fakeBroadcaster) SendToUser(userID int64, message interface{}) {
+	kind, _ := message.(map[string]interface{})["type"].(string)
+	b.sent = append(b.sent, sentMessage{userID, kind})
+}
+
+type fakeReliabilityRepo struct {
+	port.ReliabilityRepository
+}
+
+func (fakeReliabilityRepo) GetEventsByWorker(context.Context, int64, time.Time) ([]entity.ReliabilityEvent, error) {
+	return nil, nil
+}
+
+func TestRunBackfill(t *testing.T) {
+	const business = 100
+	now := time.Now()
+	soon, later, past := now.Add(10*time.Minute), now.Add(24*time.Hour), now.Add(-time.Hour)
+	lapsed := now.Add(-time.Minute)
+	rank := func(n int) *int { return &n }
+	standby := func(id, worker int64, r int) *entity.Application {
+		return &entity.Application{ID: id, ShiftID: 1, WorkerID: worker, Status: entity.ApplicationStandby, StandbyRank: rank(r)}
+	}
+	offered := func(id, worker int64, expires time.Time) *entity.Application {
+		return &entity.Application{ID: id, ShiftID: 1, WorkerID: worker, Status: entity.ApplicationOffered, StandbyRank: rank(0), OfferExpiresAt: &expires}
+	}
+	accepted := &entity.Application{ID: 9, ShiftID: 1, WorkerID: 9, Status: entity.ApplicationAccepted}
+	reconfirmBy := now.Add(time.Hour)
+	const window = 30 * time.Minute
+
+	tests := []struct {
+		name        string
+		status      entity.ShiftStatus
+		slots       int
+		startsAt    *time.Time
+		apps        []*entity.Application
+		wantOffered []int64 // Applications holding an offer afterwards
+		wantSent    []sentMessage
+	}{
+		{"freed spot goes to the best ranked", entity.ShiftOpen, 2, &later,
+			[]*entity.Application{accepted, standby(10, 1, 2), standby(11, 2, 1)},
+			[]int64{11}, []sentMessage{{2, "standby_offer"}}},
+		{"one offer per free spot", entity.ShiftOpen, 3, &later,
+			[]*entity.Application{accepted, standby(10, 1, 2), standby(11, 2, 1), standby(12, 3, 3)},
+			[]int64{10, 11}, []sentMessage{{2, "standby_offer"}, {1, "standby_offer"}}},
+		{"open offer holds the spot", entity.ShiftOpen, 1, &later,
+			[]*entity.Application{offered(10, 1, soon), standby(11, 2, 1)},
+			[]int64{10}, nil},
+		{"lapsed offer goes to the next", entity.ShiftOpen, 1, &later,
+			[]*entity.Application{offered(10, 1, lapsed), standby(11, 2, 1)},
+			[]int64{11}, []sentMessage{{1, "standby_offer_expired"}, {2, "standby_offer"}}},
+		{"offer on a filled shift goes back to standby", entity.ShiftFilled, 1, &later,
+			[]*entity.Application{offered(10, 1, lapsed), standby(11, 2, 1)},
+			nil, nil},
+		{"started shift", entity.ShiftOpen, 1, &past,
+			[]*entity.Application{standby(10, 1, 1)},
+			nil, nil},
+		{"shift without a schedule", entity.ShiftOpen, 1, nil,
+			[]*entity.Application{standby(10, 1, 1)},
+			[]int64{10}, []sentMessage{{1, "standby_offer"}}},
+		{"busy or blocked applicants skipped", entity.ShiftOpen, 1, &later,
+			[]*entity.Application{
+				{ID: 10, ShiftID: 1, WorkerID: 1, Status: entity.ApplicationStandby, StandbyRank: rank(1), ReconfirmBy: &reconfirmBy},
+				{ID: 11, ShiftID: 1, WorkerID: 2, Status: entity.ApplicationStandby, StandbyRank: rank(2), RateTurn: entity.SideBusiness},
+				standby(12, 66, 3),
+				standby(13, 4, 4),
+			},
+			[]int64{13}, []sentMessage{{4, "standby_offer"}}},
+		{"deadline capped at the start", entity.ShiftOpen, 1, &soon,
+			[]*entity.Application{standby(10, 1, 1)},
+			[]int64{10}, []sentMessage{{1, "standby_offer"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts := &fakeShiftRepo{
+				shifts: map[int64]*entity.Shift{1: {ID: 1, OwnerID: business, Status: tt.status, Slots: tt.slots, StartsAt: tt.startsAt}},
+				apps:   tt.apps,
+			}
+			for i, app := range shifts.apps {
+				copied := *app
+				shifts.apps[i] = &copied
+			}
+			repo := &fakeWaitlistRepo{shifts: shifts, blocks: &fakeBlockRepo{blocks: [][2]int64{{66, business}}}}
+			broadcaster := &fakeBroadcaster{}
+			s := NewWaitlistService(repo, shifts, nil, nil, nil, nil, broadcaster, WaitlistConfig{OfferWindow: window})
+
+			if err := s.RunBackfill(context.Background()); err != nil {
+				t.Fatalf("RunBackfill() = %v", err)
+			}
+
+			var offeredIDs []int64
+			for _, app := range shifts.apps {
+				if app.Status != entity.ApplicationOffered {
+					continue
+				}
+				offeredIDs = append(offeredIDs, app.ID)
+				if app.OfferExpiresAt.After(time.Now().Add(window)) ||
+					(tt.startsAt != nil && app.OfferExpiresAt.After(*tt.startsAt)) {
+					t.Errorf("offer to application %d runs until %v, past the window or the start", app.ID, app.OfferExpiresAt)
+				}
+			}
+			if !slices.Equal(offeredIDs, tt.wantOffered) {
+				t.Errorf("offered applications = %v, want %v", offeredIDs, tt.wantOffered)
+			}
+			if !slices.Equal(broadcaster.sent, tt.wantSent) {
+				t.Errorf("sent = %v, want %v", broadcaster.sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestRespondToOffer(t *testing.T) {
+	const business, worker = 100, 1
+	later := time.Now().Add(24 * time.Hour)
+	open, lapsed := time.Now().Add(time.Minute), time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		workerID   int64
+		status     entity.ApplicationStatus
+		expires    time.Time
+		accept     bool
+		blocked    bool
+		want       error
+		wantStatus entity.ApplicationStatus
+	}{
+		{"takes the spot", worker, entity.ApplicationOffered, open, true, false, nil, entity.ApplicationAccepted},
+		{"turns it down", worker, entity.ApplicationOffered, open, false, false, nil, entity.ApplicationCancelled},
+		{"too late", worker, entity.ApplicationOffered, lapsed, true, false, ErrNoOpenOffer, entity.ApplicationOffered},
+		{"nothing offered", worker, entity.ApplicationStandby, open, true, false, ErrNoOpenOffer, entity.ApplicationStandby},
+		{"someone else's offer", 2, entity.ApplicationOffered, open, true, false, ErrUnauthorized, entity.ApplicationOffered},
+		{"blocked since", worker, entity.ApplicationOffered, open, true, true, ErrBlocked, entity.ApplicationOffered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts := &fakeShiftRepo{
+				shifts: map[int64]*entity.Shift{1: {ID: 1, OwnerID: business, Status: entity.ShiftOpen, Slots: 1, StartsAt: &later, EndsAt: &later}},
+				apps:   []*entity.Application{{ID: 10, ShiftID: 1, WorkerID: worker, Status: tt.status, OfferExpiresAt: &tt.expires}},
+			}
+			blockRepo := &fakeBlockRepo{}
+			if tt.blocked {
+				blockRepo.blocks = [][2]int64{{business, worker}}
+			}
+			lifecycle, _ := newFakeLifecycle(shifts)
+			reliability := NewReliabilityService(fakeReliabilityRepo{}, DefaultReliabilityConfig())
+			s := NewWaitlistService(&fakeWaitlistRepo{shifts: shifts}, shifts, lifecycle, nil, reliability, NewBlockService(blockRepo, nil), nil, DefaultWaitlistConfig())
+
+			_, err := s.RespondToOffer(context.Background(), 10, tt.workerID, tt.accept)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RespondToOffer() = %v, want %v", err, tt.want)
+			}
+			if status := shifts.app(10).Status; status != tt.wantStatus {
+				t.Errorf("application is %s, want %s", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"shiftkerja-backend/internal/core/entity"
+	"shiftkerja-backend/internal/core/port"
+)
+
+var (
+	ErrInvalidPreferences  = errors.New("invalid preferences")
+	ErrInvalidAvailability = errors.New("invalid availability")
+)
+
+type WorkerProfileService struct {
+	profileRepo port.WorkerProfileRepository
+}
+
+func NewWorkerProfileService(profileRepo port.WorkerProfileRepository) *WorkerProfileService {
+	return &WorkerProfileService{profileRepo: profileRepo}
+}
+
+// GetPreferences returns the worker's preferences, or defaults if none are saved
+func (s *WorkerProfileService) GetPreferences(ctx context.Context, workerID int64) (*entity.WorkerPreferences, error) {
+	prefs, err := s.profileRepo.GetPreferences(ctx, workerID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		prefs = &entity.WorkerPreferences{
+			WorkerID:      workerID,
+			MinPayRate:    entity.NewMoney(0, entity.DefaultCurrency),
+			MaxDistanceKm: defaultRecommendRadiusKm,
+			Timezone:      defaultTimezone,
+		}
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences validates and saves the worker's preferences
+func (s *WorkerProfileService) UpdatePreferences(ctx context.Context, prefs *entity.WorkerPreferences) error {
+	if prefs.MinPayRate.Currency == "" {
+		prefs.MinPayRate.Currency = entity.DefaultCurrency
+	}
+	if !entity.ValidCurrency(prefs.MinPayRate.Currency) {
+		return fmt.Errorf("%w: unknown currency %q", ErrInvalidPreferences, prefs.MinPayRate.Currency)
+	}
+	if prefs.MinPayRate.Minor < 0 {
+		return fmt.Errorf("%w: min_pay_rate cannot be negative", ErrInvalidPreferences)
+	}
+	if prefs.MaxDistanceKm == 0 {
+		prefs.MaxDistanceKm = defaultRecommendRadiusKm
+	}
+	if prefs.MaxDistanceKm < 0 || prefs.MaxDistanceKm > 100 {
+		return fmt.Errorf("%w: max_distance_km must be between 0 and 100", ErrInvalidPreferences)
+	}
+	if (prefs.HomeLat == nil) != (prefs.HomeLng == nil) {
+		return fmt.Errorf("%w: home_lat and home_lng must be set together", ErrInvalidPreferences)
+	}
+	if prefs.HomeLat != nil && (*prefs.HomeLat < -90 || *prefs.HomeLat > 90 || *prefs.HomeLng < -180 || *prefs.HomeLng > 180) {
+		return fmt.Errorf("%w: home location is out of range", ErrInvalidPreferences)
+	}
+
+	// Keep the saved timezone unless a new one is given
+	if prefs.Timezone == "" {
+		existing, err := s.GetPreferences(ctx, prefs.WorkerID)
+		if err != nil {
+			return err
+		}
+		prefs.Timezone = existing.Timezone
+	}
+	if _, err := time.LoadLocation(prefs.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPreferences, prefs.Timezone)
+	}
+
+	return s.profileRepo.UpsertPreferences(ctx, prefs)
+}
+
+// GetAvailability returns the worker's weekly windows and upcoming blackout dates
+func (s *WorkerProfileService) GetAvailability(ctx context.Context, workerID int64) (*entity.WorkerAvailability, error) {
+	return s.profileRepo.GetAvailability(ctx, workerID)
+}
+
+// UpdateAvailability validates and replaces the worker's availability calendar
+func (s *WorkerProfileService) UpdateAvailability(ctx context.Context, av *entity.WorkerAvailability) error {
+	// Keep the saved timezone unless a new one is given
+	if av.Timezone == "" {
+		existing, err := s.profileRepo.GetAvailability(ctx, av.WorkerID)
+		if err != nil {
+			return err
+		}
+		av.Timezone = existing.Timezone
+	}
+	if _, err := time.LoadLocation(av.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidAvailability, av.Timezone)
+	}
+
+	for _, w := range av.Windows {
+		if w.Weekday < 0 || w.Weekday > 6 {
+			return fmt.Errorf("%w: weekday must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidAvailability)
+		}
+		if w.StartMinute < 0 || w.EndMinute > minutesPerDay || w.EndMinute <= w.StartMinute {
+			return fmt.Errorf("%w: windows need 0 <= start_minute < end_minute <= 1440", ErrInvalidAvailability)
+		}
+	}
+
+	seen := make(map[string]bool, len(av.Blackouts))
+	for _, b := range av.Blackouts {
+		if _, err := time.Parse("2006-01-02", b.Date); err != nil {
+			return fmt.Errorf("%w: blackout date %q must be YYYY-MM-DD", ErrInvalidAvailability, b.Date)
+		}
+		if seen[b.Date] {
+			return fmt.Errorf("%w: blackout date %s listed twice", ErrInvalidAvailability, b.Date)
+		}
+		seen[b.Date] = true
+	}
+
+	return s.profileRepo.SetAvailability(ctx, av)
+}
@@ -11,7 +11,10 @@ export const useSocketStore = defineStore('socket', () => {
     if (socket && socket.readyState === WebSocket.OPEN) return;
 
     console.log("🔌 Connecting to WebSocket...");
-    socket = new WebSocket('ws://localhost:8080/ws');
+    // Signed-in clients also get the messages meant only for them
+    const token = localStorage.getItem('token');
+    const url = token ? `ws://localhost:8080/ws?token=${encodeURIComponent(token)}` : 'ws://localhost:8080/ws';
+    socket = new WebSocket(url);
 
     socket.onopen = () => {
       console.log("✅ WebSocket Connected!");